@@ -1,15 +1,50 @@
 package account
 
 import (
+	"errors"
+	"math/big"
+
 	"github.com/yu-org/JingChou/udt"
 )
 
+var (
+	ErrAccountNotFound     = errors.New("account not found")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+)
+
 type Account struct {
-	Owner   string                   `json:"owner"`
-	UDTs    map[udt.TokenID]*udt.UDT `json:"udts"`
-	Scripts []string                 `json:"scripts"`
+	Owner    string                   `json:"owner"`
+	UDTs     map[udt.TokenID]*udt.UDT `json:"udts"`
+	Balances map[udt.TokenID]*big.Int `json:"balances,omitempty"`
+	Scripts  []string                 `json:"scripts"`
 }
 
 func (a *Account) VerifyOwner(args []byte) error {
 	return nil
 }
+
+func (a *Account) Balance(token udt.TokenID) *big.Int {
+	if balance, ok := a.Balances[token]; ok && balance != nil {
+		return new(big.Int).Set(balance)
+	}
+	return big.NewInt(0)
+}
+
+func (a *Account) AddBalance(token udt.TokenID, amount *big.Int) {
+	if a.Balances == nil {
+		a.Balances = make(map[udt.TokenID]*big.Int)
+	}
+	a.Balances[token] = new(big.Int).Add(a.Balance(token), amount)
+}
+
+func (a *Account) SubBalance(token udt.TokenID, amount *big.Int) error {
+	balance := a.Balance(token)
+	if balance.Cmp(amount) < 0 {
+		return ErrInsufficientBalance
+	}
+	if a.Balances == nil {
+		a.Balances = make(map[udt.TokenID]*big.Int)
+	}
+	a.Balances[token] = balance.Sub(balance, amount)
+	return nil
+}
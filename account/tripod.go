@@ -65,9 +65,10 @@ func (a *AccountTripod) ClaimAccount(ctx *context.WriteContext) error {
 		return errors.New("owner-script is not the same")
 	}
 	claimed := &Account{
-		Owner:   req.Owner,
-		UDTs:    oldAccount.UDTs,
-		Scripts: []string{req.Owner},
+		Owner:    req.Owner,
+		UDTs:     oldAccount.UDTs,
+		Balances: oldAccount.Balances,
+		Scripts:  []string{req.Owner},
 	}
 	byt, err := json.Marshal(claimed)
 	if err != nil {
@@ -134,12 +135,78 @@ func (a *AccountTripod) AddUDT(ctx *context.WriteContext) error {
 	return a.UDT.AddUdt(req.UDT)
 }
 
+// VerifyOwner checks the owner args against the owner script of account id.
+func (a *AccountTripod) VerifyOwner(id string, args []byte) error {
+	acc, err := a.getAccount(id)
+	if err != nil {
+		return err
+	}
+	return acc.VerifyOwner(args)
+}
+
+func (a *AccountTripod) GetBalance(id string, token udt.TokenID) (*big.Int, error) {
+	acc, err := a.loadAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	return acc.Balance(token), nil
+}
+
+// AddBalance credits amount of token to account id, the account is created if it does not exist.
+func (a *AccountTripod) AddBalance(id string, token udt.TokenID, amount *big.Int) error {
+	if amount.Sign() < 0 {
+		return errors.New("amount is negative")
+	}
+	acc, err := a.loadAccount(id)
+	if err != nil {
+		return err
+	}
+	acc.AddBalance(token, amount)
+	return a.setAccount(acc)
+}
+
+// SubBalance debits amount of token from account id.
+func (a *AccountTripod) SubBalance(id string, token udt.TokenID, amount *big.Int) error {
+	if amount.Sign() < 0 {
+		return errors.New("amount is negative")
+	}
+	acc, err := a.loadAccount(id)
+	if err != nil {
+		return err
+	}
+	if err = acc.SubBalance(token, amount); err != nil {
+		return err
+	}
+	return a.setAccount(acc)
+}
+
 func (a *AccountTripod) getAccount(id string) (*Account, error) {
 	accountByt, err := a.Get([]byte(id))
 	if err != nil {
 		return nil, err
 	}
+	if accountByt == nil {
+		return nil, ErrAccountNotFound
+	}
 	account := new(Account)
 	err = json.Unmarshal(accountByt, account)
 	return account, err
 }
+
+// loadAccount is like getAccount, but returns an empty account if id does not exist.
+func (a *AccountTripod) loadAccount(id string) (*Account, error) {
+	acc, err := a.getAccount(id)
+	if errors.Is(err, ErrAccountNotFound) {
+		return &Account{Owner: id, UDTs: make(map[udt.TokenID]*udt.UDT)}, nil
+	}
+	return acc, err
+}
+
+func (a *AccountTripod) setAccount(acc *Account) error {
+	byt, err := json.Marshal(acc)
+	if err != nil {
+		return err
+	}
+	a.Set([]byte(acc.Owner), byt)
+	return nil
+}
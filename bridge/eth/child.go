@@ -0,0 +1,276 @@
+package eth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+	"github.com/yu-org/yu/apps/eth/evm"
+	evmtypes "github.com/yu-org/yu/apps/eth/types"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+// childBridge is the JingChouChildBridge contract on the L2 EVM, driven through the Solidity tripod.
+type childBridge struct {
+	address  ethcommon.Address
+	key      *ecdsa.PrivateKey
+	relayer  ethcommon.Address
+	signer   ethtypes.Signer
+	abi      *abi.ABI
+	filterer *contracts.JingChouChildBridgeFilterer
+	gasLimit uint64
+	gasPrice *big.Int
+	// nonce is the next EVM nonce of the relayer, loaded from the state of the last block once per block.
+	nonce *uint64
+	// finalized holds the EVM transactions of the current block that finalized a deposit, by deposit nonce.
+	// The EVM state is not rolled back with a discarded writing, so such a deposit stays finalized on the child-layer
+	// bridge even if the writing that finalized it was discarded.
+	finalized map[uint64]ethcommon.Hash
+}
+
+func newChildBridge(cfg *Config) (*childBridge, error) {
+	if !ethcommon.IsHexAddress(cfg.ChildLayerContractAddress) {
+		return nil, errors.New("invalid childlayer_contract_address")
+	}
+	if cfg.ChildLayerChainID == 0 {
+		return nil, errors.New("no childlayer_chain_id configured")
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.ChildLayerRelayerKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid childlayer_relayer_key: %v", err)
+	}
+	bridgeAbi, err := contracts.JingChouChildBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	address := ethcommon.HexToAddress(cfg.ChildLayerContractAddress)
+	filterer, err := contracts.NewJingChouChildBridgeFilterer(address, nil)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := cfg.ChildLayerGasLimit
+	if gasLimit == 0 {
+		gasLimit = DefaultChildLayerGasLimit
+	}
+	return &childBridge{
+		address:   address,
+		key:       key,
+		relayer:   crypto.PubkeyToAddress(key.PublicKey),
+		signer:    ethtypes.NewEIP155Signer(new(big.Int).SetUint64(cfg.ChildLayerChainID)),
+		abi:       bridgeAbi,
+		filterer:  filterer,
+		gasLimit:  gasLimit,
+		gasPrice:  new(big.Int).SetUint64(cfg.ChildLayerGasPrice),
+		finalized: make(map[uint64]ethcommon.Hash),
+	}, nil
+}
+
+// isChildRecipient tells whether a deposit goes to an EVM address through the child-layer bridge.
+func (eth *EthRelayer) isChildRecipient(recipient string) bool {
+	return eth.child != nil && ethcommon.IsHexAddress(recipient)
+}
+
+// finalizeOnChild calls JingChouChildBridge.finalizeDeposit in the block as the relayer,
+// and returns the hash of the EVM transaction once it succeeded.
+// The BridgedERC20 created on the first deposit of a token takes the name, symbol and decimals of its mapping.
+func (eth *EthRelayer) finalizeOnChild(deposit *Deposit, mapping *registry.TokenMapping, block *types.Block) (ethcommon.Hash, error) {
+	data, err := eth.child.abi.Pack(
+		"finalizeDeposit",
+		new(big.Int).SetUint64(deposit.Nonce),
+		ethcommon.HexToAddress(deposit.L1Token),
+		ethcommon.HexToAddress(deposit.Recipient),
+		deposit.Amount,
+		mapping.Name,
+		mapping.Symbol,
+		mapping.Decimals,
+	)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	nonce, err := eth.childNonce()
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	tx, err := ethtypes.SignTx(ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    nonce,
+		To:       &eth.child.address,
+		Gas:      eth.child.gasLimit,
+		GasPrice: eth.child.gasPrice,
+		Value:    big.NewInt(0),
+		Data:     data,
+	}), eth.child.signer, eth.child.key)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	receipt, err := eth.executeOnChild(tx, block)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	// the nonce is used once the transaction is applied, even if it reverted
+	nonce++
+	eth.child.nonce = &nonce
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return ethcommon.Hash{}, fmt.Errorf("finalizeDeposit reverted in EVM tx(%s)", tx.Hash().Hex())
+	}
+	eth.child.finalized[deposit.Nonce] = tx.Hash()
+	return tx.Hash(), nil
+}
+
+// childFinalized tells whether the child-layer bridge has finalized the deposit nonce, either in the state
+// of the last block or by an EVM transaction of the current block, which it returns then.
+func (eth *EthRelayer) childFinalized(nonce uint64) (bool, ethcommon.Hash, error) {
+	if txHash, ok := eth.child.finalized[nonce]; ok {
+		return true, txHash, nil
+	}
+	last, err := eth.GetCurrentBlock()
+	if err != nil {
+		return false, ethcommon.Hash{}, err
+	}
+	state, err := eth.Solidity.StateAt(ethcommon.Hash(last.StateRoot))
+	if err != nil {
+		return false, ethcommon.Hash{}, err
+	}
+	return state.GetState(eth.child.address, depositFinalizedSlot(nonce)) != (ethcommon.Hash{}), ethcommon.Hash{}, nil
+}
+
+// depositFinalizedSlot is the storage slot of depositFinalized[nonce] in JingChouChildBridge,
+// whose mapping depositFinalized is the state variable at slot 1.
+func depositFinalizedSlot(nonce uint64) ethcommon.Hash {
+	return crypto.Keccak256Hash(
+		ethcommon.BigToHash(new(big.Int).SetUint64(nonce)).Bytes(),
+		ethcommon.BigToHash(big.NewInt(1)).Bytes(),
+	)
+}
+
+// executeOnChild runs a signed EVM transaction through the ExecuteTxn writing of the Solidity tripod.
+func (eth *EthRelayer) executeOnChild(tx *ethtypes.Transaction, block *types.Block) (*ethtypes.Receipt, error) {
+	v, r, s := tx.RawSignatureValues()
+	gas := hexutil.Uint64(tx.Gas())
+	nonce := hexutil.Uint64(tx.Nonce())
+	input := hexutil.Bytes(tx.Data())
+	req := &evm.TxRequest{
+		V: v,
+		R: r,
+		S: s,
+		TxArgs: &evmtypes.TransactionArgs{
+			From:     &eth.child.relayer,
+			To:       tx.To(),
+			Gas:      &gas,
+			GasPrice: (*hexutil.Big)(tx.GasPrice()),
+			Value:    (*hexutil.Big)(tx.Value()),
+			Nonce:    &nonce,
+			Input:    &input,
+		},
+	}
+	params, err := req.Encode()
+	if err != nil {
+		return nil, err
+	}
+	stxn, err := types.NewSignedTxn(&common.WrCall{
+		TripodName: eth.Solidity.Name(),
+		FuncName:   "ExecuteTxn",
+		Params:     string(params),
+	}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := yucontext.NewWriteContext(stxn, block, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err = eth.Solidity.ExecuteTxn(ctx); err != nil {
+		return nil, err
+	}
+	return decodeEthReceipt(ctx.Extra)
+}
+
+// childNonce returns the next EVM nonce of the relayer.
+func (eth *EthRelayer) childNonce() (uint64, error) {
+	if eth.child.nonce != nil {
+		return *eth.child.nonce, nil
+	}
+	last, err := eth.GetCurrentBlock()
+	if err != nil {
+		return 0, err
+	}
+	state, err := eth.Solidity.StateAt(ethcommon.Hash(last.StateRoot))
+	if err != nil {
+		return 0, err
+	}
+	nonce := state.GetNonce(eth.child.relayer)
+	eth.child.nonce = &nonce
+	return nonce, nil
+}
+
+// collectChildWithdrawals turns the WithdrawalInitiated events of the child-layer bridge in the EVM transactions
+// the previous block executed into withdrawals of the block, the same as the Withdraw writing.
+// The receipts of a block exist only once its state is committed, so they are read in the StartBlock of the next one.
+func (eth *EthRelayer) collectChildWithdrawals(block *types.Block) error {
+	if block.Height == 0 {
+		return nil
+	}
+	prev, err := eth.Chain.GetBlock(block.PrevHash)
+	if err != nil {
+		return err
+	}
+	for _, stxn := range prev.Txns {
+		if stxn.Raw.WrCall.TripodName != eth.Solidity.Name() {
+			continue
+		}
+		yuReceipt, err := eth.TxDB.GetReceipt(stxn.TxnHash)
+		if err != nil {
+			return err
+		}
+		if yuReceipt == nil || yuReceipt.Extra == nil {
+			continue
+		}
+		receipt, err := decodeEthReceipt(yuReceipt.Extra)
+		if err != nil {
+			return err
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if log.Address != eth.child.address || len(log.Topics) == 0 ||
+				log.Topics[0] != eth.child.abi.Events["WithdrawalInitiated"].ID {
+				continue
+			}
+			event, err := eth.child.filterer.ParseWithdrawalInitiated(*log)
+			if err != nil {
+				return err
+			}
+			err = eth.withdrawOrDelay(&Withdrawal{
+				From:      event.Sender.Hex(),
+				Recipient: event.L1Recipient.Hex(),
+				Token:     BridgedTokenID(event.L1Token),
+				L1Token:   event.L1Token.Hex(),
+				Amount:    event.Amount,
+				Height:    block.Height,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeEthReceipt(extra []byte) (*ethtypes.Receipt, error) {
+	receipt := new(ethtypes.Receipt)
+	err := json.NewDecoder(bytes.NewBuffer(extra)).Decode(receipt)
+	return receipt, err
+}
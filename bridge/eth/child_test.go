@@ -0,0 +1,31 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+)
+
+// TestDepositFinalizedSlot checks the slot childFinalized reads against JingChouChildBridge on a simulated chain.
+func TestDepositFinalizedSlot(t *testing.T) {
+	r := newTestRelayer(t)
+	opts := *r.alice
+	opts.GasLimit = 5_000_000
+	address, tx, child, err := contracts.DeployJingChouChildBridge(&opts, r.client, r.alice.From)
+	mustOk(t, err)
+	r.mine(t, tx)
+	tx, err = child.FinalizeDeposit(&opts, big.NewInt(3), ethcommon.Address{}, r.alice.From, big.NewInt(100), "Ether", "ETH", DefaultDecimals)
+	mustOk(t, err)
+	r.mine(t, tx)
+
+	for nonce, want := range map[uint64]bool{2: false, 3: true, 4: false} {
+		value, err := r.client.StorageAt(context.Background(), address, depositFinalizedSlot(nonce), nil)
+		mustOk(t, err)
+		if finalized := ethcommon.BytesToHash(value) != (ethcommon.Hash{}); finalized != want {
+			t.Fatalf("deposit %d read as finalized %v, want %v", nonce, finalized, want)
+		}
+	}
+}
@@ -1,7 +1,61 @@
 package eth
 
+import "math/big"
+
 type Config struct {
 	L1ClientAddress            string `toml:"l1_client_address"`
 	ParentLayerContractAddress string `toml:"parentlayer_contract_address"`
-	ChildLayerContractAddress  string `toml:"childlayer_contract_address"`
+	// ChildLayerContractAddress is the JingChouChildBridge on the L2 EVM, the deposits to an EVM address are
+	// finalized through it. Empty means every deposit is minted as a UDT.
+	ChildLayerContractAddress string `toml:"childlayer_contract_address"`
+	// ChildLayerRelayerKey is the hex private key of the relayer of JingChouChildBridge, its account pays the gas.
+	ChildLayerRelayerKey string `toml:"childlayer_relayer_key"`
+	// ChildLayerChainID is the chain id of the L2 EVM the relayer signs for.
+	ChildLayerChainID uint64 `toml:"childlayer_chain_id"`
+	// ChildLayerGasLimit is the gas of each deposit finalization, 0 means DefaultChildLayerGasLimit.
+	ChildLayerGasLimit uint64 `toml:"childlayer_gas_limit"`
+	// ChildLayerGasPrice is the gas price of each deposit finalization in wei, at least the base fee of the L2 EVM.
+	ChildLayerGasPrice uint64 `toml:"childlayer_gas_price"`
+
+	// NativeL1Token is the JingChouToken the parent-layer bridge mints on L1 for the native token, its nativeToken.
+	// Empty means the native token can not be bridged.
+	NativeL1Token string `toml:"native_l1_token"`
+
+	// Confirmations is how many L1 blocks must be built on top of a deposit before it is final and relayed.
+	Confirmations uint64 `toml:"confirmations"`
+	// UseFinalizedTag takes an L1 block as final once the L1 consensus finalizes it, the "finalized" block tag,
+	// instead of after Confirmations blocks.
+	UseFinalizedTag bool `toml:"use_finalized_tag"`
+	// StartL1Height is the L1 block the parent-layer bridge contract was deployed at, the first one scanned.
+	StartL1Height uint64 `toml:"start_l1_height"`
+	// MaxL1BlocksPerScan bounds the L1 blocks scanned for deposits in one L2 block, 0 means DefaultMaxL1BlocksPerScan.
+	MaxL1BlocksPerScan uint64 `toml:"max_l1_blocks_per_scan"`
+	// WithdrawalBatchSize is the L2 blocks of a withdrawal tree, the same as block_batch_size_for_prove of the zkrollup
+	// so that each proven batch carries the root of its withdrawals. It must be set.
+	WithdrawalBatchSize uint64 `toml:"withdrawal_batch_size"`
+	// ForceInclusionBlocks is how many L1 blocks after its queueing a forced transaction must be included on L2,
+	// 0 means DefaultForceInclusionBlocks. A block which leaves out an overdue forced transaction is rejected.
+	ForceInclusionBlocks uint64 `toml:"force_inclusion_blocks"`
+
+	// RateLimits caps the amounts of each L1 token bridged in a rolling window, keyed by the L1 token address,
+	// the zero address for ETH. A token without a RateLimit is not capped.
+	RateLimits map[string]*RateLimit `toml:"rate_limits"`
+	// Guardian is the account allowed to release the delayed deposits and withdrawals before their delay ends.
+	Guardian string `toml:"guardian"`
 }
+
+type RateLimit struct {
+	// Window is the L2 blocks of the rolling window.
+	Window uint64 `toml:"window"`
+	// DepositCap and WithdrawalCap bound the amounts relayed in a window, nil means uncapped.
+	DepositCap    *big.Int `toml:"deposit_cap"`
+	WithdrawalCap *big.Int `toml:"withdrawal_cap"`
+	// Delay is how many L2 blocks an amount above its cap waits in the delayed queue before it is released.
+	Delay uint64 `toml:"delay"`
+}
+
+const (
+	DefaultMaxL1BlocksPerScan   = 1000
+	DefaultChildLayerGasLimit   = 500000
+	DefaultForceInclusionBlocks = 100
+)
@@ -0,0 +1,361 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+// Deposit is a deposit observed on L1, relayed to the L2 account Recipient as Token.
+type Deposit struct {
+	Nonce     uint64      `json:"nonce"`
+	Sender    string      `json:"sender"`
+	L1Token   string      `json:"l1_token"`
+	Token     udt.TokenID `json:"token"`
+	Recipient string      `json:"recipient"`
+	Amount    *big.Int    `json:"amount"`
+	L1Height  uint64      `json:"l1_height"`
+	// L1BlockHash is the L1 block of the deposit, a pending deposit is dropped if it is reorganized out.
+	L1BlockHash string `json:"l1_block_hash"`
+	L1TxHash    string `json:"l1_tx_hash"`
+	// FinalizedOnChild tells that the deposit was finalized on the child-layer bridge instead of minted as a UDT.
+	FinalizedOnChild bool `json:"finalized_on_child,omitempty"`
+	// ChildTxHash is the finalizeDeposit transaction on the L2 EVM, empty if it was executed in an earlier block
+	// by a RelayL1 writing that was discarded.
+	ChildTxHash string `json:"child_tx_hash,omitempty"`
+	// Bounced tells that the token is off the allowlist or can not be mapped, the deposit was withdrawn back to Sender on L1.
+	Bounced bool `json:"bounced,omitempty"`
+	// Delayed tells that the deposit exceeded the deposit cap of its token, it waits in the delayed queue to be minted.
+	Delayed bool `json:"delayed,omitempty"`
+}
+
+// RelayState is how far the L1 chain has been relayed.
+type RelayState struct {
+	// L1Height is the last final L1 block relayed, L1BlockHash is its hash.
+	L1Height    uint64 `json:"l1_height"`
+	L1BlockHash string `json:"l1_block_hash,omitempty"`
+	// NextDepositNonce is the nonce of the next deposit to relay, every deposit below it is included.
+	NextDepositNonce uint64 `json:"next_deposit_nonce"`
+}
+
+// BridgedTokenID is the UDT minted on L2 for the L1 token, ETH is the zero address.
+func BridgedTokenID(token ethcommon.Address) udt.TokenID {
+	if token == (ethcommon.Address{}) {
+		return "L1-ETH"
+	}
+	return udt.TokenID("L1-" + token.Hex())
+}
+
+// nextL1Height is the first L1 block not relayed yet.
+func (eth *EthRelayer) nextL1Height(state *RelayState) uint64 {
+	return max(state.L1Height+1, eth.cfg.StartL1Height)
+}
+
+func (eth *EthRelayer) maxL1BlocksPerScan() uint64 {
+	if eth.cfg.MaxL1BlocksPerScan == 0 {
+		return DefaultMaxL1BlocksPerScan
+	}
+	return eth.cfg.MaxL1BlocksPerScan
+}
+
+// fetchDeposits returns the DepositInitiated events of the parent-layer bridge contract in the L1 blocks [from, to].
+func (eth *EthRelayer) fetchDeposits(ctx context.Context, from, to uint64) ([]*Deposit, error) {
+	filterer, err := contracts.NewJingChouBridgeFilterer(ethcommon.HexToAddress(eth.cfg.ParentLayerContractAddress), eth.ethCli)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := filterer.FilterDepositInitiated(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var deposits []*Deposit
+	for iter.Next() {
+		event := iter.Event
+		if event.Raw.Removed {
+			continue
+		}
+		if !event.Nonce.IsUint64() {
+			return nil, fmt.Errorf("deposit nonce overflows in L1 tx(%s)", event.Raw.TxHash.Hex())
+		}
+		deposits = append(deposits, &Deposit{
+			Nonce:       event.Nonce.Uint64(),
+			Sender:      event.Sender.Hex(),
+			L1Token:     event.Token.Hex(),
+			Token:       BridgedTokenID(event.Token),
+			Recipient:   event.Recipient,
+			Amount:      event.Amount,
+			L1Height:    event.Raw.BlockNumber,
+			L1BlockHash: event.Raw.BlockHash.Hex(),
+			L1TxHash:    event.Raw.TxHash.Hex(),
+		})
+	}
+	return deposits, iter.Error()
+}
+
+// applyDeposits mints deposits and moves the relay state to the L1 block scannedTo of hash scannedHash,
+// metadata holds the tokens of the deposits without a mapping yet.
+// It fails if the deposits after the relayed nonce are not consecutive. Every deposit is minted, delayed
+// or bounced, see mintDeposit, so that no deposit can stop the relay; any other error fails the RelayL1 writing
+// as a whole, and the L1 blocks are relayed again by the next proposal.
+func (eth *EthRelayer) applyDeposits(
+	state *RelayState,
+	deposits []*Deposit,
+	metadata map[ethcommon.Address]*registry.TokenMapping,
+	scannedTo uint64,
+	scannedHash string,
+	block *types.Block,
+) error {
+	sort.SliceStable(deposits, func(i, j int) bool { return deposits[i].Nonce < deposits[j].Nonce })
+	var pending []*Deposit
+	next := state.NextDepositNonce
+	for _, deposit := range deposits {
+		if deposit.Nonce < next {
+			continue
+		}
+		if deposit.Nonce > next {
+			return fmt.Errorf("deposit nonce %d is missing before L1 block %d", next, deposit.L1Height)
+		}
+		pending = append(pending, deposit)
+		next++
+	}
+	for _, deposit := range pending {
+		if err := eth.mintDeposit(deposit, metadata, block); err != nil {
+			return err
+		}
+		state.NextDepositNonce = deposit.Nonce + 1
+	}
+	state.L1Height = scannedTo
+	state.L1BlockHash = scannedHash
+	return eth.setRelayState(state)
+}
+
+// mintDeposit credits a deposit within the deposit cap of its token and delays the one above it.
+// A deposit of a token off the allowlist, or of a token that can not be mapped to a UDT, e.g. because
+// its UDT name is taken, is bounced, so that its nonce is never skipped.
+// A deposit of the JingChouToken unlocks the native token instead, see mintNativeDeposit.
+func (eth *EthRelayer) mintDeposit(deposit *Deposit, metadata map[ethcommon.Address]*registry.TokenMapping, block *types.Block) error {
+	l1Token := ethcommon.HexToAddress(deposit.L1Token)
+	if eth.isNativeL1Token(l1Token) {
+		return eth.mintNativeDeposit(deposit, block)
+	}
+	allowed, err := eth.Registry.IsAllowed(eth.origin(l1Token))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return eth.bounceDeposit(deposit, block)
+	}
+	mapping, err := eth.tokenMapping(l1Token, metadata)
+	if err != nil {
+		logrus.Warnf("map the L1 token %s of deposit(%d) failed, bounce it: %v", l1Token.Hex(), deposit.Nonce, err)
+		return eth.bounceDeposit(deposit, block)
+	}
+	deposit.Token = mapping.Token
+	admitted, err := eth.admit(DepositDirection, l1Token, deposit.Amount, block.Height)
+	if err != nil {
+		return err
+	}
+	if !admitted {
+		deposit.Delayed = true
+		return eth.delayTransfer(&DelayedTransfer{Deposit: deposit}, block.Height)
+	}
+	return eth.creditDeposit(deposit, mapping, block)
+}
+
+// creditDeposit finalizes a deposit to an EVM address on the child-layer bridge, and mints any other deposit
+// as the bridged UDT. A deposit the child-layer bridge fails to finalize is minted as the UDT instead.
+// The EVM state is not rolled back with a discarded writing, so a deposit relayed again may have been finalized
+// by the discarded attempt already: it is only recorded then, and never minted as the UDT.
+func (eth *EthRelayer) creditDeposit(deposit *Deposit, mapping *registry.TokenMapping, block *types.Block) error {
+	if eth.isChildRecipient(deposit.Recipient) {
+		finalized, txHash, err := eth.childFinalized(deposit.Nonce)
+		if err != nil {
+			return err
+		}
+		if !finalized {
+			if txHash, err = eth.finalizeOnChild(deposit, mapping, block); err != nil {
+				logrus.Warnf("finalize deposit(%d) on the child-layer bridge failed, mint it as UDT: %v", deposit.Nonce, err)
+			}
+			finalized = err == nil
+		}
+		if finalized {
+			deposit.FinalizedOnChild = true
+			if txHash != (ethcommon.Hash{}) {
+				deposit.ChildTxHash = txHash.Hex()
+			}
+			return bridge.SetJson(eth, depositKey(deposit.Nonce), deposit)
+		}
+	}
+	token, err := eth.bridgedUdt(mapping)
+	if err != nil {
+		return err
+	}
+	token.Total.Add(token.Total, deposit.Amount)
+	token.Issued.Add(token.Issued, deposit.Amount)
+	if err = eth.UDT.AddUdt(token); err != nil {
+		return err
+	}
+	if err = eth.Account.AddBalance(deposit.Recipient, deposit.Token, deposit.Amount); err != nil {
+		return err
+	}
+	return bridge.SetJson(eth, depositKey(deposit.Nonce), deposit)
+}
+
+// bounceDeposit withdraws a deposit back to its L1 sender in the block,
+// claimable on the parent-layer bridge like any other withdrawal.
+func (eth *EthRelayer) bounceDeposit(deposit *Deposit, block *types.Block) error {
+	err := eth.appendWithdrawal(&Withdrawal{
+		From:      deposit.Sender,
+		Recipient: deposit.Sender,
+		Token:     deposit.Token,
+		L1Token:   deposit.L1Token,
+		Amount:    deposit.Amount,
+		Height:    block.Height,
+	})
+	if err != nil {
+		return err
+	}
+	deposit.Bounced = true
+	return bridge.SetJson(eth, depositKey(deposit.Nonce), deposit)
+}
+
+// tokenMapping returns the mapping of an L1 token, registering it with its metadata on its first deposit.
+func (eth *EthRelayer) tokenMapping(l1Token ethcommon.Address, metadata map[ethcommon.Address]*registry.TokenMapping) (*registry.TokenMapping, error) {
+	origin := eth.origin(l1Token)
+	mapping, err := eth.Registry.Lookup(origin)
+	if err != nil || mapping != nil {
+		return mapping, err
+	}
+	mapping, ok := metadata[l1Token]
+	if !ok {
+		return nil, fmt.Errorf("no metadata of L1 token %s", l1Token.Hex())
+	}
+	mapping.Origin = origin
+	mapping.Token = BridgedTokenID(l1Token)
+	return mapping, eth.Registry.Register(mapping)
+}
+
+// origin is the L1 token as the original token of its bridged UDT.
+func (eth *EthRelayer) origin(l1Token ethcommon.Address) *udt.ChainToken {
+	return &udt.ChainToken{
+		ChainURL:     eth.chainURL,
+		TokenAddress: l1Token.Bytes(),
+	}
+}
+
+// bridgedUdt loads the UDT of a mapped L1 token, creating it on its first deposit.
+func (eth *EthRelayer) bridgedUdt(mapping *registry.TokenMapping) (*udt.UDT, error) {
+	if eth.UDT.Exist([]byte(mapping.Token)) {
+		return eth.UDT.GetUdt(mapping.Token)
+	}
+	description := "bridged from " + eth.chainURL
+	if mapping.Name != "" {
+		description = fmt.Sprintf("%s (%s) %s", mapping.Name, mapping.Symbol, description)
+	}
+	return &udt.UDT{
+		Name:          mapping.Token,
+		Creator:       eth.Name(),
+		Description:   description,
+		OriginalToken: mapping.Origin,
+		Total:         big.NewInt(0),
+		Locked:        big.NewInt(0),
+		Issued:        big.NewInt(0),
+	}, nil
+}
+
+// GetDeposit returns the relayed Deposit of nonce.
+func (eth *EthRelayer) GetDeposit(ctx *yucontext.ReadContext) {
+	nonce, err := strconv.ParseUint(ctx.GetString("nonce"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	byt, err := eth.Get(depositKey(nonce))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	if byt == nil {
+		ctx.ErrOk(errors.New("deposit not relayed"))
+		return
+	}
+	deposit := new(Deposit)
+	if err = json.Unmarshal(byt, deposit); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(deposit)
+}
+
+// DepositCount returns how many L1 deposits were relayed by the L2 block height.
+// The zkrollup puts it into the public values of the batch proof, the L1 bridge refunds the deposits beyond it
+// if the rollup halts.
+func (eth *EthRelayer) DepositCount(height common.BlockNum) (uint64, error) {
+	var count uint64
+	_, err := bridge.GetJson(eth, depositCountKey(height), &count)
+	return count, err
+}
+
+func (eth *EthRelayer) recordDepositCount(height common.BlockNum) error {
+	state, err := eth.getRelayState()
+	if err != nil {
+		return err
+	}
+	return bridge.SetJson(eth, depositCountKey(height), state.NextDepositNonce)
+}
+
+func (eth *EthRelayer) GetRelayState(ctx *yucontext.ReadContext) {
+	state, err := eth.getRelayState()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(state)
+}
+
+var relayStateKey = []byte("relay_state")
+
+func depositCountKey(height common.BlockNum) []byte {
+	return []byte("deposit_count/" + strconv.FormatUint(uint64(height), 10))
+}
+
+func depositKey(nonce uint64) []byte {
+	return []byte("deposit/" + strconv.FormatUint(nonce, 10))
+}
+
+func (eth *EthRelayer) getRelayState() (*RelayState, error) {
+	byt, err := eth.Get(relayStateKey)
+	if err != nil {
+		return nil, err
+	}
+	state := new(RelayState)
+	if byt != nil {
+		err = json.Unmarshal(byt, state)
+	}
+	return state, err
+}
+
+func (eth *EthRelayer) setRelayState(state *RelayState) error {
+	byt, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	eth.Set(relayStateKey, byt)
+	return nil
+}
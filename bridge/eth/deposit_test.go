@@ -0,0 +1,49 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/bridge/registry"
+)
+
+// TestUnmintableDepositIsBounced relays a deposit of an L1 token without its metadata, which can not be mapped
+// to a UDT; it is bounced to its sender and the deposit after it is still minted.
+func TestUnmintableDepositIsBounced(t *testing.T) {
+	r := newTestRelayer(t)
+	token := ethcommon.Address{7}
+	deposits := []*Deposit{
+		{Nonce: 0, Sender: r.alice.From.Hex(), L1Token: token.Hex(), Token: BridgedTokenID(token), Recipient: "alice", Amount: big.NewInt(5)},
+		{Nonce: 1, Sender: r.alice.From.Hex(), L1Token: (ethcommon.Address{}).Hex(), Token: BridgedTokenID(ethcommon.Address{}), Recipient: "alice", Amount: big.NewInt(100)},
+	}
+	metadata := map[ethcommon.Address]*registry.TokenMapping{
+		{}: {Name: "Ether", Symbol: "ETH", Decimals: DefaultDecimals},
+	}
+	state, err := r.getRelayState()
+	mustOk(t, err)
+	mustOk(t, r.state.Execute(func() error {
+		return r.applyDeposits(state, deposits, metadata, 10, "", newBlock(1))
+	}))
+
+	state, err = r.getRelayState()
+	mustOk(t, err)
+	if state.NextDepositNonce != 2 || state.L1Height != 10 {
+		t.Fatalf("relay state %+v, want the relay past both deposits", state)
+	}
+	bounced := new(Deposit)
+	r.load(t, depositKey(0), bounced)
+	if !bounced.Bounced {
+		t.Fatal("the unmintable deposit was not bounced")
+	}
+	withdrawal := new(Withdrawal)
+	r.load(t, withdrawalKey(0), withdrawal)
+	if withdrawal.Recipient != r.alice.From.Hex() || withdrawal.Amount.Int64() != 5 {
+		t.Fatalf("bounce %+v, want 5 back to the sender", withdrawal)
+	}
+	balance, err := r.Account.GetBalance("alice", BridgedTokenID(ethcommon.Address{}))
+	mustOk(t, err)
+	if balance.Int64() != 100 {
+		t.Fatalf("alice has %s, want 100 from the deposit after the bounce", balance)
+	}
+}
@@ -0,0 +1,105 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yu-org/JingChou/bridge/registry"
+)
+
+const erc20MetadataAbiJson = `[
+	{"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+var erc20MetadataAbi, _ = abi.JSON(strings.NewReader(erc20MetadataAbiJson))
+
+// DefaultDecimals is the decimals of a token without the decimals() method, the same as ETH.
+const DefaultDecimals = 18
+
+// fetchTokenMetadata reads the name, symbol and decimals of each L1 token of the deposits not mapped yet
+// at the L1 block height relayed up to, so that their mappings are registered without calling L1 in the middle
+// of minting, and every node reads the same metadata.
+func (eth *EthRelayer) fetchTokenMetadata(ctx context.Context, deposits []*Deposit, height uint64) (map[ethcommon.Address]*registry.TokenMapping, error) {
+	metadata := make(map[ethcommon.Address]*registry.TokenMapping)
+	for _, deposit := range deposits {
+		l1Token := ethcommon.HexToAddress(deposit.L1Token)
+		if _, ok := metadata[l1Token]; ok {
+			continue
+		}
+		mapping, err := eth.Registry.Lookup(eth.origin(l1Token))
+		if err != nil {
+			return nil, err
+		}
+		if mapping != nil {
+			continue
+		}
+		if metadata[l1Token], err = eth.fetchErc20Metadata(ctx, l1Token, height); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// fetchErc20Metadata reads the name, symbol and decimals of an L1 token at the L1 block height,
+// ETH is the zero address.
+func (eth *EthRelayer) fetchErc20Metadata(ctx context.Context, l1Token ethcommon.Address, height uint64) (*registry.TokenMapping, error) {
+	if l1Token == (ethcommon.Address{}) {
+		return &registry.TokenMapping{Name: "Ether", Symbol: "ETH", Decimals: DefaultDecimals}, nil
+	}
+	var err error
+	at := new(big.Int).SetUint64(height)
+	mapping := &registry.TokenMapping{Decimals: DefaultDecimals}
+	if mapping.Name, err = eth.callErc20String(ctx, l1Token, "name", at); err != nil {
+		return nil, err
+	}
+	if mapping.Symbol, err = eth.callErc20String(ctx, l1Token, "symbol", at); err != nil {
+		return nil, err
+	}
+	output, err := eth.callErc20(ctx, l1Token, "decimals", at)
+	if err != nil {
+		return nil, err
+	}
+	if values, err := erc20MetadataAbi.Unpack("decimals", output); err == nil {
+		mapping.Decimals = values[0].(uint8)
+	}
+	return mapping, nil
+}
+
+// callErc20String calls a string method of the ERC-20, also accepting the bytes32 some early tokens return.
+func (eth *EthRelayer) callErc20String(ctx context.Context, token ethcommon.Address, method string, at *big.Int) (string, error) {
+	output, err := eth.callErc20(ctx, token, method, at)
+	if err != nil {
+		return "", err
+	}
+	if values, err := erc20MetadataAbi.Unpack(method, output); err == nil {
+		return values[0].(string), nil
+	}
+	if len(output) == 32 {
+		return string(bytes.TrimRight(output, "\x00")), nil
+	}
+	return "", nil
+}
+
+// callErc20 calls an optional metadata method of the ERC-20 at the L1 block number at.
+// A token reverting or lacking the method returns no output, only failing to reach L1 is an error.
+func (eth *EthRelayer) callErc20(ctx context.Context, token ethcommon.Address, method string, at *big.Int) ([]byte, error) {
+	input, err := erc20MetadataAbi.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	output, err := eth.ethCli.CallContract(ctx, ethereum.CallMsg{To: &token, Data: input}, at)
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return nil, nil
+	}
+	return output, err
+}
@@ -0,0 +1,349 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+// ForcedTxn is an L2 transaction queued by forceTransaction of the parent-layer bridge contract,
+// for when the block producer censors it. An L2 block must include it by the L1 block Deadline.
+type ForcedTxn struct {
+	Index    uint64 `json:"index"`
+	Sender   string `json:"sender"`
+	Txn      []byte `json:"txn"`
+	L1Height uint64 `json:"l1_height"`
+	L1TxHash string `json:"l1_tx_hash"`
+	// ID is the TxnHash the txn has when created by types.NewSignedTxn, it identifies the txn in a block.
+	ID common.Hash `json:"id"`
+	// Deadline is the last relayed L1 height the forced txn may stay out of the L2 blocks.
+	Deadline uint64 `json:"deadline"`
+	// Invalid is why the txn can not be included at all, such a forced txn is not enforced.
+	Invalid string `json:"invalid,omitempty"`
+	// IncludedAt is the L2 block which included the forced txn.
+	IncludedAt common.BlockNum `json:"included_at,omitempty"`
+}
+
+// ForcedQueue is the queue of the forced txns relayed from L1.
+type ForcedQueue struct {
+	// Next is the index of the next forced txn to relay.
+	Next uint64 `json:"next"`
+	// Pending are the indexes of the forced txns relayed but not included yet.
+	Pending []uint64 `json:"pending"`
+}
+
+// fetchForcedTxns returns the ForcedTransactionQueued events of the parent-layer bridge contract in the L1 blocks [from, to].
+func (eth *EthRelayer) fetchForcedTxns(ctx context.Context, from, to uint64) ([]*ForcedTxn, error) {
+	filterer, err := contracts.NewJingChouBridgeFilterer(ethcommon.HexToAddress(eth.cfg.ParentLayerContractAddress), eth.ethCli)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := filterer.FilterForcedTransactionQueued(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var forced []*ForcedTxn
+	for iter.Next() {
+		event := iter.Event
+		if event.Raw.Removed {
+			continue
+		}
+		if !event.Index.IsUint64() {
+			return nil, fmt.Errorf("forced txn index overflows in L1 tx(%s)", event.Raw.TxHash.Hex())
+		}
+		forced = append(forced, &ForcedTxn{
+			Index:    event.Index.Uint64(),
+			Sender:   event.Sender.Hex(),
+			Txn:      event.Txn,
+			L1Height: event.Raw.BlockNumber,
+			L1TxHash: event.Raw.TxHash.Hex(),
+		})
+	}
+	return forced, iter.Error()
+}
+
+// newForcedTxns returns the forced txns after the queue in index order, an error if an index is missing.
+func (eth *EthRelayer) newForcedTxns(forced []*ForcedTxn) ([]*ForcedTxn, error) {
+	queue, err := eth.getForcedQueue()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(forced, func(i, j int) bool { return forced[i].Index < forced[j].Index })
+	var fresh []*ForcedTxn
+	next := queue.Next
+	for _, txn := range forced {
+		if txn.Index < next {
+			continue
+		}
+		if txn.Index > next {
+			return nil, fmt.Errorf("forced txn %d is missing before L1 block %d", next, txn.L1Height)
+		}
+		fresh = append(fresh, txn)
+		next++
+	}
+	return fresh, nil
+}
+
+// queueForcedTxns appends the forced txns to the queue, each due ForceInclusionBlocks L1 blocks after its queueing.
+// A txn that can not be decoded or checked is kept as invalid, nothing could include it. It is only checked
+// against what all nodes share, not the txpool limit of this node.
+func (eth *EthRelayer) queueForcedTxns(forced []*ForcedTxn) error {
+	if len(forced) == 0 {
+		return nil
+	}
+	queue, err := eth.getForcedQueue()
+	if err != nil {
+		return err
+	}
+	window := eth.cfg.ForceInclusionBlocks
+	if window == 0 {
+		window = DefaultForceInclusionBlocks
+	}
+	for _, txn := range forced {
+		txn.Deadline = txn.L1Height + window
+		stxn, err := decodeForcedTxn(txn.Txn)
+		if err == nil {
+			txn.ID = stxn.TxnHash
+			err = eth.checkForcedTxn(stxn)
+		}
+		if err != nil {
+			txn.Invalid = err.Error()
+		} else {
+			queue.Pending = append(queue.Pending, txn.Index)
+		}
+		if err = bridge.SetJson(eth, forcedTxnKey(txn.Index), txn); err != nil {
+			return err
+		}
+		queue.Next = txn.Index + 1
+	}
+	return bridge.SetJson(eth, forcedQueueKey, queue)
+}
+
+// includeForcedTxns marks the pending forced txns in the block as included.
+func (eth *EthRelayer) includeForcedTxns(block *types.Block) error {
+	queue, err := eth.getForcedQueue()
+	if err != nil || len(queue.Pending) == 0 {
+		return err
+	}
+	ids, err := blockTxnIDs(block)
+	if err != nil {
+		return err
+	}
+	pending := queue.Pending[:0]
+	for _, index := range queue.Pending {
+		txn, err := eth.getForcedTxn(index)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(ids, txn.ID) {
+			pending = append(pending, index)
+			continue
+		}
+		txn.IncludedAt = block.Height
+		if err = bridge.SetJson(eth, forcedTxnKey(index), txn); err != nil {
+			return err
+		}
+	}
+	queue.Pending = pending
+	return bridge.SetJson(eth, forcedQueueKey, queue)
+}
+
+// submitForcedTxns puts the pending forced txns into the txpool, for the block producer to pack them.
+func (eth *EthRelayer) submitForcedTxns() error {
+	queue, err := eth.getForcedQueue()
+	if err != nil {
+		return err
+	}
+	for _, index := range queue.Pending {
+		txn, err := eth.getForcedTxn(index)
+		if err != nil {
+			return err
+		}
+		if eth.Pool.Exist(txn.ID) {
+			continue
+		}
+		stxn, err := decodeForcedTxn(txn.Txn)
+		if err != nil {
+			return err
+		}
+		if err = eth.Pool.Insert(stxn); err != nil {
+			logrus.Debugf("insert forced txn(%d) into txpool failed: %v", index, err)
+		}
+	}
+	return nil
+}
+
+// overdueForcedTxns returns the pending forced txns overdue at the relayed L1 height in index order,
+// every one of them must be in the next block.
+func (eth *EthRelayer) overdueForcedTxns() ([]*ForcedTxn, error) {
+	queue, err := eth.getForcedQueue()
+	if err != nil || len(queue.Pending) == 0 {
+		return nil, err
+	}
+	state, err := eth.getRelayState()
+	if err != nil {
+		return nil, err
+	}
+	var overdue []*ForcedTxn
+	for _, index := range queue.Pending {
+		txn, err := eth.getForcedTxn(index)
+		if err != nil {
+			return nil, err
+		}
+		if state.L1Height > txn.Deadline {
+			overdue = append(overdue, txn)
+		}
+	}
+	return overdue, nil
+}
+
+// prioritizeForcedTxns puts the pending forced txns into the txpool and sorts the overdue ones to its head in index order,
+// so that the block producer packs them into the next block before sealing it. Only a RelayL1 proposal,
+// sorted afterwards, goes before them.
+func (eth *EthRelayer) prioritizeForcedTxns() error {
+	if err := eth.submitForcedTxns(); err != nil {
+		return err
+	}
+	overdue, err := eth.overdueForcedTxns()
+	if err != nil || len(overdue) == 0 {
+		return err
+	}
+	ranks := make(map[common.Hash]int, len(overdue))
+	for i, txn := range overdue {
+		ranks[txn.ID] = i
+	}
+	eth.Pool.SortTxns(func(txns []*types.SignedTxn) []*types.SignedTxn {
+		rankOf := make(map[*types.SignedTxn]int, len(txns))
+		for _, stxn := range txns {
+			rankOf[stxn] = len(ranks)
+			if id, err := forcedTxnID(stxn); err == nil {
+				if rank, ok := ranks[id]; ok {
+					rankOf[stxn] = rank
+				}
+			}
+		}
+		slices.SortStableFunc(txns, func(a, b *types.SignedTxn) int {
+			return rankOf[a] - rankOf[b]
+		})
+		return txns
+	})
+	return nil
+}
+
+// verifyForcedTxns rejects a block that leaves out a forced txn overdue at the relayed L1 height.
+func (eth *EthRelayer) verifyForcedTxns(block *types.Block) error {
+	overdue, err := eth.overdueForcedTxns()
+	if err != nil || len(overdue) == 0 {
+		return err
+	}
+	ids, err := blockTxnIDs(block)
+	if err != nil {
+		return err
+	}
+	for _, txn := range overdue {
+		if !slices.Contains(ids, txn.ID) {
+			return fmt.Errorf("block(%d) leaves out forced txn %d, overdue since L1 block %d", block.Height, txn.Index, txn.Deadline)
+		}
+	}
+	return nil
+}
+
+func (eth *EthRelayer) GetForcedTxn(ctx *yucontext.ReadContext) {
+	index, err := strconv.ParseUint(ctx.GetString("index"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	txn, err := eth.getForcedTxn(index)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(txn)
+}
+
+func (eth *EthRelayer) GetForcedQueue(ctx *yucontext.ReadContext) {
+	queue, err := eth.getForcedQueue()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(queue)
+}
+
+// checkForcedTxn makes sure the forced txn calls an existing writing which its tripod accepts.
+func (eth *EthRelayer) checkForcedTxn(stxn *types.SignedTxn) error {
+	if _, err := eth.Land.GetWriting(stxn.Raw.WrCall.TripodName, stxn.Raw.WrCall.FuncName); err != nil {
+		return err
+	}
+	return eth.Pool.NecessaryCheck(stxn)
+}
+
+// decodeForcedTxn decodes an encoded types.SignedTxn, giving it the TxnHash types.NewSignedTxn would.
+func decodeForcedTxn(byt []byte) (*types.SignedTxn, error) {
+	stxn, err := types.DecodeSignedTxn(byt)
+	if err != nil {
+		return nil, err
+	}
+	if stxn.Raw == nil || stxn.Raw.WrCall == nil {
+		return nil, errors.New("forced txn calls no writing")
+	}
+	stxn.TxnHash, err = forcedTxnID(stxn)
+	return stxn, err
+}
+
+// forcedTxnID hashes the txn without its TxnHash, which tripods like Solidity replace in the txpool.
+func forcedTxnID(stxn *types.SignedTxn) (common.Hash, error) {
+	return (&types.SignedTxn{Raw: stxn.Raw, Pubkey: stxn.Pubkey, Signature: stxn.Signature}).GenerateHash()
+}
+
+func blockTxnIDs(block *types.Block) ([]common.Hash, error) {
+	ids := make([]common.Hash, 0, len(block.Txns))
+	for _, stxn := range block.Txns {
+		id, err := forcedTxnID(stxn)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+var forcedQueueKey = []byte("forced_queue")
+
+func forcedTxnKey(index uint64) []byte {
+	return []byte("forced/" + strconv.FormatUint(index, 10))
+}
+
+func (eth *EthRelayer) getForcedQueue() (*ForcedQueue, error) {
+	queue := new(ForcedQueue)
+	_, err := bridge.GetJson(eth, forcedQueueKey, queue)
+	return queue, err
+}
+
+func (eth *EthRelayer) getForcedTxn(index uint64) (*ForcedTxn, error) {
+	byt, err := eth.Get(forcedTxnKey(index))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, errors.New("forced txn not found")
+	}
+	txn := new(ForcedTxn)
+	err = json.Unmarshal(byt, txn)
+	return txn, err
+}
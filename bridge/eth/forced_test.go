@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/tripod"
+	"github.com/yu-org/yu/core/types"
+)
+
+func (r *testRelayer) withdrawTxn(t *testing.T, params string) *types.SignedTxn {
+	t.Helper()
+	stxn, err := types.NewSignedTxn(&common.WrCall{TripodName: r.Name(), FuncName: "Withdraw", Params: params}, nil, nil, nil)
+	mustOk(t, err)
+	return stxn
+}
+
+func TestOverdueForcedTxnPackedFirst(t *testing.T) {
+	r := newTestRelayer(t)
+	r.SetInstance(r.EthRelayer)
+	land := tripod.NewLand()
+	land.SetTripods(r.Tripod)
+	r.SetLand(land)
+	r.pool.WithTripodCheck(r.Name(), r.EthRelayer)
+
+	forced := r.withdrawTxn(t, `{"forced":true}`)
+	byt, err := forced.Encode()
+	mustOk(t, err)
+	mustOk(t, r.state.Execute(func() error {
+		if err := r.queueForcedTxns([]*ForcedTxn{{Index: 0, Txn: byt, L1Height: 1}}); err != nil {
+			return err
+		}
+		return r.setRelayState(&RelayState{L1Height: 1 + DefaultForceInclusionBlocks})
+	}))
+	other := r.withdrawTxn(t, `{}`)
+	mustOk(t, r.pool.Insert(other))
+
+	// the forced txn is due but not overdue yet
+	mustOk(t, r.prioritizeForcedTxns())
+	if err = r.VerifyBlock(&types.Block{Header: &types.Header{Height: 2}, Txns: types.SignedTxns{other}}); err != nil {
+		t.Fatalf("rejected a block before the forced txn is overdue: %v", err)
+	}
+
+	mustOk(t, r.state.Execute(func() error {
+		return r.setRelayState(&RelayState{L1Height: 2 + DefaultForceInclusionBlocks})
+	}))
+	mustOk(t, r.prioritizeForcedTxns())
+	txns, err := r.pool.Pack(1)
+	mustOk(t, err)
+	if len(txns) != 1 || txns[0].Raw.WrCall.Params != forced.Raw.WrCall.Params {
+		t.Fatal("the overdue forced txn is not at the head of the txpool")
+	}
+	err = r.VerifyBlock(&types.Block{Header: &types.Header{Height: 3}, Txns: types.SignedTxns{other}})
+	if err == nil || !strings.Contains(err.Error(), "leaves out forced txn 0") {
+		t.Fatalf("accepted a block leaving out the overdue forced txn: %v", err)
+	}
+	mustOk(t, r.VerifyBlock(&types.Block{Header: &types.Header{Height: 3}, Txns: types.SignedTxns{txns[0], other}}))
+}
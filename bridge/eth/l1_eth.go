@@ -1,17 +1,67 @@
 package eth
 
 import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/udt"
 	"github.com/yu-org/yu/apps/eth/evm"
 	"github.com/yu-org/yu/core/tripod"
 	"github.com/yu-org/yu/core/types"
 )
 
+// EthRelayer is the bridge.Relayer of Ethereum. It relays the deposits of the parent-layer bridge contract on L1 to L2,
+// minting the bridged UDT of each L1 token, mapped one-to-one in the token registry, to the recipient account,
+// and burns the bridged UDTs withdrawn back to L1 into per-batch withdrawal trees.
+// The native token is locked instead when withdrawn, as the JingChouToken minted on L1, and unlocked when it comes back.
+//
+// With a child-layer bridge configured, the deposits to an EVM address are finalized on JingChouChildBridge
+// through the Solidity tripod, and its WithdrawalInitiated events join the same withdrawal trees.
+// It must then be registered after the Solidity tripod: its StartBlock and RelayL1 writing execute EVM transactions
+// in the state Solidity has started. The EVM withdrawals of a block join the withdrawals of the next one,
+// whose StartBlock reads the receipts of the block before.
+//
+// The final L1 blocks are relayed by the RelayL1 txn the block producer proposes, which every node checks
+// against its own L1 as a BlockVerifier, see RelayDeposits.
+// The forced txns queued on L1 are put into the txpool, the ones overdue at its head, and every node rejects
+// a block which leaves out an overdue one as a BlockVerifier, so that the block producer can not censor an L2 transaction.
+//
+// The deposits and withdrawals of a token above its configured rate limit wait in a delayed queue,
+// released once their delay ends or earlier by the guardian.
 type EthRelayer struct {
 	*tripod.Tripod
 	cfg      *Config
-	Solidity evm.Solidity `tripod:"solidity"`
-	ethCli   *ethclient.Client
+	Solidity *evm.Solidity           `tripod:"solidity"`
+	UDT      *udt.UdtTripod          `tripod:"udt"`
+	Account  *account.AccountTripod  `tripod:"account"`
+	Registry *registry.TokenRegistry `tripod:"tokenregistry"`
+	// Poa tells whether this node produces the next block, without it every node proposes the RelayL1 txns.
+	Poa    bridge.Leader `tripod:"poa,omitempty"`
+	ethCli L1Client
+	// chainURL identifies the L1 chain in the OriginalToken of bridged UDTs.
+	chainURL string
+	// child is nil if no child-layer bridge is configured.
+	child *childBridge
+	// proposal is the last RelayL1 txn this node put into its txpool.
+	proposal bridge.Proposal
+	// pending is read by GetPendingDeposits while FinalizeBlock tracks it.
+	pendingLock sync.RWMutex
+	pending     PendingDeposits
+}
+
+// L1Client reads the L1 chain, an *ethclient.Client or the client of a simulated backend in tests.
+type L1Client interface {
+	bind.ContractBackend
+	ethereum.BlockNumberReader
+	ethereum.ChainIDReader
 }
 
 func NewETHRelayer(cfg *Config) (*EthRelayer, error) {
@@ -19,21 +69,81 @@ func NewETHRelayer(cfg *Config) (*EthRelayer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &EthRelayer{
-		Tripod: tripod.NewTripod(),
-		cfg:    cfg,
-		ethCli: ethCli,
-	}, nil
+	return NewETHRelayerWithClient(cfg, ethCli)
 }
 
-func (eth *EthRelayer) StartBlock(block *types.Block) {
-
+// NewETHRelayerWithClient is NewETHRelayer reading L1 through ethCli instead of dialing L1ClientAddress.
+func NewETHRelayerWithClient(cfg *Config, ethCli L1Client) (*EthRelayer, error) {
+	// without batches no withdrawal proof could be produced, the burned tokens could never be claimed
+	if cfg.WithdrawalBatchSize == 0 {
+		return nil, errors.New("no withdrawal_batch_size configured")
+	}
+	chainID, err := ethCli.ChainID(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	eth := &EthRelayer{
+		Tripod:   tripod.NewTripod(),
+		cfg:      cfg,
+		ethCli:   ethCli,
+		chainURL: "eip155:" + chainID.String(),
+	}
+	if cfg.ChildLayerContractAddress != "" {
+		if eth.child, err = newChildBridge(cfg); err != nil {
+			return nil, err
+		}
+	}
+	eth.SetWritings(eth.Withdraw, eth.ReleaseDelayed, eth.RelayL1)
+	eth.SetReadings(
+		eth.GetDeposit, eth.GetRelayState, eth.GetWithdrawalProof, eth.GetWithdrawalProofs,
+		eth.GetForcedTxn, eth.GetForcedQueue,
+		eth.GetRateLimitUsage, eth.GetDelayedTransfer, eth.GetDelayedQueue, eth.GetHealth, eth.GetPendingDeposits,
+		eth.GetNativeSupply,
+	)
+	return eth, nil
 }
 
-func (eth *EthRelayer) EndBlock(block *types.Block) {
-
+// StartBlock marks the forced txns of the block as included, and includes the due delayed transfers
+// and child-layer withdrawals in it.
+// Its writes are sealed in a stash of their own, so that they are not discarded with the first txn of the block.
+func (eth *EthRelayer) StartBlock(block *types.Block) {
+	if eth.child != nil {
+		eth.child.nonce = nil
+		clear(eth.child.finalized)
+		if err := eth.collectChildWithdrawals(block); err != nil {
+			logrus.Errorf("collect child-layer withdrawals of block(%d) failed: %v", block.Height-1, err)
+		}
+	}
+	if err := eth.includeForcedTxns(block); err != nil {
+		logrus.Errorf("include forced txns in block(%d) failed: %v", block.Height, err)
+	}
+	if err := eth.releaseDueTransfers(block); err != nil {
+		logrus.Errorf("release delayed transfers in block(%d) failed: %v", block.Height, err)
+	}
+	if err := eth.recordDepositCount(block.Height); err != nil {
+		logrus.Errorf("record deposit count of block(%d) failed: %v", block.Height, err)
+	}
+	eth.NextTxn()
 }
 
+func (eth *EthRelayer) EndBlock(block *types.Block) {}
+
 func (eth *EthRelayer) FinalizeBlock(block *types.Block) {
+	if err := eth.trackPendingDeposits(context.Background()); err != nil {
+		logrus.Errorf("track pending deposits after block(%d) failed: %v", block.Height, err)
+	}
+	if err := eth.prioritizeForcedTxns(); err != nil {
+		logrus.Errorf("submit forced txns after block(%d) failed: %v", block.Height, err)
+	}
+	if err := eth.RelayDeposits(block); err != nil {
+		logrus.Errorf("propose relaying L1 blocks after block(%d) failed: %v", block.Height, err)
+	}
+}
 
+// VerifyBlock rejects a block relaying anything but what L1 has, or leaving out an overdue forced txn.
+func (eth *EthRelayer) VerifyBlock(block *types.Block) error {
+	if err := eth.verifyRelay(block); err != nil {
+		return err
+	}
+	return eth.verifyForcedTxns(block)
 }
@@ -0,0 +1,24 @@
+package eth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStartBlockWritesSurviveDiscard(t *testing.T) {
+	r := newTestRelayer(t)
+	r.deposit(t, 100, "alice")
+	r.commit(confirmations)
+	r.apply(t, r.propose(t, 1))
+
+	r.StartBlock(newBlock(3))
+	failed := errors.New("first txn of the block failed")
+	if err := r.state.Execute(func() error { return failed }); !errors.Is(err, failed) {
+		t.Fatal(err)
+	}
+	count, err := r.getUint(depositCountKey(3))
+	mustOk(t, err)
+	if count != 1 || !r.Exist(depositCountKey(3)) {
+		t.Fatalf("deposit count of block 3 is %d after the discard, want 1", count)
+	}
+}
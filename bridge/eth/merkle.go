@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"bytes"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MerkleRoot returns the root of the tree over leaves, built the way OpenZeppelin's MerkleProof verifies it:
+// each pair is hashed in sorted order and an unpaired node is carried to the next level.
+// The root of no leaves is the zero hash.
+func MerkleRoot(leaves []ethcommon.Hash) ethcommon.Hash {
+	if len(leaves) == 0 {
+		return ethcommon.Hash{}
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// MerkleProof returns the sibling hashes from the leaf at index up to the root.
+func MerkleProof(leaves []ethcommon.Hash, index int) []ethcommon.Hash {
+	var proof []ethcommon.Hash
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof
+}
+
+// VerifyMerkleProof checks proof of leaf against root, the same as MerkleProof.verify on L1.
+func VerifyMerkleProof(root, leaf ethcommon.Hash, proof []ethcommon.Hash) bool {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = hashPair(computed, sibling)
+	}
+	return computed == root
+}
+
+func nextLevel(level []ethcommon.Hash) []ethcommon.Hash {
+	next := make([]ethcommon.Hash, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, hashPair(level[i], level[i+1]))
+	}
+	return next
+}
+
+func hashPair(a, b ethcommon.Hash) ethcommon.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+}
@@ -0,0 +1,64 @@
+package eth
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testLeaves(n int) []ethcommon.Hash {
+	leaves := make([]ethcommon.Hash, n)
+	for i := range leaves {
+		leaves[i] = crypto.Keccak256Hash([]byte{byte(i)})
+	}
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := testLeaves(3)
+	tests := []struct {
+		name   string
+		leaves []ethcommon.Hash
+		want   ethcommon.Hash
+	}{
+		{name: "no leaves", want: ethcommon.Hash{}},
+		{name: "single leaf", leaves: leaves[:1], want: leaves[0]},
+		{name: "pair", leaves: leaves[:2], want: hashPair(leaves[0], leaves[1])},
+		// the unpaired third leaf is carried up unhashed
+		{name: "odd count", leaves: leaves, want: hashPair(hashPair(leaves[0], leaves[1]), leaves[2])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MerkleRoot(tt.leaves); got != tt.want {
+				t.Fatalf("root %s, want %s", got.Hex(), tt.want.Hex())
+			}
+		})
+	}
+	if hashPair(leaves[0], leaves[1]) != hashPair(leaves[1], leaves[0]) {
+		t.Fatal("pairs are not hashed in sorted order")
+	}
+}
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := testLeaves(n)
+		root := MerkleRoot(leaves)
+		for i, leaf := range leaves {
+			proof := MerkleProof(leaves, i)
+			if !VerifyMerkleProof(root, leaf, proof) {
+				t.Fatalf("proof of leaf %d of %d does not verify", i, n)
+			}
+			if VerifyMerkleProof(root, crypto.Keccak256Hash(leaf.Bytes()), proof) {
+				t.Fatalf("proof of leaf %d of %d verifies another leaf", i, n)
+			}
+		}
+	}
+	if proof := MerkleProof(testLeaves(1), 0); len(proof) != 0 {
+		t.Fatalf("proof of a single leaf has %d hashes, want none", len(proof))
+	}
+	// the last of 5 leaves is carried up twice, its proof has the root of the first 4 only
+	if proof := MerkleProof(testLeaves(5), 4); len(proof) != 1 || proof[0] != MerkleRoot(testLeaves(4)) {
+		t.Fatalf("proof of the unpaired leaf: %v", proof)
+	}
+}
@@ -0,0 +1,124 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+// NativeSupply is the native token bridged to L1 against the supply left on L2.
+type NativeSupply struct {
+	// L1Token is the JingChouToken on L1, empty if the native token can not be bridged.
+	L1Token string   `json:"l1_token"`
+	Total   *big.Int `json:"total"`
+	Issued  *big.Int `json:"issued"`
+	// Bridged is the amount locked on L2, the same as the supply of the JingChouToken once every withdrawal is claimed.
+	Bridged *big.Int `json:"bridged"`
+	// L2Supply is the issued amount not bridged.
+	L2Supply *big.Int `json:"l2_supply"`
+}
+
+// isNativeL1Token tells whether the L1 token is the JingChouToken of the native token.
+func (eth *EthRelayer) isNativeL1Token(l1Token ethcommon.Address) bool {
+	return eth.cfg.NativeL1Token != "" && ethcommon.HexToAddress(eth.cfg.NativeL1Token) == l1Token
+}
+
+// nativeUdt loads the native token, it is not in the state until it is bridged first.
+func (eth *EthRelayer) nativeUdt() (*udt.UDT, error) {
+	if eth.UDT.Exist([]byte(udt.NativeToken.Name)) {
+		return eth.UDT.GetUdt(udt.NativeToken.Name)
+	}
+	native := udt.NativeToken
+	native.Total = new(big.Int).Set(udt.NativeToken.Total)
+	native.Locked = new(big.Int).Set(udt.NativeToken.Locked)
+	native.Issued = new(big.Int).Set(udt.NativeToken.Issued)
+	return &native, nil
+}
+
+// withdrawNative locks the native token on L2 and withdraws it as the JingChouToken minted on L1 when claimed.
+func (eth *EthRelayer) withdrawNative(req *WithdrawRequest, height common.BlockNum) error {
+	if eth.cfg.NativeL1Token == "" {
+		return errors.New("native token is not bridged to L1")
+	}
+	if err := eth.Account.SubBalance(req.FromID, req.Token, req.Amount); err != nil {
+		return err
+	}
+	native, err := eth.nativeUdt()
+	if err != nil {
+		return err
+	}
+	native.Locked.Add(native.Locked, req.Amount)
+	if err = eth.UDT.AddUdt(native); err != nil {
+		return err
+	}
+	return eth.withdrawOrDelay(&Withdrawal{
+		From:      req.FromID,
+		Recipient: ethcommon.HexToAddress(req.Recipient).Hex(),
+		Token:     req.Token,
+		L1Token:   ethcommon.HexToAddress(eth.cfg.NativeL1Token).Hex(),
+		Amount:    req.Amount,
+		Height:    height,
+	})
+}
+
+// mintNativeDeposit unlocks a deposit of the JingChouToken burned on L1 within its deposit cap,
+// and delays the one above it. The native token has no mapping in the registry and is always allowed.
+func (eth *EthRelayer) mintNativeDeposit(deposit *Deposit, block *types.Block) error {
+	deposit.Token = udt.NativeToken.Name
+	admitted, err := eth.admit(DepositDirection, ethcommon.HexToAddress(deposit.L1Token), deposit.Amount, block.Height)
+	if err != nil {
+		return err
+	}
+	if !admitted {
+		deposit.Delayed = true
+		return eth.delayTransfer(&DelayedTransfer{Deposit: deposit}, block.Height)
+	}
+	return eth.unlockNative(deposit, block)
+}
+
+// unlockNative credits a deposit of the native token to its recipient from the amount locked on L2.
+// A deposit above the locked amount can only come from a JingChouToken minted by no withdrawal, it is bounced.
+func (eth *EthRelayer) unlockNative(deposit *Deposit, block *types.Block) error {
+	native, err := eth.nativeUdt()
+	if err != nil {
+		return err
+	}
+	if native.Locked.Cmp(deposit.Amount) < 0 {
+		logrus.Warnf("native deposit(%d) of %s exceeds the locked %s, bounce it", deposit.Nonce, deposit.Amount, native.Locked)
+		return eth.bounceDeposit(deposit, block)
+	}
+	native.Locked.Sub(native.Locked, deposit.Amount)
+	if err = eth.UDT.AddUdt(native); err != nil {
+		return err
+	}
+	if err = eth.Account.AddBalance(deposit.Recipient, deposit.Token, deposit.Amount); err != nil {
+		return err
+	}
+	return bridge.SetJson(eth, depositKey(deposit.Nonce), deposit)
+}
+
+// GetNativeSupply returns the native token bridged to L1 against its supply on L2.
+func (eth *EthRelayer) GetNativeSupply(ctx *yucontext.ReadContext) {
+	native, err := eth.nativeUdt()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	supply := &NativeSupply{
+		Total:    native.Total,
+		Issued:   native.Issued,
+		Bridged:  native.Locked,
+		L2Supply: new(big.Int).Sub(native.Issued, native.Locked),
+	}
+	if eth.cfg.NativeL1Token != "" {
+		supply.L1Token = ethcommon.HexToAddress(eth.cfg.NativeL1Token).Hex()
+	}
+	ctx.JsonOk(supply)
+}
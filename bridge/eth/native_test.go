@@ -0,0 +1,118 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+)
+
+var nativeL1Token = ethcommon.Address{0x77}
+
+func (r *testRelayer) nativeSupply(t *testing.T) *NativeSupply {
+	t.Helper()
+	ctx, err := yucontext.NewReadContext(&common.RdCall{})
+	mustOk(t, err)
+	r.GetNativeSupply(ctx)
+	supply, ok := ctx.Response().DataInterface.(*NativeSupply)
+	if !ok {
+		t.Fatalf("GetNativeSupply responded %v", ctx.Response().DataInterface)
+	}
+	return supply
+}
+
+// relayNative relays a deposit of the JingChouToken burned on L1 in the block height.
+func (r *testRelayer) relayNative(t *testing.T, nonce uint64, amount int64, height common.BlockNum) {
+	t.Helper()
+	state, err := r.getRelayState()
+	mustOk(t, err)
+	deposit := &Deposit{Nonce: nonce, Sender: r.alice.From.Hex(), L1Token: nativeL1Token.Hex(), Recipient: "alice", Amount: big.NewInt(amount)}
+	mustOk(t, r.state.Execute(func() error {
+		return r.applyDeposits(state, []*Deposit{deposit}, nil, uint64(height), "", newBlock(height))
+	}))
+}
+
+func TestNativeLockAndUnlock(t *testing.T) {
+	r := newTestRelayer(t)
+	r.cfg.NativeL1Token = nativeL1Token.Hex()
+	mustOk(t, r.Account.AddBalance("alice", udt.NativeToken.Name, big.NewInt(1_000)))
+	balance := func() int64 {
+		balance, err := r.Account.GetBalance("alice", udt.NativeToken.Name)
+		mustOk(t, err)
+		return balance.Int64()
+	}
+
+	mustOk(t, r.state.Execute(func() error {
+		return r.withdrawNative(&WithdrawRequest{FromID: "alice", Token: udt.NativeToken.Name, Amount: big.NewInt(300), Recipient: r.alice.From.Hex()}, 1)
+	}))
+	if got := balance(); got != 700 {
+		t.Fatalf("alice has %d after withdrawing 300, want 700", got)
+	}
+	withdrawal := new(Withdrawal)
+	r.load(t, withdrawalKey(0), withdrawal)
+	if withdrawal.L1Token != nativeL1Token.Hex() || withdrawal.Amount.Int64() != 300 {
+		t.Fatalf("withdrawal %+v, want 300 of the JingChouToken", withdrawal)
+	}
+	if supply := r.nativeSupply(t); supply.Bridged.Int64() != 300 || supply.L1Token != nativeL1Token.Hex() {
+		t.Fatalf("native supply %+v after the withdrawal, want 300 bridged", supply)
+	}
+
+	r.relayNative(t, 0, 200, 2)
+	if got := balance(); got != 900 {
+		t.Fatalf("alice has %d after the deposit of 200, want 900", got)
+	}
+	supply := r.nativeSupply(t)
+	if supply.Bridged.Int64() != 100 {
+		t.Fatalf("%s bridged after the deposit, want 100", supply.Bridged)
+	}
+	if want := new(big.Int).Sub(udt.NativeToken.Issued, big.NewInt(100)); supply.L2Supply.Cmp(want) != 0 || supply.Issued.Cmp(udt.NativeToken.Issued) != 0 {
+		t.Fatalf("native supply %+v, want %s on L2", supply, want)
+	}
+}
+
+// TestNativeDepositAboveLockedIsBounced deposits more JingChouToken than was ever withdrawn from L2,
+// which no withdrawal can have minted; it is bounced instead of unlocking native tokens that are not locked.
+func TestNativeDepositAboveLockedIsBounced(t *testing.T) {
+	r := newTestRelayer(t)
+	r.cfg.NativeL1Token = nativeL1Token.Hex()
+	mustOk(t, r.Account.AddBalance("alice", udt.NativeToken.Name, big.NewInt(100)))
+	mustOk(t, r.state.Execute(func() error {
+		return r.withdrawNative(&WithdrawRequest{FromID: "alice", Token: udt.NativeToken.Name, Amount: big.NewInt(100), Recipient: r.alice.From.Hex()}, 1)
+	}))
+
+	r.relayNative(t, 0, 500, 2)
+	deposit := new(Deposit)
+	r.load(t, depositKey(0), deposit)
+	if !deposit.Bounced {
+		t.Fatal("the deposit above the locked amount was not bounced")
+	}
+	bounce := new(Withdrawal)
+	r.load(t, withdrawalKey(1), bounce)
+	if bounce.Recipient != r.alice.From.Hex() || bounce.Amount.Int64() != 500 {
+		t.Fatalf("bounce %+v, want 500 back to the sender", bounce)
+	}
+	if balance, err := r.Account.GetBalance("alice", udt.NativeToken.Name); err != nil || balance.Sign() != 0 {
+		t.Fatalf("alice has %v after the bounce: %v", balance, err)
+	}
+	if supply := r.nativeSupply(t); supply.Bridged.Int64() != 100 {
+		t.Fatalf("%s bridged after the bounce, want the 100 still locked", supply.Bridged)
+	}
+}
+
+func TestNativeNotBridged(t *testing.T) {
+	r := newTestRelayer(t)
+	mustOk(t, r.Account.AddBalance("alice", udt.NativeToken.Name, big.NewInt(100)))
+	err := r.state.Execute(func() error {
+		return r.withdrawNative(&WithdrawRequest{FromID: "alice", Token: udt.NativeToken.Name, Amount: big.NewInt(100), Recipient: r.alice.From.Hex()}, 1)
+	})
+	if err == nil {
+		t.Fatal("withdrew the native token without a JingChouToken on L1")
+	}
+	supply := r.nativeSupply(t)
+	if supply.L1Token != "" || supply.Bridged.Sign() != 0 || supply.L2Supply.Cmp(udt.NativeToken.Issued) != 0 {
+		t.Fatalf("native supply %+v, want nothing bridged", supply)
+	}
+}
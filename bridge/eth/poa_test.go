@@ -0,0 +1,114 @@
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/yu/apps/poa"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/config"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+	"github.com/yu-org/yu/core/keypair"
+	"github.com/yu-org/yu/core/tripod"
+	"github.com/yu-org/yu/core/txpool"
+	"github.com/yu-org/yu/core/types"
+	"github.com/yu-org/yu/infra/p2p"
+)
+
+// newPoaNode runs yu's PoA for the validator with secret, on chainEnv.
+func newPoaNode(secret string, validators []*poa.ValidatorConf, chainEnv *env.ChainEnv) *poa.Poa {
+	node := poa.NewPoa(&poa.PoaConfig{
+		KeyType:       keypair.Sr25519,
+		MySecret:      secret,
+		Validators:    validators,
+		BlockInterval: 200,
+		PackNum:       10,
+	})
+	node.SetChainEnv(chainEnv)
+	node.SetInstance(node)
+	return node
+}
+
+// TestForgedRelayThroughPoa has a block producer inflate a deposit in its RelayL1 txn and send the block
+// to another validator over yu's PoA, which takes it without running VerifyBlock on it.
+func TestForgedRelayThroughPoa(t *testing.T) {
+	r := newTestRelayer(t)
+	r.SetInstance(r.EthRelayer)
+	r.deposit(t, 100, "alice")
+	r.commit(confirmations)
+	proposal := r.propose(t, 2)
+	req := new(RelayL1Request)
+	mustOk(t, proposal.BindJson(req))
+	req.Deposits[0].Amount = big.NewInt(1_000_000)
+	params, err := json.Marshal(req)
+	mustOk(t, err)
+	forged, err := types.NewSignedTxn(&common.WrCall{TripodName: r.Name(), FuncName: relayL1Writing, Params: string(params)}, nil, nil, nil)
+	mustOk(t, err)
+
+	secrets := []string{"producer", "follower"}
+	validators := make([]*poa.ValidatorConf, len(secrets))
+	for i, secret := range secrets {
+		pubkey, _ := keypair.GenSrKeyWithSecret([]byte(secret))
+		validators[i] = &poa.ValidatorConf{Pubkey: pubkey.StringWithType()}
+	}
+	// PoA runs the block verifiers, its own one too, on the genesis block instead of the blocks it receives,
+	// so the genesis block is signed by a validator for the PoA check to pass.
+	genesis := newBlock(0)
+	genesis.Hash = common.Hash{1}
+	pubkey, privkey := keypair.GenSrKeyWithSecret([]byte(secrets[0]))
+	genesis.MinerPubkey = pubkey.BytesWithType()
+	genesis.MinerSignature, err = privkey.SignData(genesis.Hash.Bytes())
+	mustOk(t, err)
+	network := p2p.NewMockP2p(1)
+	network.AddTopic(common.StartBlockTopic)
+
+	producerPool := txpool.NewTxPool(common.FullNode, &config.TxpoolConf{PoolSize: 100, TxnMaxSize: 1 << 20})
+	mustOk(t, producerPool.Insert(forged))
+	producer := newPoaNode(secrets[0], validators, &env.ChainEnv{State: memstate.New(), Pool: producerPool, P2pNetwork: network})
+
+	// the follower runs the relayer under test
+	r.ChainEnv.P2pNetwork = network
+	follower := newPoaNode(secrets[1], validators, r.ChainEnv)
+	land := tripod.NewLand()
+	land.SetTripods(follower.Tripod, r.Tripod)
+	follower.SetLand(land)
+	follower.InitChain(genesis)
+
+	// block 3 is the turn of the producer
+	producer.StartBlock(newBlock(3))
+	block := newBlock(3)
+	follower.StartBlock(block)
+	if len(block.Txns) != 1 || block.Txns[0].Raw.WrCall.Params != forged.Raw.WrCall.Params {
+		t.Fatalf("follower took %d txns from the producer, want the forged relay", len(block.Txns))
+	}
+	if err = r.VerifyBlock(block); err == nil {
+		t.Fatal("VerifyBlock accepted the forged relay")
+	}
+
+	// the follower executes the block the way the kernel does
+	for i, stxn := range block.Txns {
+		writing, err := land.GetWriting(stxn.Raw.WrCall.TripodName, stxn.Raw.WrCall.FuncName)
+		mustOk(t, err)
+		ctx, err := yucontext.NewWriteContext(stxn, block, i)
+		mustOk(t, err)
+		err = r.state.Execute(func() error { return writing(ctx) })
+		if err == nil || !strings.Contains(err.Error(), "differs from L1") {
+			t.Fatalf("executed the forged relay: %v", err)
+		}
+	}
+	balance, err := r.Account.GetBalance("alice", BridgedTokenID(ethcommon.Address{}))
+	mustOk(t, err)
+	if balance.Sign() != 0 {
+		t.Fatalf("alice has %s from the forged relay", balance)
+	}
+	state, err := r.getRelayState()
+	mustOk(t, err)
+	if state.NextDepositNonce != 0 {
+		t.Fatalf("the forged relay moved the next deposit nonce to %d", state.NextDepositNonce)
+	}
+}
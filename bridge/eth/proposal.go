@@ -0,0 +1,254 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+const relayL1Writing = "RelayL1"
+
+const (
+	// finalityWait bounds how long a node whose L1 lags waits for the last L1 block of a relay to be final.
+	finalityWait = 30 * time.Second
+	finalityPoll = time.Second
+)
+
+// RelayL1Request relays the final L1 blocks [From, To] into the L2 block Height. The block producer reads them
+// from L1 and proposes them as a system txn, every node checks them against its own L1 in VerifyBlock
+// and again in the RelayL1 writing, which then applies them from the txn alone.
+type RelayL1Request struct {
+	Height      common.BlockNum `json:"height"`
+	From        uint64          `json:"from"`
+	To          uint64          `json:"to"`
+	L1BlockHash string          `json:"l1_block_hash"`
+	Deposits    []*Deposit      `json:"deposits"`
+	Forced      []*ForcedTxn    `json:"forced"`
+	// Metadata holds the tokens of the deposits without a mapping yet.
+	Metadata map[ethcommon.Address]*registry.TokenMapping `json:"metadata"`
+}
+
+// RelayDeposits proposes relaying the final L1 blocks after the relay state, at most MaxL1BlocksPerScan of them,
+// in the block after block: the node producing it reads them from L1 and puts a RelayL1 txn at the head of its txpool.
+// A deposit or forced txn relayed already is ignored, so every one is relayed exactly once;
+// a missing nonce or index fails the RelayL1 writing until the L1 logs are complete.
+func (eth *EthRelayer) RelayDeposits(block *types.Block) error {
+	if eth.Poa != nil && !eth.Poa.AmILeader(block.Height+1) {
+		return nil
+	}
+	ctx := context.Background()
+	state, err := eth.getRelayState()
+	if err != nil {
+		return err
+	}
+	if err = eth.checkCanonical(ctx, state); err != nil {
+		return err
+	}
+	confirmed, err := eth.Finalized(ctx)
+	if err != nil {
+		return err
+	}
+	from := eth.nextL1Height(state)
+	if from > confirmed {
+		return nil
+	}
+	req, err := eth.fetchRelay(ctx, from, min(from+eth.maxL1BlocksPerScan()-1, confirmed))
+	if err != nil {
+		return err
+	}
+	req.Height = block.Height + 1
+	stxn, err := eth.proposal.Propose(eth.Pool, eth.Name(), relayL1Writing, req)
+	if err != nil {
+		return err
+	}
+	eth.Pool.SortTxns(func(txns []*types.SignedTxn) []*types.SignedTxn {
+		slices.SortStableFunc(txns, func(a, b *types.SignedTxn) int {
+			return boolToInt(b.TxnHash == stxn.TxnHash) - boolToInt(a.TxnHash == stxn.TxnHash)
+		})
+		return txns
+	})
+	return nil
+}
+
+// fetchRelay reads the L1 blocks [from, to] to relay.
+func (eth *EthRelayer) fetchRelay(ctx context.Context, from, to uint64) (*RelayL1Request, error) {
+	header, err := eth.ethCli.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := eth.fetchDeposits(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	forced, err := eth.fetchForcedTxns(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := eth.fetchTokenMetadata(ctx, deposits, to)
+	if err != nil {
+		return nil, err
+	}
+	return &RelayL1Request{
+		From:        from,
+		To:          to,
+		L1BlockHash: header.Hash().Hex(),
+		Deposits:    deposits,
+		Forced:      forced,
+		Metadata:    metadata,
+	}, nil
+}
+
+// RelayL1 mints the deposits in nonce order into the block and queues the forced txns in index order.
+// Only the block producer puts it into a block, see RelayDeposits; it fails unless it relays the L1 blocks
+// right after the relay state exactly as the L1 of this node has them, and then writes nothing.
+// The check is not left to VerifyBlock alone: yu's PoA runs the block verifiers on its genesis block
+// instead of the blocks it receives, so a producer could relay deposits L1 never had.
+func (eth *EthRelayer) RelayL1(ctx *yucontext.WriteContext) error {
+	req := new(RelayL1Request)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if req.Height != ctx.Block.Height {
+		return fmt.Errorf("relay proposed for block(%d) in block(%d)", req.Height, ctx.Block.Height)
+	}
+	state, err := eth.getRelayState()
+	if err != nil {
+		return err
+	}
+	if next := eth.nextL1Height(state); req.From != next {
+		return fmt.Errorf("relay starts at L1 block %d, the next one is %d", req.From, next)
+	}
+	if req.To < req.From || req.To-req.From >= eth.maxL1BlocksPerScan() {
+		return fmt.Errorf("invalid L1 range [%d, %d]", req.From, req.To)
+	}
+	if err = eth.checkRelay(context.Background(), req); err != nil {
+		return fmt.Errorf("relay of L1 blocks [%d, %d] differs from L1: %v", req.From, req.To, err)
+	}
+	forced, err := eth.newForcedTxns(req.Forced)
+	if err != nil {
+		return err
+	}
+	if req.Metadata == nil {
+		req.Metadata = make(map[ethcommon.Address]*registry.TokenMapping)
+	}
+	if err = eth.applyDeposits(state, req.Deposits, req.Metadata, req.To, req.L1BlockHash, ctx.Block); err != nil {
+		return err
+	}
+	if err = eth.queueForcedTxns(forced); err != nil {
+		return err
+	}
+	return eth.recordDepositCount(ctx.Block.Height)
+}
+
+// CheckTxn keeps the RelayL1 txns out of the txpool, only the block producer proposes them.
+func (eth *EthRelayer) CheckTxn(stxn *types.SignedTxn) error {
+	if stxn.Raw.WrCall.FuncName == relayL1Writing {
+		return errors.New("RelayL1 is proposed by the block producer only")
+	}
+	return nil
+}
+
+// verifyRelay rejects a block with more than one RelayL1 txn, or one which relays anything but what L1 has
+// in the final blocks it claims.
+func (eth *EthRelayer) verifyRelay(block *types.Block) error {
+	relayed := false
+	for _, stxn := range block.Txns {
+		wrCall := stxn.Raw.WrCall
+		if wrCall.TripodName != eth.Name() || wrCall.FuncName != relayL1Writing {
+			continue
+		}
+		if relayed {
+			return fmt.Errorf("block(%d) relays L1 twice", block.Height)
+		}
+		relayed = true
+		req := new(RelayL1Request)
+		if err := stxn.BindJson(req); err != nil {
+			return err
+		}
+		if err := eth.checkRelay(context.Background(), req); err != nil {
+			return fmt.Errorf("block(%d) relays L1 blocks [%d, %d] wrongly: %v", block.Height, req.From, req.To, err)
+		}
+	}
+	return nil
+}
+
+// checkRelay reads the L1 blocks of a proposed relay again and compares them with it.
+func (eth *EthRelayer) checkRelay(ctx context.Context, req *RelayL1Request) error {
+	if req.To < req.From {
+		return errors.New("empty L1 range")
+	}
+	if err := eth.awaitFinalized(ctx, req.To); err != nil {
+		return err
+	}
+	fetched, err := eth.fetchRelay(ctx, req.From, req.To)
+	if err != nil {
+		return err
+	}
+	if fetched.L1BlockHash != req.L1BlockHash {
+		return fmt.Errorf("L1 block %d is %s, not %s", req.To, fetched.L1BlockHash, req.L1BlockHash)
+	}
+	if !jsonEqual(fetched.Deposits, req.Deposits) {
+		return errors.New("deposits differ from L1")
+	}
+	if !jsonEqual(fetched.Forced, req.Forced) {
+		return errors.New("forced txns differ from L1")
+	}
+	// the proposal may carry the metadata of a token mapped since, check each token against L1 instead
+	for l1Token, mapping := range req.Metadata {
+		want, err := eth.fetchErc20Metadata(ctx, l1Token, req.To)
+		if err != nil {
+			return err
+		}
+		if !jsonEqual(want, mapping) {
+			return fmt.Errorf("metadata of L1 token %s differs from L1", l1Token.Hex())
+		}
+	}
+	return nil
+}
+
+// awaitFinalized waits up to finalityWait for the L1 block height to be final, the L1 of this node may lag
+// behind the one of the block producer.
+func (eth *EthRelayer) awaitFinalized(ctx context.Context, height uint64) error {
+	deadline := time.Now().Add(finalityWait)
+	for {
+		confirmed, err := eth.Finalized(ctx)
+		if err != nil {
+			return err
+		}
+		if height <= confirmed {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("L1 block %d is not final, final is %d", height, confirmed)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(finalityPoll):
+		}
+	}
+}
+
+func jsonEqual(a, b any) bool {
+	aByt, aErr := json.Marshal(a)
+	bByt, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aByt, bByt)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
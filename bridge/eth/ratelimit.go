@@ -0,0 +1,353 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+type Direction string
+
+const (
+	DepositDirection    Direction = "deposit"
+	WithdrawalDirection Direction = "withdrawal"
+)
+
+// UsageEntry is the amount of a token bridged in one direction in the L2 block Height.
+type UsageEntry struct {
+	Height common.BlockNum `json:"height"`
+	Amount *big.Int        `json:"amount"`
+}
+
+// DelayedTransfer is a deposit or withdrawal above the cap of its token, held until ReleaseAt.
+// A delayed deposit has consumed its nonce, it is minted when released.
+// A delayed withdrawal has burned its tokens, it joins the withdrawal tree of the block it is released in.
+type DelayedTransfer struct {
+	ID         uint64          `json:"id"`
+	Deposit    *Deposit        `json:"deposit,omitempty"`
+	Withdrawal *Withdrawal     `json:"withdrawal,omitempty"`
+	DelayedAt  common.BlockNum `json:"delayed_at"`
+	ReleaseAt  common.BlockNum `json:"release_at"`
+	ReleasedAt common.BlockNum `json:"released_at,omitempty"`
+}
+
+func (d *DelayedTransfer) l1Token() ethcommon.Address {
+	if d.Deposit != nil {
+		return ethcommon.HexToAddress(d.Deposit.L1Token)
+	}
+	return ethcommon.HexToAddress(d.Withdrawal.L1Token)
+}
+
+// DelayedQueue is the queue of the delayed transfers.
+type DelayedQueue struct {
+	// Next is the id of the next delayed transfer.
+	Next uint64 `json:"next"`
+	// Pending are the ids of the delayed transfers not released yet.
+	Pending []uint64 `json:"pending"`
+}
+
+// RateLimitUsage is the amount of an L1 token bridged in the current window against its caps.
+type RateLimitUsage struct {
+	L1Token        string     `json:"l1_token"`
+	Limit          *RateLimit `json:"limit"`
+	DepositUsed    *big.Int   `json:"deposit_used"`
+	WithdrawalUsed *big.Int   `json:"withdrawal_used"`
+}
+
+// rateLimit returns the RateLimit of the L1 token, nil if it is not capped.
+func (eth *EthRelayer) rateLimit(l1Token ethcommon.Address) *RateLimit {
+	for token, limit := range eth.cfg.RateLimits {
+		if ethcommon.HexToAddress(token) == l1Token {
+			return limit
+		}
+	}
+	return nil
+}
+
+// admit counts amount into the window of the L1 token ending at height, unless it would exceed the cap.
+// It returns false, counting nothing, if the transfer must be delayed instead.
+func (eth *EthRelayer) admit(direction Direction, l1Token ethcommon.Address, amount *big.Int, height common.BlockNum) (bool, error) {
+	limit := eth.rateLimit(l1Token)
+	if limit == nil {
+		return true, nil
+	}
+	limitCap := limit.DepositCap
+	if direction == WithdrawalDirection {
+		limitCap = limit.WithdrawalCap
+	}
+	if limitCap == nil {
+		return true, nil
+	}
+	used, err := eth.usage(direction, l1Token, limit.Window, height)
+	if err != nil {
+		return false, err
+	}
+	if new(big.Int).Add(used, amount).Cmp(limitCap) > 0 {
+		return false, nil
+	}
+	return true, eth.addUsage(direction, l1Token, amount, height)
+}
+
+// usage sums the amounts of the L1 token bridged in the window of L2 blocks (height-window, height].
+func (eth *EthRelayer) usage(direction Direction, l1Token ethcommon.Address, window uint64, height common.BlockNum) (*big.Int, error) {
+	entries, err := eth.getUsage(direction, l1Token)
+	if err != nil {
+		return nil, err
+	}
+	used := big.NewInt(0)
+	for _, entry := range entries {
+		if inWindow(entry.Height, window, height) {
+			used.Add(used, entry.Amount)
+		}
+	}
+	return used, nil
+}
+
+// addUsage counts amount into the block height, dropping the entries out of the window.
+func (eth *EthRelayer) addUsage(direction Direction, l1Token ethcommon.Address, amount *big.Int, height common.BlockNum) error {
+	limit := eth.rateLimit(l1Token)
+	if limit == nil {
+		return nil
+	}
+	entries, err := eth.getUsage(direction, l1Token)
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		if inWindow(entry.Height, limit.Window, height) {
+			kept = append(kept, entry)
+		}
+	}
+	if n := len(kept); n > 0 && kept[n-1].Height == height {
+		kept[n-1].Amount.Add(kept[n-1].Amount, amount)
+	} else {
+		kept = append(kept, &UsageEntry{Height: height, Amount: new(big.Int).Set(amount)})
+	}
+	return bridge.SetJson(eth, usageKey(direction, l1Token), kept)
+}
+
+func inWindow(entryHeight common.BlockNum, window uint64, height common.BlockNum) bool {
+	return uint64(entryHeight)+window > uint64(height)
+}
+
+// withdrawOrDelay appends the withdrawal to its tree, or delays it if it exceeds the withdrawal cap of its token.
+func (eth *EthRelayer) withdrawOrDelay(withdrawal *Withdrawal) error {
+	admitted, err := eth.admit(WithdrawalDirection, ethcommon.HexToAddress(withdrawal.L1Token), withdrawal.Amount, withdrawal.Height)
+	if err != nil {
+		return err
+	}
+	if !admitted {
+		return eth.delayTransfer(&DelayedTransfer{Withdrawal: withdrawal}, withdrawal.Height)
+	}
+	return eth.appendWithdrawal(withdrawal)
+}
+
+// delayTransfer holds the transfer for the Delay of its token from the block height.
+func (eth *EthRelayer) delayTransfer(transfer *DelayedTransfer, height common.BlockNum) error {
+	queue, err := eth.getDelayedQueue()
+	if err != nil {
+		return err
+	}
+	transfer.ID = queue.Next
+	transfer.DelayedAt = height
+	transfer.ReleaseAt = height + common.BlockNum(eth.rateLimit(transfer.l1Token()).Delay)
+	if err = bridge.SetJson(eth, delayedKey(transfer.ID), transfer); err != nil {
+		return err
+	}
+	if transfer.Deposit != nil {
+		if err = bridge.SetJson(eth, depositKey(transfer.Deposit.Nonce), transfer.Deposit); err != nil {
+			return err
+		}
+	}
+	queue.Next++
+	queue.Pending = append(queue.Pending, transfer.ID)
+	return bridge.SetJson(eth, delayedQueueKey, queue)
+}
+
+// releaseDueTransfers mints the delayed deposits and appends the delayed withdrawals due at the block,
+// bypassing the caps but counting into them.
+func (eth *EthRelayer) releaseDueTransfers(block *types.Block) error {
+	queue, err := eth.getDelayedQueue()
+	if err != nil || len(queue.Pending) == 0 {
+		return err
+	}
+	pending := queue.Pending[:0]
+	for _, id := range queue.Pending {
+		transfer, err := eth.getDelayedTransfer(id)
+		if err != nil {
+			return err
+		}
+		if transfer.ReleaseAt > block.Height {
+			pending = append(pending, id)
+			continue
+		}
+		if err = eth.releaseTransfer(transfer, block); err != nil {
+			return err
+		}
+	}
+	queue.Pending = pending
+	return bridge.SetJson(eth, delayedQueueKey, queue)
+}
+
+func (eth *EthRelayer) releaseTransfer(transfer *DelayedTransfer, block *types.Block) error {
+	var direction Direction
+	var amount *big.Int
+	if deposit := transfer.Deposit; deposit != nil {
+		direction, amount = DepositDirection, deposit.Amount
+		deposit.Delayed = false
+		if err := eth.releaseDeposit(deposit, block); err != nil {
+			return err
+		}
+	} else {
+		direction, amount = WithdrawalDirection, transfer.Withdrawal.Amount
+		transfer.Withdrawal.Height = block.Height
+		if err := eth.appendWithdrawal(transfer.Withdrawal); err != nil {
+			return err
+		}
+	}
+	if err := eth.addUsage(direction, transfer.l1Token(), amount, block.Height); err != nil {
+		return err
+	}
+	transfer.ReleasedAt = block.Height
+	return bridge.SetJson(eth, delayedKey(transfer.ID), transfer)
+}
+
+// releaseDeposit credits a delayed deposit, unlocking it if it is of the native token.
+func (eth *EthRelayer) releaseDeposit(deposit *Deposit, block *types.Block) error {
+	if deposit.Token.IsNative() {
+		return eth.unlockNative(deposit, block)
+	}
+	mapping, err := eth.Registry.Lookup(eth.origin(ethcommon.HexToAddress(deposit.L1Token)))
+	if err != nil {
+		return err
+	}
+	if mapping == nil {
+		return errors.New("delayed deposit of an unmapped token")
+	}
+	return eth.creditDeposit(deposit, mapping, block)
+}
+
+type ReleaseDelayedRequest struct {
+	ID           uint64 `json:"id"`
+	GuardianArgs []byte `json:"guardian_args"`
+}
+
+// ReleaseDelayed makes a delayed transfer due, it is released at the start of the next block.
+// Only the guardian can call it.
+func (eth *EthRelayer) ReleaseDelayed(ctx *yucontext.WriteContext) error {
+	req := new(ReleaseDelayedRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if eth.cfg.Guardian == "" {
+		return errors.New("no guardian configured")
+	}
+	if err := eth.Account.VerifyOwner(eth.cfg.Guardian, req.GuardianArgs); err != nil {
+		return err
+	}
+	transfer, err := eth.getDelayedTransfer(req.ID)
+	if err != nil {
+		return err
+	}
+	if transfer.ReleasedAt != 0 {
+		return errors.New("delayed transfer released")
+	}
+	if transfer.ReleaseAt > ctx.Block.Height {
+		transfer.ReleaseAt = ctx.Block.Height
+	}
+	return bridge.SetJson(eth, delayedKey(req.ID), transfer)
+}
+
+// GetRateLimitUsage returns the RateLimitUsage of l1_token, or of every capped token if it is empty.
+func (eth *EthRelayer) GetRateLimitUsage(ctx *yucontext.ReadContext) {
+	block, err := eth.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	filter := ctx.GetString("l1_token")
+	usages := make([]*RateLimitUsage, 0)
+	for token, limit := range eth.cfg.RateLimits {
+		l1Token := ethcommon.HexToAddress(token)
+		if filter != "" && ethcommon.HexToAddress(filter) != l1Token {
+			continue
+		}
+		usage := &RateLimitUsage{L1Token: l1Token.Hex(), Limit: limit}
+		if usage.DepositUsed, err = eth.usage(DepositDirection, l1Token, limit.Window, block.Height); err != nil {
+			ctx.ErrOk(err)
+			return
+		}
+		if usage.WithdrawalUsed, err = eth.usage(WithdrawalDirection, l1Token, limit.Window, block.Height); err != nil {
+			ctx.ErrOk(err)
+			return
+		}
+		usages = append(usages, usage)
+	}
+	ctx.JsonOk(usages)
+}
+
+func (eth *EthRelayer) GetDelayedTransfer(ctx *yucontext.ReadContext) {
+	id, err := strconv.ParseUint(ctx.GetString("id"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	transfer, err := eth.getDelayedTransfer(id)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(transfer)
+}
+
+func (eth *EthRelayer) GetDelayedQueue(ctx *yucontext.ReadContext) {
+	queue, err := eth.getDelayedQueue()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(queue)
+}
+
+var delayedQueueKey = []byte("delayed_queue")
+
+func delayedKey(id uint64) []byte {
+	return []byte("delayed/" + strconv.FormatUint(id, 10))
+}
+
+func usageKey(direction Direction, l1Token ethcommon.Address) []byte {
+	return []byte("rate_usage/" + string(direction) + "/" + l1Token.Hex())
+}
+
+func (eth *EthRelayer) getUsage(direction Direction, l1Token ethcommon.Address) ([]*UsageEntry, error) {
+	var entries []*UsageEntry
+	_, err := bridge.GetJson(eth, usageKey(direction, l1Token), &entries)
+	return entries, err
+}
+
+func (eth *EthRelayer) getDelayedQueue() (*DelayedQueue, error) {
+	queue := new(DelayedQueue)
+	_, err := bridge.GetJson(eth, delayedQueueKey, queue)
+	return queue, err
+}
+
+func (eth *EthRelayer) getDelayedTransfer(id uint64) (*DelayedTransfer, error) {
+	byt, err := eth.Get(delayedKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, errors.New("delayed transfer not found")
+	}
+	transfer := new(DelayedTransfer)
+	err = json.Unmarshal(byt, transfer)
+	return transfer, err
+}
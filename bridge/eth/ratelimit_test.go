@@ -0,0 +1,173 @@
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+)
+
+const (
+	window = 10
+	delay  = 5
+)
+
+// newCappedRelayer returns a testRelayer capping the deposits and withdrawals of ETH at 100 in a window of 10 blocks.
+func newCappedRelayer(t *testing.T) *testRelayer {
+	r := newTestRelayer(t)
+	r.cfg.RateLimits = map[string]*RateLimit{
+		(ethcommon.Address{}).Hex(): {Window: window, DepositCap: big.NewInt(100), WithdrawalCap: big.NewInt(100), Delay: delay},
+	}
+	r.cfg.Guardian = "guardian"
+	mustOk(t, r.Account.AddBalance("guardian", BridgedTokenID(ethcommon.Address{}), big.NewInt(0)))
+	return r
+}
+
+func (r *testRelayer) releaseDelayed(t *testing.T, height common.BlockNum, id uint64) error {
+	byt, err := json.Marshal(&ReleaseDelayedRequest{ID: id})
+	mustOk(t, err)
+	params, err := yucontext.NewParamsResponseFromStr(string(byt))
+	mustOk(t, err)
+	return r.state.Execute(func() error {
+		return r.ReleaseDelayed(&yucontext.WriteContext{ParamsResponse: params, Block: newBlock(height)})
+	})
+}
+
+func (r *testRelayer) delayedTransfer(t *testing.T, id uint64) *DelayedTransfer {
+	t.Helper()
+	transfer, err := r.getDelayedTransfer(id)
+	mustOk(t, err)
+	return transfer
+}
+
+func TestAdmitWithinWindow(t *testing.T) {
+	r := newCappedRelayer(t)
+	eth := ethcommon.Address{}
+	tests := []struct {
+		name      string
+		direction Direction
+		l1Token   ethcommon.Address
+		amount    int64
+		height    common.BlockNum
+		want      bool
+		wantUsed  int64
+	}{
+		{"within the cap", DepositDirection, eth, 60, 1, true, 60},
+		{"above the cap", DepositDirection, eth, 50, 5, false, 60},
+		{"up to the cap", DepositDirection, eth, 40, 5, true, 100},
+		{"cap reached", DepositDirection, eth, 1, 10, false, 100},
+		// the 60 of block 1 leave the window at block 11
+		{"window rolled", DepositDirection, eth, 60, 11, true, 100},
+		{"other direction", WithdrawalDirection, eth, 100, 11, true, 100},
+		{"uncapped token", DepositDirection, ethcommon.Address{7}, 1_000, 11, true, 0},
+	}
+	for _, tt := range tests {
+		var admitted bool
+		mustOk(t, r.state.Execute(func() (err error) {
+			admitted, err = r.admit(tt.direction, tt.l1Token, big.NewInt(tt.amount), tt.height)
+			return err
+		}))
+		if admitted != tt.want {
+			t.Fatalf("%s: admitted %v, want %v", tt.name, admitted, tt.want)
+		}
+		used, err := r.usage(tt.direction, tt.l1Token, window, tt.height)
+		mustOk(t, err)
+		if used.Int64() != tt.wantUsed {
+			t.Fatalf("%s: %s used in the window, want %d", tt.name, used, tt.wantUsed)
+		}
+	}
+}
+
+func TestDelayedWithdrawalReleased(t *testing.T) {
+	r := newCappedRelayer(t)
+	withdraw := func(amount int64, height common.BlockNum) {
+		mustOk(t, r.state.Execute(func() error {
+			return r.withdrawOrDelay(&Withdrawal{From: "alice", Recipient: r.alice.From.Hex(), L1Token: (ethcommon.Address{}).Hex(),
+				Amount: big.NewInt(amount), Height: height})
+		}))
+	}
+	withdraw(80, 1)
+	withdraw(30, 2)
+
+	queue, err := r.getDelayedQueue()
+	mustOk(t, err)
+	if len(queue.Pending) != 1 || queue.Next != 1 {
+		t.Fatalf("delayed queue %+v, want the withdrawal above the cap", queue)
+	}
+	if transfer := r.delayedTransfer(t, 0); transfer.DelayedAt != 2 || transfer.ReleaseAt != 2+delay {
+		t.Fatalf("delayed withdrawal %+v, want it released at %d", transfer, 2+delay)
+	}
+
+	mustOk(t, r.state.Execute(func() error { return r.releaseDueTransfers(newBlock(delay + 1)) }))
+	if transfer := r.delayedTransfer(t, 0); transfer.ReleasedAt != 0 {
+		t.Fatal("released a delayed withdrawal before its delay ended")
+	}
+	mustOk(t, r.state.Execute(func() error { return r.releaseDueTransfers(newBlock(delay + 2)) }))
+	if transfer := r.delayedTransfer(t, 0); transfer.ReleasedAt != delay+2 {
+		t.Fatalf("delayed withdrawal %+v, want it released at %d", transfer, delay+2)
+	}
+	withdrawal := new(Withdrawal)
+	r.load(t, withdrawalKey(1), withdrawal)
+	if withdrawal.Amount.Int64() != 30 || withdrawal.Height != delay+2 {
+		t.Fatalf("released withdrawal %+v, want 30 in block %d", withdrawal, delay+2)
+	}
+	// the release bypasses the cap but counts into it
+	used, err := r.usage(WithdrawalDirection, ethcommon.Address{}, window, delay+2)
+	mustOk(t, err)
+	if used.Int64() != 110 {
+		t.Fatalf("%s withdrawn in the window, want 110", used)
+	}
+	if queue, err = r.getDelayedQueue(); err != nil || len(queue.Pending) != 0 {
+		t.Fatalf("delayed queue %+v after the release: %v", queue, err)
+	}
+}
+
+func TestGuardianReleasesDelayedDeposit(t *testing.T) {
+	r := newCappedRelayer(t)
+	deposits := []*Deposit{
+		{Nonce: 0, Sender: r.alice.From.Hex(), L1Token: (ethcommon.Address{}).Hex(), Recipient: "alice", Amount: big.NewInt(150)},
+	}
+	metadata := map[ethcommon.Address]*registry.TokenMapping{
+		{}: {Name: "Ether", Symbol: "ETH", Decimals: DefaultDecimals},
+	}
+	state, err := r.getRelayState()
+	mustOk(t, err)
+	mustOk(t, r.state.Execute(func() error {
+		return r.applyDeposits(state, deposits, metadata, 10, "", newBlock(1))
+	}))
+	deposit := new(Deposit)
+	r.load(t, depositKey(0), deposit)
+	if !deposit.Delayed {
+		t.Fatal("the deposit above the cap was not delayed")
+	}
+
+	guardian := r.cfg.Guardian
+	r.cfg.Guardian = ""
+	if err = r.releaseDelayed(t, 2, 0); err == nil {
+		t.Fatal("released a delayed deposit without a guardian")
+	}
+	r.cfg.Guardian = guardian
+	mustOk(t, r.releaseDelayed(t, 2, 0))
+	if transfer := r.delayedTransfer(t, 0); transfer.ReleaseAt != 2 || transfer.ReleasedAt != 0 {
+		t.Fatalf("delayed deposit %+v, want it due at block 2", transfer)
+	}
+	// it is minted at the start of the next block
+	mustOk(t, r.state.Execute(func() error { return r.releaseDueTransfers(newBlock(3)) }))
+	balance, err := r.Account.GetBalance("alice", BridgedTokenID(ethcommon.Address{}))
+	mustOk(t, err)
+	if balance.Int64() != 150 {
+		t.Fatalf("alice has %s, want 150 after the release", balance)
+	}
+	released := new(Deposit)
+	r.load(t, depositKey(0), released)
+	if released.Delayed {
+		t.Fatal("the released deposit is still delayed")
+	}
+	if err = r.releaseDelayed(t, 4, 0); err == nil || err.Error() != "delayed transfer released" {
+		t.Fatalf("released a delayed deposit twice: %v", err)
+	}
+}
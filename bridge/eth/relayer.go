@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yu-org/JingChou/bridge"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+var _ bridge.Relayer = (*EthRelayer)(nil)
+
+func (eth *EthRelayer) ChainURL() string {
+	return eth.chainURL
+}
+
+// Finalized returns the latest final L1 block: the finalized one with UseFinalizedTag,
+// otherwise the latest one with Confirmations blocks on top of it, 0 if there is none yet.
+func (eth *EthRelayer) Finalized(ctx context.Context) (uint64, error) {
+	if eth.cfg.UseFinalizedTag {
+		header, err := eth.ethCli.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		if err != nil {
+			return 0, err
+		}
+		return header.Number.Uint64(), nil
+	}
+	head, err := eth.ethCli.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head < eth.cfg.Confirmations {
+		return 0, nil
+	}
+	return head - eth.cfg.Confirmations, nil
+}
+
+// SubmitWithdrawals has nothing to send: L1 learns the withdrawals from the withdrawal root in the public values
+// of the batch proof the zkrollup submits, and each one is claimed on the parent-layer bridge with its Merkle proof.
+func (eth *EthRelayer) SubmitWithdrawals(*types.Block) error {
+	return nil
+}
+
+func (eth *EthRelayer) Health(ctx context.Context) *bridge.Health {
+	health := &bridge.Health{ChainURL: eth.chainURL}
+	state, err := eth.getRelayState()
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Relayed = state.L1Height
+	if health.Finalized, err = eth.Finalized(ctx); err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+func (eth *EthRelayer) GetHealth(ctx *yucontext.ReadContext) {
+	ctx.JsonOk(eth.Health(context.Background()))
+}
@@ -0,0 +1,111 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+	yucontext "github.com/yu-org/yu/core/context"
+)
+
+// checkCanonical makes sure the last L1 block relayed is still on the canonical chain.
+// If it is not, L1 reorganized a block taken as final, and the relay stops instead of relaying the new branch
+// on top of deposits which may no longer exist.
+func (eth *EthRelayer) checkCanonical(ctx context.Context, state *RelayState) error {
+	if state.L1BlockHash == "" {
+		return nil
+	}
+	header, err := eth.ethCli.HeaderByNumber(ctx, new(big.Int).SetUint64(state.L1Height))
+	if err != nil {
+		return err
+	}
+	if hash := header.Hash().Hex(); hash != state.L1BlockHash {
+		return fmt.Errorf(
+			"final L1 block %d was reorganized from %s to %s, deepen the finality of the relayer",
+			state.L1Height, state.L1BlockHash, hash,
+		)
+	}
+	return nil
+}
+
+// PendingDeposits are the deposits a node sees on L1 after the final blocks. Each node tracks them from its own L1
+// head in memory, apart from the chain state, since the heads of the nodes differ and the state must not.
+type PendingDeposits struct {
+	Deposits []*Deposit `json:"deposits"`
+	// Reorgs counts the L1 reorgs which dropped pending deposits since this node started.
+	Reorgs uint64 `json:"reorgs"`
+}
+
+// trackPendingDeposits scans the L1 blocks after the final ones up to the L1 head for the deposits not final yet.
+// A pending deposit is never minted, it is only shown by GetPendingDeposits until it is relayed as final;
+// one whose L1 block is reorganized out is dropped, and relayed from its new block if it is included again.
+func (eth *EthRelayer) trackPendingDeposits(ctx context.Context) error {
+	state, err := eth.getRelayState()
+	if err != nil {
+		return err
+	}
+	head, err := eth.ethCli.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	var deposits []*Deposit
+	from := eth.nextL1Height(state)
+	to := min(from+eth.maxL1BlocksPerScan()-1, head)
+	if from <= head {
+		if deposits, err = eth.fetchDeposits(ctx, from, to); err != nil {
+			return err
+		}
+	}
+	pending := make([]*Deposit, 0, len(deposits))
+	for _, deposit := range deposits {
+		if deposit.Nonce >= state.NextDepositNonce {
+			pending = append(pending, deposit)
+		}
+	}
+
+	eth.pendingLock.Lock()
+	defer eth.pendingLock.Unlock()
+	reorged := false
+	for _, deposit := range eth.pending.Deposits {
+		// the blocks beyond a scan bounded by MaxL1BlocksPerScan are tracked in the next scans
+		if deposit.Nonce < state.NextDepositNonce || deposit.L1Height > to && to < head || containsDeposit(pending, deposit) {
+			continue
+		}
+		logrus.Warnf("L1 reorg dropped the pending deposit(%d) of L1 block %d(%s)",
+			deposit.Nonce, deposit.L1Height, deposit.L1BlockHash)
+		reorged = true
+	}
+	if reorged {
+		eth.pending.Reorgs++
+	}
+	eth.pending.Deposits = pending
+	return nil
+}
+
+// containsDeposit tells whether the deposit is among the deposits in the same L1 block.
+func containsDeposit(deposits []*Deposit, deposit *Deposit) bool {
+	for _, d := range deposits {
+		if d.Nonce == deposit.Nonce && d.L1BlockHash == deposit.L1BlockHash {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingDeposits returns the PendingDeposits this node tracked last.
+func (eth *EthRelayer) pendingDeposits() *PendingDeposits {
+	eth.pendingLock.RLock()
+	defer eth.pendingLock.RUnlock()
+	return &PendingDeposits{
+		Deposits: slices.Clone(eth.pending.Deposits),
+		Reorgs:   eth.pending.Reorgs,
+	}
+}
+
+// GetPendingDeposits returns the deposits this node sees on L1 but not final yet, they are minted once final,
+// and how many L1 reorgs dropped some of them.
+func (eth *EthRelayer) GetPendingDeposits(ctx *yucontext.ReadContext) {
+	ctx.JsonOk(eth.pendingDeposits())
+}
@@ -0,0 +1,271 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/config"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+	"github.com/yu-org/yu/core/txpool"
+	"github.com/yu-org/yu/core/types"
+)
+
+const confirmations = 3
+
+// testRelayer is an EthRelayer over a simulated L1 with the parent-layer bridge deployed.
+type testRelayer struct {
+	*EthRelayer
+	state   *memstate.State
+	pool    *txpool.TxPool
+	backend *simulated.Backend
+	client  simulated.Client
+	alice   *bind.TransactOpts
+	bridge  *contracts.JingChouBridge
+}
+
+func newTestRelayer(t *testing.T) *testRelayer {
+	key, err := crypto.GenerateKey()
+	mustOk(t, err)
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))},
+	})
+	t.Cleanup(func() { backend.Close() })
+	alice, err := bind.NewKeyedTransactorWithChainID(key, params.AllDevChainProtocolChanges.ChainID)
+	mustOk(t, err)
+	r := &testRelayer{state: memstate.New(), backend: backend, client: backend.Client(), alice: alice}
+	deployer := *alice
+	deployer.GasLimit = 5_000_000
+	// deposits never verify a proof
+	rollupAddr, tx, _, err := contracts.DeployJingChouRollup(&deployer, r.client, ethcommon.Address{1}, [32]byte{1}, [32]byte{2}, [32]byte{}, big.NewInt(3600))
+	mustOk(t, err)
+	r.mine(t, tx)
+	bridgeAddr, tx, bridge, err := contracts.DeployJingChouBridge(&deployer, r.client, rollupAddr)
+	mustOk(t, err)
+	r.mine(t, tx)
+	r.bridge = bridge
+
+	r.EthRelayer, err = NewETHRelayerWithClient(&Config{
+		ParentLayerContractAddress: bridgeAddr.Hex(),
+		Confirmations:              confirmations,
+		WithdrawalBatchSize:        10,
+	}, r.client)
+	mustOk(t, err)
+	r.pool = txpool.NewTxPool(common.FullNode, &config.TxpoolConf{PoolSize: 100, TxnMaxSize: 1 << 20})
+	chainEnv := &env.ChainEnv{State: r.state, Pool: r.pool}
+	r.SetChainEnv(chainEnv)
+	r.UDT, r.Account = udt.NewUdtTripod(), account.NewAccountTripod()
+	r.Registry = registry.NewTokenRegistry(&registry.Config{})
+	r.UDT.SetChainEnv(chainEnv)
+	r.Account.SetChainEnv(chainEnv)
+	r.Registry.SetChainEnv(chainEnv)
+	r.Registry.Account = r.Account
+	return r
+}
+
+// mine commits an L1 block with the sent transaction and fails the test unless it succeeded.
+func (r *testRelayer) mine(t *testing.T, tx *ethtypes.Transaction) *ethtypes.Receipt {
+	t.Helper()
+	r.backend.Commit()
+	receipt, err := r.client.TransactionReceipt(context.Background(), tx.Hash())
+	mustOk(t, err)
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		t.Fatalf("tx(%s) reverted", tx.Hash().Hex())
+	}
+	return receipt
+}
+
+// deposit deposits amount wei to the L2 account recipient, and returns the L1 block of the deposit.
+func (r *testRelayer) deposit(t *testing.T, amount int64, recipient string) *ethtypes.Header {
+	t.Helper()
+	opts := *r.alice
+	opts.Value = big.NewInt(amount)
+	tx, err := r.bridge.DepositETH(&opts, recipient)
+	mustOk(t, err)
+	receipt := r.mine(t, tx)
+	header, err := r.client.HeaderByHash(context.Background(), receipt.BlockHash)
+	mustOk(t, err)
+	return header
+}
+
+// commit builds n empty L1 blocks on the head.
+func (r *testRelayer) commit(n int) {
+	for i := 0; i < n; i++ {
+		r.backend.Commit()
+	}
+}
+
+// fork makes the parent of the L1 block the head, reorganizing out the block and every one after it.
+func (r *testRelayer) fork(t *testing.T, header *ethtypes.Header) {
+	t.Helper()
+	mustOk(t, r.backend.Fork(header.ParentHash))
+}
+
+// propose lets the relayer propose relaying L1 after the L2 block height, and returns the proposal if any.
+func (r *testRelayer) propose(t *testing.T, height common.BlockNum) *types.SignedTxn {
+	t.Helper()
+	mustOk(t, r.RelayDeposits(newBlock(height)))
+	txns, err := r.pool.Pack(1)
+	mustOk(t, err)
+	if len(txns) == 0 {
+		return nil
+	}
+	return txns[0]
+}
+
+// proposedDeposits returns the deposits a proposal relays, none without a proposal.
+func proposedDeposits(t *testing.T, stxn *types.SignedTxn) []*Deposit {
+	t.Helper()
+	if stxn == nil {
+		return nil
+	}
+	req := new(RelayL1Request)
+	mustOk(t, stxn.BindJson(req))
+	return req.Deposits
+}
+
+// apply executes the proposed RelayL1 txn in its block.
+func (r *testRelayer) apply(t *testing.T, stxn *types.SignedTxn) {
+	t.Helper()
+	req := new(RelayL1Request)
+	mustOk(t, stxn.BindJson(req))
+	ctx, err := yucontext.NewWriteContext(stxn, newBlock(req.Height), 0)
+	mustOk(t, err)
+	mustOk(t, r.state.Execute(func() error { return r.RelayL1(ctx) }))
+	mustOk(t, r.pool.Reset([]*types.SignedTxn{stxn}))
+}
+
+// load unmarshals the value of key into v, failing if the key is missing.
+func (r *testRelayer) load(t *testing.T, key []byte, v any) {
+	t.Helper()
+	found, err := bridge.GetJson(r, key, v)
+	mustOk(t, err)
+	if !found {
+		t.Fatalf("%s not found", key)
+	}
+}
+
+func newBlock(height common.BlockNum) *types.Block {
+	return &types.Block{Header: &types.Header{Height: height}}
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReorgDropsPendingDeposit(t *testing.T) {
+	r := newTestRelayer(t)
+	header := r.deposit(t, 100, "alice")
+
+	mustOk(t, r.trackPendingDeposits(context.Background()))
+	pending := r.pendingDeposits()
+	if len(pending.Deposits) != 1 || pending.Deposits[0].L1BlockHash != header.Hash().Hex() || pending.Reorgs != 0 {
+		t.Fatalf("unexpected pending deposits %+v", pending)
+	}
+	// each node tracks the L1 head it sees, nothing of it is consensus state
+	if keys := r.state.Keys(); len(keys) != 0 {
+		t.Fatalf("tracking pending deposits wrote the state: %v", keys)
+	}
+
+	r.fork(t, header)
+	mustOk(t, r.trackPendingDeposits(context.Background()))
+	pending = r.pendingDeposits()
+	if len(pending.Deposits) != 0 || pending.Reorgs != 1 {
+		t.Fatalf("pending deposits %+v after the reorg, want none and one reorg", pending)
+	}
+	if deposits := proposedDeposits(t, r.propose(t, 1)); len(deposits) != 0 {
+		t.Fatal("proposed relaying a reorganized deposit")
+	}
+	if balance, err := r.Account.GetBalance("alice", BridgedTokenID(ethcommon.Address{})); err != nil || balance.Sign() != 0 {
+		t.Fatalf("alice has %v after the reorg: %v", balance, err)
+	}
+}
+
+func TestRelayOnlyFinalDeposits(t *testing.T) {
+	r := newTestRelayer(t)
+	r.deposit(t, 100, "alice")
+
+	// the deposit is pending until confirmations blocks are built on top of it
+	r.commit(confirmations - 1)
+	stxn := r.propose(t, 1)
+	if deposits := proposedDeposits(t, stxn); len(deposits) != 0 {
+		t.Fatal("proposed relaying a deposit not final")
+	}
+	if stxn != nil {
+		r.apply(t, stxn)
+	}
+	r.commit(1)
+	stxn = r.propose(t, 2)
+	if deposits := proposedDeposits(t, stxn); len(deposits) != 1 {
+		t.Fatal("no relay proposed for a final deposit")
+	}
+	mustOk(t, r.verifyRelay(&types.Block{Header: &types.Header{Height: 3}, Txns: types.SignedTxns{stxn}}))
+	r.apply(t, stxn)
+	balance, err := r.Account.GetBalance("alice", BridgedTokenID(ethcommon.Address{}))
+	mustOk(t, err)
+	if balance.Int64() != 100 {
+		t.Fatalf("alice has %s, want 100", balance)
+	}
+
+	mustOk(t, r.trackPendingDeposits(context.Background()))
+	if pending := r.pendingDeposits(); len(pending.Deposits) != 0 {
+		t.Fatalf("relayed deposit still pending: %+v", pending)
+	}
+	if stxn = r.propose(t, 3); stxn != nil {
+		t.Fatal("proposed relaying the deposit again")
+	}
+}
+
+func TestVerifyRejectsReorganizedRelay(t *testing.T) {
+	r := newTestRelayer(t)
+	header := r.deposit(t, 100, "alice")
+	r.commit(confirmations)
+	stxn := r.propose(t, 1)
+	if stxn == nil {
+		t.Fatal("no relay proposed for a final deposit")
+	}
+
+	// L1 reorganizes the block of the deposit away, and builds as many blocks again without it
+	r.fork(t, header)
+	r.commit(confirmations + 1)
+	err := r.verifyRelay(&types.Block{Header: &types.Header{Height: 2}, Txns: types.SignedTxns{stxn}})
+	if err == nil || !strings.Contains(err.Error(), "wrongly") {
+		t.Fatalf("accepted relaying a reorganized L1 block: %v", err)
+	}
+}
+
+func TestRelayStopsOnReorganizedFinalBlock(t *testing.T) {
+	r := newTestRelayer(t)
+	header := r.deposit(t, 100, "alice")
+	r.commit(confirmations)
+	r.apply(t, r.propose(t, 1))
+
+	// a reorg deeper than the confirmations replaces an L1 block relayed as final
+	r.fork(t, header)
+	r.commit(confirmations + 2)
+	err := r.RelayDeposits(newBlock(2))
+	if err == nil || !strings.Contains(err.Error(), "reorganized") {
+		t.Fatalf("relayed on top of a reorganized final block: %v", err)
+	}
+	if r.pool.Size() != 0 {
+		t.Fatal("proposed relaying after the reorg")
+	}
+}
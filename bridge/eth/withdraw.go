@@ -0,0 +1,303 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+)
+
+// Withdrawal moves Amount of a bridged token from the L2 account From, or the L2 EVM address From
+// through the child-layer bridge, to the L1 address Recipient.
+// It is claimable on the parent-layer bridge contract with its Merkle proof once its batch is verified.
+type Withdrawal struct {
+	Nonce     uint64          `json:"nonce"`
+	From      string          `json:"from"`
+	Recipient string          `json:"recipient"`
+	Token     udt.TokenID     `json:"token"`
+	L1Token   string          `json:"l1_token"`
+	Amount    *big.Int        `json:"amount"`
+	Height    common.BlockNum `json:"height"`
+}
+
+var withdrawalLeafArgs = func() abi.Arguments {
+	uint256, _ := abi.NewType("uint256", "", nil)
+	address, _ := abi.NewType("address", "", nil)
+	return abi.Arguments{{Type: uint256}, {Type: address}, {Type: address}, {Type: uint256}}
+}()
+
+// Leaf is keccak256(bytes.concat(keccak256(abi.encode(nonce, recipient, l1Token, amount)))),
+// hashed twice so that a leaf can not be mistaken for an inner node of the tree.
+func (w *Withdrawal) Leaf() (ethcommon.Hash, error) {
+	encoded, err := withdrawalLeafArgs.Pack(
+		new(big.Int).SetUint64(w.Nonce),
+		ethcommon.HexToAddress(w.Recipient),
+		ethcommon.HexToAddress(w.L1Token),
+		w.Amount,
+	)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return crypto.Keccak256Hash(crypto.Keccak256(encoded)), nil
+}
+
+type WithdrawRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	Token     udt.TokenID `json:"token"`
+	Amount    *big.Int    `json:"amount"`
+	// Recipient is the L1 address receiving the tokens.
+	Recipient string `json:"recipient"`
+}
+
+// Withdraw burns the bridged token, or locks the native token, and appends the withdrawal to the tree of the current batch,
+// or to the delayed queue if it exceeds the withdrawal cap of the token.
+func (eth *EthRelayer) Withdraw(ctx *yucontext.WriteContext) error {
+	req := new(WithdrawRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := eth.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if req.Amount == nil || req.Amount.Sign() <= 0 {
+		return errors.New("withdraw amount must be positive")
+	}
+	if !ethcommon.IsHexAddress(req.Recipient) {
+		return errors.New("invalid L1 recipient")
+	}
+	if req.Token.IsNative() {
+		return eth.withdrawNative(req, ctx.Block.Height)
+	}
+	if !eth.UDT.Exist([]byte(req.Token)) {
+		return errors.New("token is not bridged from L1")
+	}
+	token, err := eth.UDT.GetUdt(req.Token)
+	if err != nil {
+		return err
+	}
+	if token.OriginalToken == nil || token.OriginalToken.ChainURL != eth.chainURL {
+		return errors.New("token is not bridged from L1")
+	}
+	if err = eth.Account.SubBalance(req.FromID, req.Token, req.Amount); err != nil {
+		return err
+	}
+	token.Total.Sub(token.Total, req.Amount)
+	token.Issued.Sub(token.Issued, req.Amount)
+	if err = eth.UDT.AddUdt(token); err != nil {
+		return err
+	}
+
+	return eth.withdrawOrDelay(&Withdrawal{
+		From:      req.FromID,
+		Recipient: ethcommon.HexToAddress(req.Recipient).Hex(),
+		Token:     req.Token,
+		L1Token:   ethcommon.BytesToAddress(token.OriginalToken.TokenAddress).Hex(),
+		Amount:    req.Amount,
+		Height:    ctx.Block.Height,
+	})
+}
+
+// appendWithdrawal gives the withdrawal the next nonce and appends its leaf to the tree of its block.
+func (eth *EthRelayer) appendWithdrawal(withdrawal *Withdrawal) error {
+	nonce, err := eth.getUint(withdrawalNonceKey)
+	if err != nil {
+		return err
+	}
+	withdrawal.Nonce = nonce
+	leaf, err := withdrawal.Leaf()
+	if err != nil {
+		return err
+	}
+	leaves, err := eth.getLeaves(withdrawal.Height)
+	if err != nil {
+		return err
+	}
+	if err = bridge.SetJson(eth, withdrawalLeavesKey(withdrawal.Height), append(leaves, leaf)); err != nil {
+		return err
+	}
+	nonces, err := eth.getWithdrawalsOf(withdrawal.From)
+	if err != nil {
+		return err
+	}
+	if err = bridge.SetJson(eth, withdrawalsOfKey(withdrawal.From), append(nonces, nonce)); err != nil {
+		return err
+	}
+	if err = bridge.SetJson(eth, withdrawalKey(nonce), withdrawal); err != nil {
+		return err
+	}
+	return bridge.SetJson(eth, withdrawalNonceKey, nonce+1)
+}
+
+// WithdrawalRoot returns the Merkle root of the withdrawals made in the L2 blocks [from, to], in the order they were made.
+// The zkrollup puts it into the public values of the batch proof, so the L1 contract trusts it once the proof is verified.
+func (eth *EthRelayer) WithdrawalRoot(from, to common.BlockNum) (ethcommon.Hash, error) {
+	leaves, err := eth.batchLeaves(from, to)
+	if err != nil {
+		return ethcommon.Hash{}, err
+	}
+	return MerkleRoot(leaves), nil
+}
+
+// WithdrawalProof is a withdrawal with its Merkle proof against the root of the batch [BatchFrom, BatchTo].
+type WithdrawalProof struct {
+	*Withdrawal
+	BatchFrom common.BlockNum  `json:"batch_from"`
+	BatchTo   common.BlockNum  `json:"batch_to"`
+	Leaf      ethcommon.Hash   `json:"leaf"`
+	Root      ethcommon.Hash   `json:"root"`
+	Proof     []ethcommon.Hash `json:"proof"`
+	// Sealed tells whether the batch has ended, the root of an open batch still changes.
+	Sealed bool `json:"sealed"`
+}
+
+// GetWithdrawalProof returns the WithdrawalProof of the withdrawal nonce.
+func (eth *EthRelayer) GetWithdrawalProof(ctx *yucontext.ReadContext) {
+	nonce, err := strconv.ParseUint(ctx.GetString("nonce"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	block, err := eth.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	proof, err := eth.withdrawalProof(nonce, block.Height)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(proof)
+}
+
+// GetWithdrawalProofs returns the WithdrawalProof of every withdrawal of account.
+func (eth *EthRelayer) GetWithdrawalProofs(ctx *yucontext.ReadContext) {
+	nonces, err := eth.getWithdrawalsOf(ctx.GetString("account"))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	block, err := eth.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	proofs := make([]*WithdrawalProof, 0, len(nonces))
+	for _, nonce := range nonces {
+		proof, err := eth.withdrawalProof(nonce, block.Height)
+		if err != nil {
+			ctx.ErrOk(err)
+			return
+		}
+		proofs = append(proofs, proof)
+	}
+	ctx.JsonOk(proofs)
+}
+
+func (eth *EthRelayer) withdrawalProof(nonce uint64, height common.BlockNum) (*WithdrawalProof, error) {
+	byt, err := eth.Get(withdrawalKey(nonce))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, errors.New("withdrawal not found")
+	}
+	withdrawal := new(Withdrawal)
+	if err = json.Unmarshal(byt, withdrawal); err != nil {
+		return nil, err
+	}
+	from, to, err := eth.batchOf(withdrawal.Height)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := eth.batchLeaves(from, to)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := withdrawal.Leaf()
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i := range leaves {
+		if leaves[i] == leaf {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("withdrawal is not in its batch")
+	}
+	return &WithdrawalProof{
+		Withdrawal: withdrawal,
+		BatchFrom:  from,
+		BatchTo:    to,
+		Leaf:       leaf,
+		Root:       MerkleRoot(leaves),
+		Proof:      MerkleProof(leaves, index),
+		Sealed:     height >= to,
+	}, nil
+}
+
+// batchOf returns the L2 blocks of the batch containing height, the batches are [1, size], [size+1, 2*size], ...
+func (eth *EthRelayer) batchOf(height common.BlockNum) (from, to common.BlockNum, err error) {
+	size := common.BlockNum(eth.cfg.WithdrawalBatchSize)
+	if size == 0 {
+		return 0, 0, errors.New("no withdrawal batch size configured")
+	}
+	from = (height-1)/size*size + 1
+	return from, from + size - 1, nil
+}
+
+func (eth *EthRelayer) batchLeaves(from, to common.BlockNum) ([]ethcommon.Hash, error) {
+	var leaves []ethcommon.Hash
+	for height := from; height <= to; height++ {
+		blockLeaves, err := eth.getLeaves(height)
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, blockLeaves...)
+	}
+	return leaves, nil
+}
+
+var withdrawalNonceKey = []byte("withdrawal_nonce")
+
+func withdrawalKey(nonce uint64) []byte {
+	return []byte("withdrawal/" + strconv.FormatUint(nonce, 10))
+}
+
+func withdrawalLeavesKey(height common.BlockNum) []byte {
+	return []byte("withdrawal_leaves/" + strconv.FormatUint(uint64(height), 10))
+}
+
+func withdrawalsOfKey(account string) []byte {
+	return []byte("withdrawals_of/" + account)
+}
+
+func (eth *EthRelayer) getLeaves(height common.BlockNum) ([]ethcommon.Hash, error) {
+	var leaves []ethcommon.Hash
+	_, err := bridge.GetJson(eth, withdrawalLeavesKey(height), &leaves)
+	return leaves, err
+}
+
+func (eth *EthRelayer) getWithdrawalsOf(account string) ([]uint64, error) {
+	var nonces []uint64
+	_, err := bridge.GetJson(eth, withdrawalsOfKey(account), &nonces)
+	return nonces, err
+}
+
+func (eth *EthRelayer) getUint(key []byte) (uint64, error) {
+	var n uint64
+	_, err := bridge.GetJson(eth, key, &n)
+	return n, err
+}
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/yu/common"
+)
+
+func TestBatchOf(t *testing.T) {
+	r := newTestRelayer(t)
+	tests := []struct {
+		height   common.BlockNum
+		from, to common.BlockNum
+	}{
+		{height: 1, from: 1, to: 10},
+		{height: 10, from: 1, to: 10},
+		{height: 11, from: 11, to: 20},
+		{height: 25, from: 21, to: 30},
+	}
+	for _, tt := range tests {
+		from, to, err := r.batchOf(tt.height)
+		mustOk(t, err)
+		if from != tt.from || to != tt.to {
+			t.Fatalf("batch of block %d is [%d, %d], want [%d, %d]", tt.height, from, to, tt.from, tt.to)
+		}
+	}
+	r.cfg.WithdrawalBatchSize = 0
+	if _, _, err := r.batchOf(1); err == nil {
+		t.Fatal("batched withdrawals without a batch size")
+	}
+}
+
+func TestWithdrawalProof(t *testing.T) {
+	r := newTestRelayer(t)
+	// nonces 0 to 2 are in the batch [1, 10], nonce 3 in [11, 20]
+	for i, height := range []common.BlockNum{1, 1, 5, 12} {
+		mustOk(t, r.state.Execute(func() error {
+			return r.appendWithdrawal(&Withdrawal{
+				From:      "alice",
+				Recipient: r.alice.From.Hex(),
+				Token:     BridgedTokenID(r.alice.From),
+				L1Token:   r.alice.From.Hex(),
+				Amount:    big.NewInt(int64(i + 1)),
+				Height:    height,
+			})
+		}))
+	}
+	root, err := r.WithdrawalRoot(1, 10)
+	mustOk(t, err)
+
+	tests := []struct {
+		nonce      uint64
+		height     common.BlockNum
+		from, to   common.BlockNum
+		wantSealed bool
+	}{
+		{nonce: 0, height: 9, from: 1, to: 10},
+		{nonce: 1, height: 10, from: 1, to: 10, wantSealed: true},
+		{nonce: 2, height: 12, from: 1, to: 10, wantSealed: true},
+		{nonce: 3, height: 12, from: 11, to: 20},
+	}
+	for _, tt := range tests {
+		proof, err := r.withdrawalProof(tt.nonce, tt.height)
+		mustOk(t, err)
+		if proof.Nonce != tt.nonce || proof.BatchFrom != tt.from || proof.BatchTo != tt.to || proof.Sealed != tt.wantSealed {
+			t.Fatalf("proof of withdrawal %d at block %d: %+v", tt.nonce, tt.height, proof)
+		}
+		if !VerifyMerkleProof(proof.Root, proof.Leaf, proof.Proof) {
+			t.Fatalf("proof of withdrawal %d does not verify", tt.nonce)
+		}
+		if tt.from == 1 && proof.Root != root {
+			t.Fatalf("withdrawal %d proven against %s, want the batch root %s", tt.nonce, proof.Root.Hex(), root.Hex())
+		}
+	}
+	if _, err = r.withdrawalProof(4, 12); err == nil {
+		t.Fatal("proved a withdrawal never made")
+	}
+}
@@ -0,0 +1,46 @@
+package bridge
+
+import (
+	"encoding/json"
+
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/types"
+)
+
+// Pool is the txpool a Relayer proposes its txns into, like the Pool of its tripod.
+type Pool interface {
+	Insert(stxn *types.SignedTxn) error
+	ResetByHashes(hashes []common.Hash) error
+}
+
+// Proposal is the last txn a Relayer proposed, see RelayDeposits.
+type Proposal struct {
+	hash common.Hash
+}
+
+// Propose puts a txn calling the writing of the tripod with req into pool. It replaces the last txn proposed,
+// which is stale if a block another node produced left it unpacked.
+func (p *Proposal) Propose(pool Pool, tripodName, writing string, req any) (*types.SignedTxn, error) {
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	stxn, err := types.NewSignedTxn(&common.WrCall{
+		TripodName: tripodName,
+		FuncName:   writing,
+		Params:     string(params),
+	}, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.hash != (common.Hash{}) {
+		if err = pool.ResetByHashes([]common.Hash{p.hash}); err != nil {
+			return nil, err
+		}
+	}
+	if err = pool.Insert(stxn); err != nil {
+		return nil, err
+	}
+	p.hash = stxn.TxnHash
+	return stxn, nil
+}
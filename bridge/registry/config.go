@@ -0,0 +1,8 @@
+package registry
+
+type Config struct {
+	// Governor is the account allowed to add and remove tokens from the allowlist.
+	Governor string `toml:"governor"`
+	// EnforceAllowlist only bridges the tokens on the allowlist, otherwise any token is mapped on its first deposit.
+	EnforceAllowlist bool `toml:"enforce_allowlist"`
+}
@@ -0,0 +1,240 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/tripod"
+)
+
+var (
+	ErrTokenMapped    = errors.New("token is already mapped")
+	ErrTokenIDMapped  = errors.New("token id is already mapped to another token")
+	ErrTokenNotMapped = errors.New("token is not mapped")
+	ErrNoGovernor     = errors.New("allowlist governance is not configured")
+)
+
+// TokenMapping maps a token on another chain one-to-one to the UDT bridged from it,
+// with the metadata of the original token.
+type TokenMapping struct {
+	Origin   *udt.ChainToken `json:"origin"`
+	Token    udt.TokenID     `json:"token"`
+	Name     string          `json:"name"`
+	Symbol   string          `json:"symbol"`
+	Decimals uint8           `json:"decimals"`
+}
+
+// TokenRegistry keeps the mappings between the tokens of other chains and their bridged UDTs,
+// and the allowlist of the tokens the relayers may bridge.
+type TokenRegistry struct {
+	*tripod.Tripod
+
+	Account *account.AccountTripod `tripod:"account"`
+
+	cfg *Config
+}
+
+func NewTokenRegistry(cfg *Config) *TokenRegistry {
+	r := &TokenRegistry{
+		Tripod: tripod.NewTripodWithName("tokenregistry"),
+		cfg:    cfg,
+	}
+	r.SetWritings(r.AllowToken, r.DisallowToken)
+	r.SetReadings(r.GetTokenMapping, r.GetTokenMappingOf, r.GetAllowlist)
+	return r
+}
+
+// Lookup returns the mapping of the original token, nil if it has none.
+func (r *TokenRegistry) Lookup(origin *udt.ChainToken) (*TokenMapping, error) {
+	mapping := new(TokenMapping)
+	found, err := bridge.GetJson(r, mappingKey(origin), mapping)
+	if err != nil || !found {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// LookupToken returns the mapping of a bridged UDT, nil if it has none.
+func (r *TokenRegistry) LookupToken(id udt.TokenID) (*TokenMapping, error) {
+	origin := new(udt.ChainToken)
+	found, err := bridge.GetJson(r, originKey(id), origin)
+	if err != nil || !found {
+		return nil, err
+	}
+	return r.Lookup(origin)
+}
+
+// Register adds the mapping, neither its original token nor its UDT may be mapped already.
+func (r *TokenRegistry) Register(mapping *TokenMapping) error {
+	if mapping.Origin == nil || mapping.Origin.ChainURL == "" || len(mapping.Origin.TokenAddress) == 0 {
+		return errors.New("invalid original token")
+	}
+	if mapping.Token == "" || mapping.Token.IsNative() {
+		return errors.New("invalid token id")
+	}
+	if r.Exist(mappingKey(mapping.Origin)) {
+		return ErrTokenMapped
+	}
+	if r.Exist(originKey(mapping.Token)) {
+		return ErrTokenIDMapped
+	}
+	if err := bridge.SetJson(r, mappingKey(mapping.Origin), mapping); err != nil {
+		return err
+	}
+	return bridge.SetJson(r, originKey(mapping.Token), mapping.Origin)
+}
+
+// IsAllowed tells whether the original token may be bridged, any token may unless the allowlist is enforced.
+func (r *TokenRegistry) IsAllowed(origin *udt.ChainToken) (bool, error) {
+	if r.cfg == nil || !r.cfg.EnforceAllowlist {
+		return true, nil
+	}
+	allowlist, err := r.getAllowlist()
+	if err != nil {
+		return false, err
+	}
+	return indexOf(allowlist, origin) >= 0, nil
+}
+
+type AllowTokenRequest struct {
+	ChainURL string `json:"chain_url"`
+	// Address is the hex address of the token on its chain, see parseOrigin.
+	Address      string `json:"address"`
+	GovernorArgs []byte `json:"governor_args"`
+}
+
+// AllowToken adds a token to the allowlist, only the governor can call it.
+func (r *TokenRegistry) AllowToken(ctx *context.WriteContext) error {
+	origin, allowlist, err := r.bindAllowlistRequest(ctx)
+	if err != nil {
+		return err
+	}
+	if indexOf(allowlist, origin) >= 0 {
+		return nil
+	}
+	return bridge.SetJson(r, allowlistKey, append(allowlist, origin))
+}
+
+// DisallowToken removes a token from the allowlist, only the governor can call it.
+// Its deposits are no longer bridged, the tokens bridged before can still be withdrawn.
+func (r *TokenRegistry) DisallowToken(ctx *context.WriteContext) error {
+	origin, allowlist, err := r.bindAllowlistRequest(ctx)
+	if err != nil {
+		return err
+	}
+	i := indexOf(allowlist, origin)
+	if i < 0 {
+		return errors.New("token is not on the allowlist")
+	}
+	return bridge.SetJson(r, allowlistKey, append(allowlist[:i], allowlist[i+1:]...))
+}
+
+func (r *TokenRegistry) bindAllowlistRequest(ctx *context.WriteContext) (*udt.ChainToken, []*udt.ChainToken, error) {
+	req := new(AllowTokenRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return nil, nil, err
+	}
+	if r.cfg == nil || r.cfg.Governor == "" {
+		return nil, nil, ErrNoGovernor
+	}
+	if err := r.Account.VerifyOwner(r.cfg.Governor, req.GovernorArgs); err != nil {
+		return nil, nil, err
+	}
+	origin, err := parseOrigin(req.ChainURL, req.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	allowlist, err := r.getAllowlist()
+	return origin, allowlist, err
+}
+
+// GetTokenMapping returns the TokenMapping of the token at address on chain_url.
+func (r *TokenRegistry) GetTokenMapping(ctx *context.ReadContext) {
+	origin, err := parseOrigin(ctx.GetString("chain_url"), ctx.GetString("address"))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	mapping, err := r.Lookup(origin)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	if mapping == nil {
+		ctx.ErrOk(ErrTokenNotMapped)
+		return
+	}
+	ctx.JsonOk(mapping)
+}
+
+// GetTokenMappingOf returns the TokenMapping of the bridged UDT token_id.
+func (r *TokenRegistry) GetTokenMappingOf(ctx *context.ReadContext) {
+	mapping, err := r.LookupToken(udt.TokenID(ctx.GetString("token_id")))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	if mapping == nil {
+		ctx.ErrOk(ErrTokenNotMapped)
+		return
+	}
+	ctx.JsonOk(mapping)
+}
+
+func (r *TokenRegistry) GetAllowlist(ctx *context.ReadContext) {
+	allowlist, err := r.getAllowlist()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(allowlist)
+}
+
+var allowlistKey = []byte("allowlist")
+
+func mappingKey(origin *udt.ChainToken) []byte {
+	return []byte("mapping/" + origin.ChainURL + "/" + hex.EncodeToString(origin.TokenAddress))
+}
+
+func originKey(id udt.TokenID) []byte {
+	return []byte("origin/" + string(id))
+}
+
+// parseOrigin parses a hex token address, the zero address standing for the native coin of an EVM chain.
+// The address of a token on a yu chain is its TokenID in hex.
+func parseOrigin(chainURL, address string) (*udt.ChainToken, error) {
+	if chainURL == "" {
+		return nil, errors.New("empty chain url")
+	}
+	if ethcommon.IsHexAddress(address) {
+		return &udt.ChainToken{ChainURL: chainURL, TokenAddress: ethcommon.HexToAddress(address).Bytes()}, nil
+	}
+	tokenAddress, err := hexutil.Decode(address)
+	if err != nil || len(tokenAddress) == 0 {
+		return nil, fmt.Errorf("invalid token address %q", address)
+	}
+	return &udt.ChainToken{ChainURL: chainURL, TokenAddress: tokenAddress}, nil
+}
+
+func indexOf(allowlist []*udt.ChainToken, origin *udt.ChainToken) int {
+	for i, token := range allowlist {
+		if token.ChainURL == origin.ChainURL && bytes.Equal(token.TokenAddress, origin.TokenAddress) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *TokenRegistry) getAllowlist() ([]*udt.ChainToken, error) {
+	var allowlist []*udt.ChainToken
+	_, err := bridge.GetJson(r, allowlistKey, &allowlist)
+	return allowlist, err
+}
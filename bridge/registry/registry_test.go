@@ -0,0 +1,211 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+)
+
+const l1 = "eip155:1"
+
+var usdc = &udt.ChainToken{ChainURL: l1, TokenAddress: bytes.Repeat([]byte{0xaa}, 20)}
+
+type testRegistry struct {
+	*TokenRegistry
+	state *memstate.State
+}
+
+func newTestRegistry(t *testing.T, cfg *Config) *testRegistry {
+	state := memstate.New()
+	chainEnv := &env.ChainEnv{State: state}
+	acc := account.NewAccountTripod()
+	acc.SetChainEnv(chainEnv)
+	mustOk(t, acc.AddBalance("gov", "USD", big.NewInt(0)))
+	r := NewTokenRegistry(cfg)
+	r.SetChainEnv(chainEnv)
+	r.Account = acc
+	return &testRegistry{TokenRegistry: r, state: state}
+}
+
+// write runs a writing of the registry as a txn.
+func (tr *testRegistry) write(t *testing.T, writing func(*context.WriteContext) error, req *AllowTokenRequest) error {
+	params, err := context.NewParamsResponseFromStr(string(mustJson(t, req)))
+	mustOk(t, err)
+	return tr.state.Execute(func() error {
+		return writing(&context.WriteContext{ParamsResponse: params})
+	})
+}
+
+func (tr *testRegistry) allowlist(t *testing.T) []*udt.ChainToken {
+	allowlist, err := tr.getAllowlist()
+	mustOk(t, err)
+	return allowlist
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	tr := newTestRegistry(t, nil)
+	mapping := &TokenMapping{Origin: usdc, Token: "l1-usdc", Name: "USD Coin", Symbol: "USDC", Decimals: 6}
+	mustOk(t, tr.state.Execute(func() error { return tr.Register(mapping) }))
+
+	got, err := tr.Lookup(&udt.ChainToken{ChainURL: l1, TokenAddress: bytes.Repeat([]byte{0xaa}, 20)})
+	mustOk(t, err)
+	if got == nil || got.Token != "l1-usdc" || got.Symbol != "USDC" || got.Decimals != 6 {
+		t.Fatalf("lookup of the original token: %+v", got)
+	}
+	if got, err = tr.LookupToken("l1-usdc"); err != nil || got == nil || !bytes.Equal(got.Origin.TokenAddress, usdc.TokenAddress) {
+		t.Fatalf("lookup of the bridged token: %+v, %v", got, err)
+	}
+	// the same address on another chain is another token
+	if got, err = tr.Lookup(&udt.ChainToken{ChainURL: "eip155:10", TokenAddress: usdc.TokenAddress}); err != nil || got != nil {
+		t.Fatalf("lookup of an unmapped token: %+v, %v", got, err)
+	}
+	if got, err = tr.LookupToken("l1-dai"); err != nil || got != nil {
+		t.Fatalf("lookup of an unmapped bridged token: %+v, %v", got, err)
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	tr := newTestRegistry(t, nil)
+	mustOk(t, tr.state.Execute(func() error {
+		return tr.Register(&TokenMapping{Origin: usdc, Token: "l1-usdc"})
+	}))
+	dai := &udt.ChainToken{ChainURL: l1, TokenAddress: bytes.Repeat([]byte{0xbb}, 20)}
+	tests := []struct {
+		name    string
+		mapping *TokenMapping
+		wantErr string
+	}{
+		{"no original token", &TokenMapping{Token: "l1-dai"}, "invalid original token"},
+		{"no chain url", &TokenMapping{Origin: &udt.ChainToken{TokenAddress: dai.TokenAddress}, Token: "l1-dai"}, "invalid original token"},
+		{"no token address", &TokenMapping{Origin: &udt.ChainToken{ChainURL: l1}, Token: "l1-dai"}, "invalid original token"},
+		{"no token id", &TokenMapping{Origin: dai}, "invalid token id"},
+		{"native token id", &TokenMapping{Origin: dai, Token: udt.NativeToken.Name}, "invalid token id"},
+		{"original token mapped", &TokenMapping{Origin: usdc, Token: "l1-usdc2"}, ErrTokenMapped.Error()},
+		{"token id mapped", &TokenMapping{Origin: dai, Token: "l1-usdc"}, ErrTokenIDMapped.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tr.Register(tt.mapping)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("Register returned %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+	if got, err := tr.Lookup(dai); err != nil || got != nil {
+		t.Fatalf("a rejected mapping was registered: %+v, %v", got, err)
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	tr := newTestRegistry(t, &Config{Governor: "gov", EnforceAllowlist: true})
+	allowed := func() bool {
+		ok, err := tr.IsAllowed(usdc)
+		mustOk(t, err)
+		return ok
+	}
+	if allowed() {
+		t.Fatal("a token off the enforced allowlist is allowed")
+	}
+
+	req := &AllowTokenRequest{ChainURL: l1, Address: "0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa"}
+	mustOk(t, tr.write(t, tr.AllowToken, req))
+	// allowing a token again changes nothing
+	mustOk(t, tr.write(t, tr.AllowToken, &AllowTokenRequest{ChainURL: l1, Address: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}))
+	if !allowed() || len(tr.allowlist(t)) != 1 {
+		t.Fatalf("allowlist after allowing the token: %v", tr.allowlist(t))
+	}
+
+	mustOk(t, tr.write(t, tr.DisallowToken, req))
+	if allowed() || len(tr.allowlist(t)) != 0 {
+		t.Fatalf("allowlist after disallowing the token: %v", tr.allowlist(t))
+	}
+	if err := tr.write(t, tr.DisallowToken, req); err == nil || err.Error() != "token is not on the allowlist" {
+		t.Fatalf("disallowed a token off the allowlist: %v", err)
+	}
+}
+
+func TestAllowlistNotEnforced(t *testing.T) {
+	tr := newTestRegistry(t, nil)
+	if ok, err := tr.IsAllowed(usdc); err != nil || !ok {
+		t.Fatalf("a token is not allowed without an enforced allowlist: %v, %v", ok, err)
+	}
+	err := tr.write(t, tr.AllowToken, &AllowTokenRequest{ChainURL: l1, Address: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"})
+	if !errors.Is(err, ErrNoGovernor) {
+		t.Fatalf("allowed a token without a governor: %v", err)
+	}
+}
+
+func TestGetAllowlist(t *testing.T) {
+	tr := newTestRegistry(t, &Config{Governor: "gov", EnforceAllowlist: true})
+	mustOk(t, tr.write(t, tr.AllowToken, &AllowTokenRequest{ChainURL: l1, Address: "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}))
+	mustOk(t, tr.write(t, tr.AllowToken, &AllowTokenRequest{ChainURL: "yu:jingchou", Address: "0x555344"}))
+	var allowlist []*udt.ChainToken
+	readJson(t, tr.GetAllowlist, struct{}{}, &allowlist)
+	if len(allowlist) != 2 || !bytes.Equal(allowlist[0].TokenAddress, usdc.TokenAddress) || string(allowlist[1].TokenAddress) != "USD" {
+		t.Fatalf("allowlist %v", allowlist)
+	}
+}
+
+func TestParseOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		chainURL string
+		address  string
+		want     []byte
+		wantErr  bool
+	}{
+		{"evm address", l1, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", usdc.TokenAddress, false},
+		{"checksummed evm address", l1, "0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa", usdc.TokenAddress, false},
+		{"native coin", l1, "0x0000000000000000000000000000000000000000", make([]byte, 20), false},
+		{"yu token", "yu:jingchou", "0x555344", []byte("USD"), false},
+		{"no chain url", "", "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", nil, true},
+		{"no address", l1, "", nil, true},
+		{"empty hex", l1, "0x", nil, true},
+		{"not hex", l1, "USD", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin, err := parseOrigin(tt.chainURL, tt.address)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsed %+v, want an error", origin)
+				}
+				return
+			}
+			mustOk(t, err)
+			if origin.ChainURL != tt.chainURL || !bytes.Equal(origin.TokenAddress, tt.want) {
+				t.Fatalf("parsed %s/%x, want %s/%x", origin.ChainURL, origin.TokenAddress, tt.chainURL, tt.want)
+			}
+		})
+	}
+}
+
+// readJson calls a reading with req and unmarshals its json response into resp.
+func readJson(t *testing.T, reading func(*context.ReadContext), req, resp any) {
+	ctx, err := context.NewReadContext(&common.RdCall{Params: string(mustJson(t, req))})
+	mustOk(t, err)
+	reading(ctx)
+	mustOk(t, json.Unmarshal(mustJson(t, ctx.Response().DataInterface), resp))
+}
+
+func mustJson(t *testing.T, v any) []byte {
+	byt, err := json.Marshal(v)
+	mustOk(t, err)
+	return byt
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package bridge
+
+import (
+	"context"
+
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/types"
+)
+
+// Relayer bridges the tokens between JingChou and another chain. Each kind of chain has its implementation,
+// eth.EthRelayer for Ethereum and yu.YuRelayer for another JingChou or yu chain.
+type Relayer interface {
+	// ChainURL identifies the other chain, it is the ChainURL of the OriginalToken of the UDTs bridged from it.
+	ChainURL() string
+	// Finalized returns the latest height of the other chain whose deposits can no longer be reverted.
+	Finalized(ctx context.Context) (uint64, error)
+	// RelayDeposits proposes crediting the deposits made on the other chain up to its finalized height
+	// in the block after block, each exactly once and in order. The node producing that block puts them into it
+	// as a txn, which the other nodes check against the other chain before they accept the block.
+	RelayDeposits(block *types.Block) error
+	// SubmitWithdrawals hands the withdrawals made in the block over to the other chain.
+	SubmitWithdrawals(block *types.Block) error
+	// Health reports how far the relayer has caught up with the other chain.
+	Health(ctx context.Context) *Health
+}
+
+// Leader is the consensus tripod choosing the node producing each block, like *poa.Poa.
+// A Relayer injects it to propose its txns only on the node producing the next block.
+type Leader interface {
+	AmILeader(blockHeight common.BlockNum) bool
+}
+
+// Health is how far a Relayer has caught up with the other chain.
+type Health struct {
+	ChainURL string `json:"chain_url"`
+	// Finalized is the finalized height of the other chain, Relayed is the last height of it relayed.
+	Finalized uint64 `json:"finalized"`
+	Relayed   uint64 `json:"relayed"`
+	// Error is why the other chain or the relay state can not be read, empty if the relayer is healthy.
+	Error string `json:"error,omitempty"`
+}
+
+func (h *Health) Healthy() bool {
+	return h.Error == ""
+}
+
+// Lag is how many finalized heights of the other chain are not relayed yet.
+func (h *Health) Lag() uint64 {
+	if h.Finalized < h.Relayed {
+		return 0
+	}
+	return h.Finalized - h.Relayed
+}
@@ -0,0 +1,27 @@
+package bridge
+
+import "encoding/json"
+
+// State is the state of a bridge tripod, *tripod.Tripod implements it.
+type State interface {
+	Get(key []byte) ([]byte, error)
+	Set(key, value []byte)
+}
+
+// GetJson unmarshals the value of key into v and tells whether the key exists, leaving v untouched if it is missing.
+func GetJson(state State, key []byte, v any) (bool, error) {
+	byt, err := state.Get(key)
+	if err != nil || byt == nil {
+		return false, err
+	}
+	return true, json.Unmarshal(byt, v)
+}
+
+func SetJson(state State, key []byte, v any) error {
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	state.Set(key, byt)
+	return nil
+}
@@ -0,0 +1,162 @@
+package yu
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/keypair"
+)
+
+var ErrNoSigner = errors.New("this node attests no transfers, no signer_secret configured")
+
+// TransferBatch is a run of published transfers of a chain, from nonce From on.
+type TransferBatch struct {
+	From      uint64      `json:"from"`
+	Transfers []*Transfer `json:"transfers"`
+}
+
+// Attestation is the signature of a node of a chain that a TransferBatch is in its outbox, in finalized blocks.
+type Attestation struct {
+	// Pubkey is the public key of the node in StringWithType.
+	Pubkey    string `json:"pubkey"`
+	Signature []byte `json:"signature"`
+}
+
+// digest is what an Attestation signs, the batch published by the chain fromChain for the chain toChain.
+// Both chains are signed along, so that an attestation relays nothing to a third chain bridged to fromChain.
+func (b *TransferBatch) digest(fromChain, toChain string) ([]byte, error) {
+	byt, err := json.Marshal(&struct {
+		FromChain string `json:"from_chain"`
+		ToChain   string `json:"to_chain"`
+		*TransferBatch
+	}{fromChain, toChain, b})
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(append([]byte(tripodName+"/batch/"), byt...))
+	return hash[:], nil
+}
+
+type AttestRequest struct {
+	From  uint64 `json:"from"`
+	Count uint64 `json:"count"`
+}
+
+// attest signs the published transfers [from, from+count) of this chain, once all of them are finalized.
+func (r *YuRelayer) attest(from, count uint64) (*Attestation, error) {
+	if r.signer == nil {
+		return nil, ErrNoSigner
+	}
+	finalized, err := r.finalized()
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := r.transfers(from, count)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(transfers)) != count {
+		return nil, fmt.Errorf("only %d transfers published from nonce %d", len(transfers), from)
+	}
+	for _, transfer := range transfers {
+		if transfer.Height > finalized {
+			return nil, fmt.Errorf("transfer %d in block(%d) is not finalized", transfer.Nonce, transfer.Height)
+		}
+	}
+	digest, err := (&TransferBatch{From: from, Transfers: transfers}).digest(r.cfg.ChainURL, r.cfg.PeerChainURL)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := r.signer.SignData(digest)
+	if err != nil {
+		return nil, err
+	}
+	return &Attestation{Pubkey: r.signerPubkey, Signature: signature}, nil
+}
+
+// countAttestations returns how many distinct PeerSigners have signed the batch validly, ignoring any other signature.
+func (r *YuRelayer) countAttestations(batch *TransferBatch, attestations []*Attestation) (int, error) {
+	digest, err := batch.digest(r.cfg.PeerChainURL, r.cfg.ChainURL)
+	if err != nil {
+		return 0, err
+	}
+	signed := make(map[string]bool)
+	for _, attestation := range attestations {
+		pubkey, ok := r.peerSigners[attestation.Pubkey]
+		if !ok || signed[attestation.Pubkey] || !pubkey.VerifySignature(digest, attestation.Signature) {
+			continue
+		}
+		signed[attestation.Pubkey] = true
+	}
+	return len(signed), nil
+}
+
+// verifyAttestations makes sure a quorum of PeerSigners has attested the batch.
+func (r *YuRelayer) verifyAttestations(batch *TransferBatch, attestations []*Attestation) error {
+	count, err := r.countAttestations(batch, attestations)
+	if err != nil {
+		return err
+	}
+	if count < r.quorum {
+		return fmt.Errorf("%d of the peer signers attested transfers from nonce %d, the quorum is %d", count, batch.From, r.quorum)
+	}
+	return nil
+}
+
+// GetAttestation signs the published transfers [From, From+Count) once all of them are finalized.
+// The other chain relays them through it, so the params are bound as json rather than read by GetString.
+func (r *YuRelayer) GetAttestation(ctx *yucontext.ReadContext) {
+	req := new(AttestRequest)
+	if err := ctx.BindJson(req); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	attestation, err := r.attest(req.From, req.Count)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(attestation)
+}
+
+// newSigner generates the key this node attests with, nil without a secret.
+func newSigner(cfg *Config) (keypair.PrivKey, string, error) {
+	if cfg.SignerSecret == "" {
+		return nil, "", nil
+	}
+	keyType := cfg.SignerKeyType
+	if keyType == "" {
+		keyType = keypair.Ed25519
+	}
+	pubkey, privkey, err := keypair.GenKeyPairWithSecret(keyType, []byte(cfg.SignerSecret))
+	if err != nil {
+		return nil, "", err
+	}
+	return privkey, pubkey.StringWithType(), nil
+}
+
+// newPeerSigners parses the PeerSigners keyed by their StringWithType, and returns the quorum of them.
+func newPeerSigners(cfg *Config) (map[string]keypair.PubKey, int, error) {
+	if len(cfg.PeerSigners) == 0 {
+		return nil, 0, errors.New("no peer_signers configured")
+	}
+	signers := make(map[string]keypair.PubKey, len(cfg.PeerSigners))
+	for _, str := range cfg.PeerSigners {
+		pubkey, err := keypair.PubkeyFromStr(str)
+		if err != nil || pubkey == nil {
+			return nil, 0, fmt.Errorf("invalid peer signer %s: %v", str, err)
+		}
+		signers[pubkey.StringWithType()] = pubkey
+	}
+	quorum := cfg.PeerQuorum
+	if quorum == 0 {
+		quorum = len(signers)
+	}
+	if quorum < 0 || quorum > len(signers) {
+		return nil, 0, fmt.Errorf("peer_quorum %d out of %d peer signers", cfg.PeerQuorum, len(signers))
+	}
+	return signers, quorum, nil
+}
@@ -0,0 +1,25 @@
+package yu
+
+type Config struct {
+	// ChainURL identifies this chain, it is the PeerChainURL of the YuRelayer on the other chain.
+	ChainURL string `toml:"chain_url"`
+	// PeerChainURL identifies the other chain in the OriginalToken of the UDTs bridged from it, e.g. "yu:2".
+	PeerChainURL string `toml:"peer_chain_url"`
+	// PeerAddresses are the http addresses of nodes of the other chain, e.g. "http://localhost:7999", whose YuRelayer
+	// readings are called: the transfers are read from the first one answering, and attested by each of them.
+	// Empty means the peers are set by SetPeers, e.g. to in-process chains.
+	PeerAddresses []string `toml:"peer_addresses"`
+	// PeerSigners are the public keys, in StringWithType, of the nodes of the other chain attesting its transfers.
+	// It must be set, and the same on every node of this chain.
+	PeerSigners []string `toml:"peer_signers"`
+	// PeerQuorum is how many distinct PeerSigners must attest the transfers relayed, 0 means all of them.
+	PeerQuorum int `toml:"peer_quorum"`
+	// SignerKeyType and SignerSecret generate the key this node attests the transfers of its own chain with,
+	// an empty SignerSecret means this node attests nothing. SignerKeyType is ed25519 if empty.
+	SignerKeyType string `toml:"signer_key_type"`
+	SignerSecret  string `toml:"signer_secret"`
+	// MaxTransfersPerBlock bounds the transfers relayed in one block, 0 means DefaultMaxTransfersPerBlock.
+	MaxTransfersPerBlock uint64 `toml:"max_transfers_per_block"`
+}
+
+const DefaultMaxTransfersPerBlock = 1000
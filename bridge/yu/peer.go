@@ -0,0 +1,119 @@
+package yu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/yu-org/yu/common"
+)
+
+var ErrNoPeer = errors.New("no peer chain is set")
+
+// readingPath is where a yu chain serves the reading calls over http.
+const readingPath = "/api/reading"
+
+// Peer reads the YuRelayer of a node of the other chain.
+type Peer interface {
+	// Finalized returns the last finalized block of the other chain.
+	Finalized() (common.BlockNum, error)
+	// Transfers returns the published transfers of the other chain from nonce from on, at most limit of them.
+	Transfers(from, limit uint64) ([]*Transfer, error)
+	// Attest returns the signature of the node over the published transfers [from, from+count),
+	// once all of them are finalized.
+	Attest(from, count uint64) (*Attestation, error)
+}
+
+// NewLocalPeer reads the YuRelayer of a chain running in the same process, e.g. to bridge two chains in tests.
+func NewLocalPeer(r *YuRelayer) Peer {
+	return &localPeer{r: r}
+}
+
+type localPeer struct {
+	r *YuRelayer
+}
+
+func (p *localPeer) Finalized() (common.BlockNum, error) {
+	return p.r.finalized()
+}
+
+func (p *localPeer) Transfers(from, limit uint64) ([]*Transfer, error) {
+	return p.r.transfers(from, limit)
+}
+
+func (p *localPeer) Attest(from, count uint64) (*Attestation, error) {
+	return p.r.attest(from, count)
+}
+
+// NewHttpPeer calls the readings of the YuRelayer named name of the chain serving http at address,
+// the TripodName of this chain there.
+func NewHttpPeer(address, name string) Peer {
+	return &httpPeer{
+		url:        strings.TrimSuffix(address, "/") + readingPath,
+		tripodName: name,
+		client:     http.DefaultClient,
+	}
+}
+
+type httpPeer struct {
+	url        string
+	tripodName string
+	client     *http.Client
+}
+
+func (p *httpPeer) Finalized() (common.BlockNum, error) {
+	finalized := new(FinalizedHeight)
+	err := p.read("GetFinalized", struct{}{}, finalized)
+	return finalized.Height, err
+}
+
+func (p *httpPeer) Transfers(from, limit uint64) ([]*Transfer, error) {
+	var transfers []*Transfer
+	err := p.read("GetTransfers", &GetTransfersRequest{From: from, Limit: limit}, &transfers)
+	return transfers, err
+}
+
+func (p *httpPeer) Attest(from, count uint64) (*Attestation, error) {
+	attestation := new(Attestation)
+	err := p.read("GetAttestation", &AttestRequest{From: from, Count: count}, attestation)
+	return attestation, err
+}
+
+func (p *httpPeer) read(funcName string, params any, v any) error {
+	paramsByt, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&common.RdCall{
+		TripodName: p.tripodName,
+		FuncName:   funcName,
+		Params:     string(paramsByt),
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Post(p.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	byt, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading %s of the peer chain: %s", funcName, resp.Status)
+	}
+	// a reading failed with ctx.ErrOk responds {"err": ...}
+	var failed struct {
+		Err json.RawMessage `json:"err"`
+	}
+	if json.Unmarshal(byt, &failed) == nil && failed.Err != nil {
+		return fmt.Errorf("reading %s of the peer chain failed: %s", funcName, failed.Err)
+	}
+	return json.Unmarshal(byt, v)
+}
@@ -0,0 +1,465 @@
+package yu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/keypair"
+	"github.com/yu-org/yu/core/tripod"
+	"github.com/yu-org/yu/core/types"
+)
+
+const (
+	tripodName            = "yurelayer"
+	relayTransfersWriting = "RelayTransfers"
+)
+
+var _ bridge.Relayer = (*YuRelayer)(nil)
+
+// YuRelayer is the bridge.Relayer of another JingChou or yu chain running a YuRelayer too.
+// Each side locks the tokens native to it into its outbox, or burns the tokens bridged from the other side,
+// and pulls the outbox of the other side once finalized: minting the bridged UDT of a token native to the other side,
+// mapped one-to-one in the token registry, or unlocking a token of its own coming back.
+//
+// The nodes of each side attest its finalized transfers with their signer keys. The block producer collects
+// the attestations of a quorum of the configured peer signers and proposes the transfers as a RelayTransfers txn,
+// which every node checks against the signatures alone, so that all nodes write the same state.
+type YuRelayer struct {
+	*tripod.Tripod
+	cfg      *Config
+	UDT      *udt.UdtTripod          `tripod:"udt"`
+	Account  *account.AccountTripod  `tripod:"account"`
+	Registry *registry.TokenRegistry `tripod:"tokenregistry"`
+	// Poa tells whether this node produces the next block, without it every node proposes the RelayTransfers txns.
+	Poa bridge.Leader `tripod:"poa,omitempty"`
+	// peers are empty until they are set, nothing is relayed then.
+	peers []Peer
+	// peerSigners are the keys attesting the transfers of the other chain, quorum of them must sign.
+	peerSigners map[string]keypair.PubKey
+	quorum      int
+	// signer is the key this node attests the transfers of its own chain with, nil if it attests nothing.
+	signer       keypair.PrivKey
+	signerPubkey string
+	// proposal is the last RelayTransfers txn this node put into its txpool.
+	proposal bridge.Proposal
+}
+
+// RelayState is how far the outbox of the other chain has been relayed.
+type RelayState struct {
+	// PeerHeight is the block of the other chain with the last transfer relayed.
+	PeerHeight common.BlockNum `json:"peer_height"`
+	// NextNonce is the nonce of the next transfer of the other chain to relay.
+	NextNonce uint64 `json:"next_nonce"`
+}
+
+// ErrTokenTaken is returned when the UDT name of a token of the other chain belongs to another UDT,
+// the transfers of that token bounce back then.
+var ErrTokenTaken = errors.New("bridged token name is taken by another udt")
+
+// TripodName is the name of the YuRelayer bridging to the chain peerChainURL. A chain bridged to several chains
+// runs a YuRelayer for each of them, every one with its own outbox and relay state.
+func TripodName(peerChainURL string) string {
+	return tripodName + "/" + peerChainURL
+}
+
+// BridgedTokenID is the UDT minted for the token of the other chain. The length of chainURL before it
+// keeps ("a-b", "c") and ("a", "b-c") apart.
+func BridgedTokenID(chainURL string, token udt.TokenID) udt.TokenID {
+	return udt.TokenID(strconv.Itoa(len(chainURL)) + ":" + chainURL + "-" + string(token))
+}
+
+func NewYuRelayer(cfg *Config) (*YuRelayer, error) {
+	if cfg.ChainURL == "" || cfg.PeerChainURL == "" {
+		return nil, errors.New("chain_url and peer_chain_url must be configured")
+	}
+	peerSigners, quorum, err := newPeerSigners(cfg)
+	if err != nil {
+		return nil, err
+	}
+	signer, signerPubkey, err := newSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	r := &YuRelayer{
+		Tripod:       tripod.NewTripodWithName(TripodName(cfg.PeerChainURL)),
+		cfg:          cfg,
+		peerSigners:  peerSigners,
+		quorum:       quorum,
+		signer:       signer,
+		signerPubkey: signerPubkey,
+	}
+	for _, address := range cfg.PeerAddresses {
+		r.peers = append(r.peers, NewHttpPeer(address, TripodName(cfg.ChainURL)))
+	}
+	r.SetWritings(r.Transfer, r.RelayTransfers)
+	r.SetReadings(
+		r.GetTransfer, r.GetTransfers, r.GetInboundTransfer, r.GetLocked,
+		r.GetFinalized, r.GetAttestation, r.GetRelayState, r.GetHealth,
+	)
+	return r, nil
+}
+
+// SetPeers sets how the nodes of the other chain are read.
+func (r *YuRelayer) SetPeers(peers ...Peer) {
+	r.peers = peers
+}
+
+// SignerPubkey is the public key this node attests the transfers of its chain with, in StringWithType,
+// empty if it attests nothing. The other chain lists it in its PeerSigners.
+func (r *YuRelayer) SignerPubkey() string {
+	return r.signerPubkey
+}
+
+func (r *YuRelayer) StartBlock(block *types.Block) {}
+
+// EndBlock publishes the transfers made in the block. Its writes are sealed in a stash of their own,
+// so that they are not discarded with the first txn of the next block.
+func (r *YuRelayer) EndBlock(block *types.Block) {
+	if err := r.SubmitWithdrawals(block); err != nil {
+		logrus.Errorf("publish transfers of block(%d) failed: %v", block.Height, err)
+	}
+	r.NextTxn()
+}
+
+func (r *YuRelayer) FinalizeBlock(block *types.Block) {
+	if err := r.RelayDeposits(block); err != nil {
+		logrus.Errorf("propose relaying transfers of %s after block(%d) failed: %v", r.cfg.PeerChainURL, block.Height, err)
+	}
+}
+
+func (r *YuRelayer) ChainURL() string {
+	return r.cfg.PeerChainURL
+}
+
+// Finalized returns the last finalized block of the other chain, as the first peer answering tells.
+func (r *YuRelayer) Finalized(context.Context) (uint64, error) {
+	var err error = ErrNoPeer
+	for _, peer := range r.peers {
+		var height common.BlockNum
+		if height, err = peer.Finalized(); err == nil {
+			return uint64(height), nil
+		}
+	}
+	return 0, err
+}
+
+// RelayTransfersRequest relays a TransferBatch of the other chain attested by its nodes.
+type RelayTransfersRequest struct {
+	*TransferBatch
+	Attestations []*Attestation `json:"attestations"`
+}
+
+// RelayDeposits proposes crediting the finalized transfers of the other chain after the relay state,
+// at most MaxTransfersPerBlock of them, in the block after block: the node producing it reads them from a peer,
+// collects the attestations of a quorum of the peer signers and puts a RelayTransfers txn into its txpool.
+func (r *YuRelayer) RelayDeposits(block *types.Block) error {
+	if len(r.peers) == 0 || (r.Poa != nil && !r.Poa.AmILeader(block.Height+1)) {
+		return nil
+	}
+	state, err := r.getRelayState()
+	if err != nil {
+		return err
+	}
+	batch, err := r.fetchBatch(state.NextNonce)
+	if err != nil || len(batch.Transfers) == 0 {
+		return err
+	}
+	attestations, err := r.collectAttestations(batch)
+	if err != nil {
+		return err
+	}
+	_, err = r.proposal.Propose(r.Pool, r.Name(), relayTransfersWriting, &RelayTransfersRequest{TransferBatch: batch, Attestations: attestations})
+	return err
+}
+
+// fetchBatch reads the finalized transfers from nonce from on, at most MaxTransfersPerBlock of them,
+// from the first peer answering.
+func (r *YuRelayer) fetchBatch(from uint64) (*TransferBatch, error) {
+	var err error = ErrNoPeer
+	for _, peer := range r.peers {
+		var finalized common.BlockNum
+		if finalized, err = peer.Finalized(); err != nil {
+			continue
+		}
+		var transfers []*Transfer
+		if transfers, err = peer.Transfers(from, r.maxTransfersPerBlock()); err != nil {
+			continue
+		}
+		batch := &TransferBatch{From: from}
+		for _, transfer := range transfers {
+			if transfer.Height > finalized || transfer.Nonce != from+uint64(len(batch.Transfers)) {
+				break
+			}
+			batch.Transfers = append(batch.Transfers, transfer)
+		}
+		return batch, nil
+	}
+	return nil, err
+}
+
+// collectAttestations asks the peers to attest the batch until a quorum of the peer signers has.
+func (r *YuRelayer) collectAttestations(batch *TransferBatch) ([]*Attestation, error) {
+	var attestations []*Attestation
+	for _, peer := range r.peers {
+		attestation, err := peer.Attest(batch.From, uint64(len(batch.Transfers)))
+		if err != nil {
+			logrus.Debugf("peer of %s attests no transfers from nonce %d: %v", r.cfg.PeerChainURL, batch.From, err)
+			continue
+		}
+		attestations = append(attestations, attestation)
+		count, err := r.countAttestations(batch, attestations)
+		if err != nil {
+			return nil, err
+		}
+		if count >= r.quorum {
+			return attestations, nil
+		}
+	}
+	return nil, r.verifyAttestations(batch, attestations)
+}
+
+// RelayTransfers credits an attested batch of transfers of the other chain in nonce order.
+// It fails unless the batch starts at the next nonce to relay and a quorum of the peer signers has attested it,
+// and then writes nothing.
+func (r *YuRelayer) RelayTransfers(ctx *yucontext.WriteContext) error {
+	req := new(RelayTransfersRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if req.TransferBatch == nil || len(req.Transfers) == 0 {
+		return errors.New("no transfers to relay")
+	}
+	if uint64(len(req.Transfers)) > r.maxTransfersPerBlock() {
+		return fmt.Errorf("%d transfers exceed max_transfers_per_block", len(req.Transfers))
+	}
+	state, err := r.getRelayState()
+	if err != nil {
+		return err
+	}
+	if req.From != state.NextNonce {
+		return fmt.Errorf("transfers of %s start at nonce %d, the next one is %d", r.cfg.PeerChainURL, req.From, state.NextNonce)
+	}
+	if err = r.verifyAttestations(req.TransferBatch, req.Attestations); err != nil {
+		return err
+	}
+	for _, transfer := range req.Transfers {
+		if transfer.Nonce != state.NextNonce {
+			return fmt.Errorf("transfer nonce %d of %s is missing", state.NextNonce, r.cfg.PeerChainURL)
+		}
+		if err = r.receive(transfer, ctx.Block); err != nil {
+			return err
+		}
+		state.NextNonce++
+		state.PeerHeight = transfer.Height
+	}
+	return bridge.SetJson(r, relayStateKey, state)
+}
+
+func (r *YuRelayer) maxTransfersPerBlock() uint64 {
+	if r.cfg.MaxTransfersPerBlock == 0 {
+		return DefaultMaxTransfersPerBlock
+	}
+	return r.cfg.MaxTransfersPerBlock
+}
+
+// receive mints or unlocks a transfer of the other chain to its recipient.
+// A token off the allowlist or whose UDT name is taken, or unlocking more than was locked, bounces the transfer
+// back to its sender, so that its nonce is never skipped.
+func (r *YuRelayer) receive(transfer *Transfer, block *types.Block) error {
+	if transfer.Returning {
+		locked, err := r.getLocked(transfer.Token)
+		if err != nil {
+			return err
+		}
+		if locked.Cmp(transfer.Amount) < 0 {
+			return r.bounce(transfer, block)
+		}
+		if err = bridge.SetJson(r, lockedKey(transfer.Token), locked.Sub(locked, transfer.Amount)); err != nil {
+			return err
+		}
+		if err = r.Account.AddBalance(transfer.Recipient, transfer.Token, transfer.Amount); err != nil {
+			return err
+		}
+		return bridge.SetJson(r, inboundKey(transfer.Nonce), transfer)
+	}
+
+	origin := r.origin(transfer.Token)
+	allowed, err := r.Registry.IsAllowed(origin)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return r.bounce(transfer, block)
+	}
+	mapping, err := r.tokenMapping(origin)
+	if errors.Is(err, ErrTokenTaken) {
+		return r.bounce(transfer, block)
+	}
+	if err != nil {
+		return err
+	}
+	token, err := r.bridgedUdt(mapping)
+	if errors.Is(err, ErrTokenTaken) {
+		return r.bounce(transfer, block)
+	}
+	if err != nil {
+		return err
+	}
+	token.Total.Add(token.Total, transfer.Amount)
+	token.Issued.Add(token.Issued, transfer.Amount)
+	if err = r.UDT.AddUdt(token); err != nil {
+		return err
+	}
+	if err = r.Account.AddBalance(transfer.Recipient, mapping.Token, transfer.Amount); err != nil {
+		return err
+	}
+	return bridge.SetJson(r, inboundKey(transfer.Nonce), transfer)
+}
+
+// bounce sends a transfer of the other chain back to its sender in the block.
+func (r *YuRelayer) bounce(transfer *Transfer, block *types.Block) error {
+	err := r.appendTransfer(&Transfer{
+		From:      transfer.Recipient,
+		Recipient: transfer.From,
+		Token:     transfer.Token,
+		Returning: !transfer.Returning,
+		Amount:    transfer.Amount,
+		Height:    block.Height,
+	})
+	if err != nil {
+		return err
+	}
+	transfer.Bounced = true
+	return bridge.SetJson(r, inboundKey(transfer.Nonce), transfer)
+}
+
+// tokenMapping returns the mapping of a token of the other chain, registering it on its first transfer.
+// It registers no mapping to a UDT existing already, which would make that UDT redeemable on the other chain.
+func (r *YuRelayer) tokenMapping(origin *udt.ChainToken) (*registry.TokenMapping, error) {
+	mapping, err := r.Registry.Lookup(origin)
+	if err != nil || mapping != nil {
+		return mapping, err
+	}
+	token := udt.TokenID(origin.TokenAddress)
+	mapping = &registry.TokenMapping{
+		Origin: origin,
+		Token:  BridgedTokenID(origin.ChainURL, token),
+		Name:   string(token),
+		Symbol: string(token),
+	}
+	if r.UDT.Exist([]byte(mapping.Token)) {
+		return nil, ErrTokenTaken
+	}
+	return mapping, r.Registry.Register(mapping)
+}
+
+// origin is the token of the other chain as the original token of its bridged UDT.
+func (r *YuRelayer) origin(token udt.TokenID) *udt.ChainToken {
+	return &udt.ChainToken{
+		ChainURL:     r.cfg.PeerChainURL,
+		TokenAddress: []byte(token),
+	}
+}
+
+// bridgedUdt loads the UDT of a mapped token, creating it on its first transfer.
+// An existing UDT of that name is only used if this relayer created it for the same token.
+func (r *YuRelayer) bridgedUdt(mapping *registry.TokenMapping) (*udt.UDT, error) {
+	if r.UDT.Exist([]byte(mapping.Token)) {
+		token, err := r.UDT.GetUdt(mapping.Token)
+		if err != nil {
+			return nil, err
+		}
+		if token.Creator != r.Name() || token.OriginalToken == nil ||
+			token.OriginalToken.ChainURL != mapping.Origin.ChainURL ||
+			!bytes.Equal(token.OriginalToken.TokenAddress, mapping.Origin.TokenAddress) {
+			return nil, ErrTokenTaken
+		}
+		return token, nil
+	}
+	return &udt.UDT{
+		Name:          mapping.Token,
+		Creator:       r.Name(),
+		Description:   mapping.Name + " bridged from " + mapping.Origin.ChainURL,
+		OriginalToken: mapping.Origin,
+		Total:         big.NewInt(0),
+		Locked:        big.NewInt(0),
+		Issued:        big.NewInt(0),
+	}, nil
+}
+
+func (r *YuRelayer) Health(ctx context.Context) *bridge.Health {
+	health := &bridge.Health{ChainURL: r.cfg.PeerChainURL}
+	state, err := r.getRelayState()
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Relayed = uint64(state.PeerHeight)
+	if health.Finalized, err = r.Finalized(ctx); err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	// with every finalized transfer relayed, the relay has caught up with the other chain
+	batch, err := r.fetchBatch(state.NextNonce)
+	if err != nil {
+		health.Error = err.Error()
+	} else if len(batch.Transfers) == 0 {
+		health.Relayed = health.Finalized
+	}
+	return health
+}
+
+// FinalizedHeight is the response of GetFinalized.
+type FinalizedHeight struct {
+	Height common.BlockNum `json:"height"`
+}
+
+// GetFinalized returns the FinalizedHeight of this chain, the other side only relays the transfers below it.
+func (r *YuRelayer) GetFinalized(ctx *yucontext.ReadContext) {
+	height, err := r.finalized()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(&FinalizedHeight{Height: height})
+}
+
+func (r *YuRelayer) GetRelayState(ctx *yucontext.ReadContext) {
+	state, err := r.getRelayState()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(state)
+}
+
+func (r *YuRelayer) GetHealth(ctx *yucontext.ReadContext) {
+	ctx.JsonOk(r.Health(context.Background()))
+}
+
+func (r *YuRelayer) finalized() (common.BlockNum, error) {
+	block, err := r.Chain.LastFinalized()
+	if err != nil {
+		return 0, err
+	}
+	return block.Height, nil
+}
+
+var relayStateKey = []byte("relay_state")
+
+func (r *YuRelayer) getRelayState() (*RelayState, error) {
+	state := new(RelayState)
+	_, err := bridge.GetJson(r, relayStateKey, state)
+	return state, err
+}
@@ -0,0 +1,421 @@
+package yu
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/bridge/registry"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/config"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+	"github.com/yu-org/yu/core/txpool"
+	"github.com/yu-org/yu/core/types"
+)
+
+// memChain only tells its finalized height.
+type memChain struct {
+	types.IBlockChain
+	finalized common.BlockNum
+}
+
+func (c *memChain) LastFinalized() (*types.Block, error) { return newBlock(c.finalized), nil }
+
+type leader bool
+
+func (l leader) AmILeader(common.BlockNum) bool { return bool(l) }
+
+// testChain is a chain running in the process, whose nodes share its state and attest with their own keys.
+type testChain struct {
+	url     string
+	state   *memstate.State
+	chain   *memChain
+	pool    *txpool.TxPool
+	account *account.AccountTripod
+	udt     *udt.UdtTripod
+	nodes   []*YuRelayer
+}
+
+func signerSecret(url string, node int) string {
+	return fmt.Sprintf("%s/node-%d", url, node)
+}
+
+func signerPubkey(t *testing.T, url string, node int) string {
+	_, pubkey, err := newSigner(&Config{SignerSecret: signerSecret(url, node)})
+	mustOk(t, err)
+	return pubkey
+}
+
+func newTestChain(t *testing.T, url, peerURL string, nodes, peerQuorum int) *testChain {
+	chainEnv := &env.ChainEnv{
+		State: memstate.New(),
+		Chain: &memChain{},
+		Pool:  txpool.NewTxPool(common.FullNode, &config.TxpoolConf{PoolSize: 100, TxnMaxSize: 1 << 20}),
+	}
+	c := &testChain{
+		url:     url,
+		state:   chainEnv.State.(*memstate.State),
+		chain:   chainEnv.Chain.(*memChain),
+		pool:    chainEnv.Pool.(*txpool.TxPool),
+		account: account.NewAccountTripod(),
+		udt:     udt.NewUdtTripod(),
+	}
+	c.account.SetChainEnv(chainEnv)
+	c.udt.SetChainEnv(chainEnv)
+	reg := registry.NewTokenRegistry(&registry.Config{})
+	reg.SetChainEnv(chainEnv)
+	reg.Account = c.account
+
+	var peerSigners []string
+	for i := 0; i < 3; i++ {
+		peerSigners = append(peerSigners, signerPubkey(t, peerURL, i))
+	}
+	for i := 0; i < nodes; i++ {
+		r, err := NewYuRelayer(&Config{
+			ChainURL:             url,
+			PeerChainURL:         peerURL,
+			PeerSigners:          peerSigners,
+			PeerQuorum:           peerQuorum,
+			SignerSecret:         signerSecret(url, i),
+			MaxTransfersPerBlock: 2,
+		})
+		mustOk(t, err)
+		r.SetChainEnv(chainEnv)
+		r.UDT, r.Account, r.Registry = c.udt, c.account, reg
+		c.nodes = append(c.nodes, r)
+	}
+	return c
+}
+
+// connect lets each chain read every node of the other one.
+func connect(a, b *testChain) {
+	for _, pair := range [][2]*testChain{{a, b}, {b, a}} {
+		var peers []Peer
+		for _, node := range pair[1].nodes {
+			peers = append(peers, NewLocalPeer(node))
+		}
+		for _, node := range pair[0].nodes {
+			node.SetPeers(peers...)
+		}
+	}
+}
+
+func (c *testChain) relayer() *YuRelayer { return c.nodes[0] }
+
+// fund credits an account at genesis.
+func (c *testChain) fund(t *testing.T, owner string, token udt.TokenID, amount int64) {
+	t.Helper()
+	mustOk(t, c.account.AddBalance(owner, token, big.NewInt(amount)))
+	c.state.NextTxn()
+}
+
+// transfer sends tokens to the other chain in the block, and publishes them as the block ends.
+func (c *testChain) transfer(t *testing.T, height common.BlockNum, from string, token udt.TokenID, amount int64, recipient string) error {
+	t.Helper()
+	err := c.state.Execute(func() error {
+		return c.relayer().Transfer(writeCtx(t, newBlock(height), &TransferRequest{
+			FromID: from, Token: token, Amount: big.NewInt(amount), Recipient: recipient,
+		}))
+	})
+	c.relayer().EndBlock(newBlock(height))
+	return err
+}
+
+// produce executes the txns the producer has put into the txpool in the block, then lets it propose for the next one.
+func (c *testChain) produce(t *testing.T, height common.BlockNum) []error {
+	t.Helper()
+	block := newBlock(height)
+	txns, err := c.pool.Pack(100)
+	mustOk(t, err)
+	var errs []error
+	for _, stxn := range txns {
+		ctx, err := context.NewWriteContext(stxn, block, 0)
+		mustOk(t, err)
+		errs = append(errs, c.state.Execute(func() error { return c.relayer().RelayTransfers(ctx) }))
+	}
+	mustOk(t, c.pool.Reset(txns))
+	c.relayer().EndBlock(block)
+	c.relayer().FinalizeBlock(block)
+	return errs
+}
+
+func (c *testChain) balance(t *testing.T, owner string, token udt.TokenID) int64 {
+	t.Helper()
+	balance, err := c.account.GetBalance(owner, token)
+	mustOk(t, err)
+	return balance.Int64()
+}
+
+func TestRelayBetweenChains(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 3, 2)
+	b := newTestChain(t, "yu:B", "yu:A", 3, 2)
+	connect(a, b)
+	bridged := BridgedTokenID("yu:A", "JingChou")
+
+	a.fund(t, "alice", "JingChou", 1000)
+	for i := 0; i < 3; i++ {
+		mustOk(t, a.transfer(t, 5, "alice", "JingChou", 100, "bob"))
+	}
+	if err := a.transfer(t, 5, "alice", "JingChou", 1000, "bob"); err == nil {
+		t.Fatal("transferred more than alice has")
+	}
+
+	// nothing is proposed before the block of the transfers is finalized
+	a.chain.finalized = 4
+	b.produce(t, 1)
+	if b.pool.Size() != 0 {
+		t.Fatal("proposed transfers of a block not finalized")
+	}
+
+	a.chain.finalized = 5
+	b.relayer().FinalizeBlock(newBlock(1))
+	for _, err := range b.produce(t, 2) {
+		mustOk(t, err)
+	}
+	// at most MaxTransfersPerBlock in a block
+	if balance := b.balance(t, "bob", bridged); balance != 200 {
+		t.Fatalf("bob has %d after the first block, want 200", balance)
+	}
+	for _, err := range b.produce(t, 3) {
+		mustOk(t, err)
+	}
+	if balance := b.balance(t, "bob", bridged); balance != 300 {
+		t.Fatalf("bob has %d, want 300", balance)
+	}
+	if errs := b.produce(t, 4); len(errs) != 0 {
+		t.Fatalf("relayed the transfers again: %v", errs)
+	}
+	health := b.relayer().Health(nil)
+	if !health.Healthy() || health.Finalized != 5 || health.Lag() != 0 {
+		t.Fatalf("unexpected health %+v", health)
+	}
+
+	// bob sends 120 back to carol, unlocked on A
+	mustOk(t, b.transfer(t, 7, "bob", bridged, 120, "carol"))
+	b.chain.finalized = 7
+	a.relayer().FinalizeBlock(newBlock(9))
+	for _, err := range a.produce(t, 10) {
+		mustOk(t, err)
+	}
+	if carol, alice := a.balance(t, "carol", "JingChou"), a.balance(t, "alice", "JingChou"); carol != 120 || alice != 700 {
+		t.Fatalf("carol has %d and alice %d on A, want 120 and 700", carol, alice)
+	}
+	token, err := b.udt.GetUdt(bridged)
+	mustOk(t, err)
+	if token.Total.Int64() != 180 {
+		t.Fatalf("%s total %s on B, want 180", bridged, token.Total)
+	}
+}
+
+func TestPublishedTransfersSurviveDiscard(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 1, 0)
+	a.fund(t, "alice", "JingChou", 100)
+	mustOk(t, a.transfer(t, 1, "alice", "JingChou", 100, "bob"))
+
+	// the first txn of the next block fails
+	err := a.state.Execute(func() error {
+		return a.relayer().Transfer(writeCtx(t, newBlock(2), &TransferRequest{
+			FromID: "alice", Token: "JingChou", Amount: big.NewInt(100), Recipient: "bob",
+		}))
+	})
+	if err == nil {
+		t.Fatal("transferred more than alice has")
+	}
+	outbox, err := a.relayer().getOutbox()
+	mustOk(t, err)
+	if outbox.Next != 1 || outbox.Published != 1 {
+		t.Fatalf("outbox %+v after the discard, want the transfer published", outbox)
+	}
+}
+
+func TestRelayNeedsQuorum(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 3, 2)
+	b := newTestChain(t, "yu:B", "yu:A", 1, 2)
+	connect(a, b)
+	a.fund(t, "alice", "JingChou", 100)
+	mustOk(t, a.transfer(t, 1, "alice", "JingChou", 100, "bob"))
+	a.chain.finalized = 1
+
+	// a single node of A can not attest alone
+	b.relayer().SetPeers(NewLocalPeer(a.nodes[0]))
+	err := b.relayer().RelayDeposits(newBlock(1))
+	if err == nil || !strings.Contains(err.Error(), "quorum") {
+		t.Fatalf("proposed transfers attested by one node: %v", err)
+	}
+
+	// a quorum attests, but a tampered or signed-again request is refused
+	b.relayer().SetPeers(NewLocalPeer(a.nodes[0]), NewLocalPeer(a.nodes[1]))
+	mustOk(t, b.relayer().RelayDeposits(newBlock(1)))
+	txns, err := b.pool.Pack(1)
+	mustOk(t, err)
+	req := new(RelayTransfersRequest)
+	mustOk(t, txns[0].BindJson(req))
+
+	tampered := *req.Transfers[0]
+	tampered.Amount = big.NewInt(1_000_000)
+	forged := &RelayTransfersRequest{
+		TransferBatch: &TransferBatch{From: req.From, Transfers: []*Transfer{&tampered}},
+		Attestations:  req.Attestations,
+	}
+	if err = b.state.Execute(func() error { return b.relayer().RelayTransfers(writeCtx(t, newBlock(2), forged)) }); err == nil {
+		t.Fatal("relayed a tampered transfer")
+	}
+	duplicated := &RelayTransfersRequest{
+		TransferBatch: req.TransferBatch,
+		Attestations:  []*Attestation{req.Attestations[0], req.Attestations[0]},
+	}
+	if err = b.state.Execute(func() error { return b.relayer().RelayTransfers(writeCtx(t, newBlock(2), duplicated)) }); err == nil {
+		t.Fatal("relayed transfers attested twice by the same node")
+	}
+	unknown, err := a.nodes[2].attest(0, 1)
+	mustOk(t, err)
+	unknown.Pubkey = signerPubkey(t, "yu:C", 0)
+	outsider := &RelayTransfersRequest{
+		TransferBatch: req.TransferBatch,
+		Attestations:  []*Attestation{req.Attestations[0], unknown},
+	}
+	if err = b.state.Execute(func() error { return b.relayer().RelayTransfers(writeCtx(t, newBlock(2), outsider)) }); err == nil {
+		t.Fatal("relayed transfers attested by a node of another chain")
+	}
+	if balance := b.balance(t, "bob", BridgedTokenID("yu:A", "JingChou")); balance != 0 {
+		t.Fatalf("bob has %d after refused relays", balance)
+	}
+
+	for _, err = range b.produce(t, 2) {
+		mustOk(t, err)
+	}
+	if balance := b.balance(t, "bob", BridgedTokenID("yu:A", "JingChou")); balance != 100 {
+		t.Fatalf("bob has %d, want 100", balance)
+	}
+	if err = b.state.Execute(func() error { return b.relayer().RelayTransfers(writeCtx(t, newBlock(3), req)) }); err == nil {
+		t.Fatal("relayed the same transfers twice")
+	}
+}
+
+// TestAttestationBindsChains relays transfers of A to C with the attestations A made for B,
+// C trusting the signers of A as B does.
+func TestAttestationBindsChains(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 3, 2)
+	b := newTestChain(t, "yu:B", "yu:A", 1, 2)
+	c := newTestChain(t, "yu:C", "yu:A", 1, 2)
+	connect(a, b)
+	a.fund(t, "alice", "JingChou", 100)
+	mustOk(t, a.transfer(t, 1, "alice", "JingChou", 100, "bob"))
+	a.chain.finalized = 1
+
+	mustOk(t, b.relayer().RelayDeposits(newBlock(1)))
+	txns, err := b.pool.Pack(1)
+	mustOk(t, err)
+	req := new(RelayTransfersRequest)
+	mustOk(t, txns[0].BindJson(req))
+	err = c.state.Execute(func() error { return c.relayer().RelayTransfers(writeCtx(t, newBlock(2), req)) })
+	if err == nil || !strings.Contains(err.Error(), "quorum") {
+		t.Fatalf("relayed to C transfers attested for B: %v", err)
+	}
+	if balance := c.balance(t, "bob", BridgedTokenID("yu:A", "JingChou")); balance != 0 {
+		t.Fatalf("bob has %d on C", balance)
+	}
+}
+
+func TestOnlyLeaderProposes(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 3, 0)
+	b := newTestChain(t, "yu:B", "yu:A", 1, 0)
+	connect(a, b)
+	a.fund(t, "alice", "JingChou", 100)
+	mustOk(t, a.transfer(t, 1, "alice", "JingChou", 100, "bob"))
+	a.chain.finalized = 1
+
+	b.relayer().Poa = leader(false)
+	mustOk(t, b.relayer().RelayDeposits(newBlock(1)))
+	if b.pool.Size() != 0 {
+		t.Fatal("a node not producing the next block proposed transfers")
+	}
+	b.relayer().Poa = leader(true)
+	mustOk(t, b.relayer().RelayDeposits(newBlock(1)))
+	if b.pool.Size() != 1 {
+		t.Fatal("the producer of the next block proposed no transfers")
+	}
+}
+
+func TestTripodNamePerPeer(t *testing.T) {
+	b := newTestChain(t, "yu:A", "yu:B", 1, 0)
+	c := newTestChain(t, "yu:A", "yu:C", 1, 0)
+	if b.relayer().Name() != TripodName("yu:B") || c.relayer().Name() == b.relayer().Name() {
+		t.Fatalf("the relayers of A to B and to C are named %s and %s", b.relayer().Name(), c.relayer().Name())
+	}
+	r, err := NewYuRelayer(&Config{ChainURL: "yu:A", PeerChainURL: "yu:B", PeerAddresses: []string{"http://localhost:7999"},
+		PeerSigners: []string{signerPubkey(t, "yu:B", 0)}})
+	mustOk(t, err)
+	// the peer reads the relayer of B bridging to A
+	if peer := r.peers[0].(*httpPeer); peer.tripodName != TripodName("yu:A") {
+		t.Fatalf("the peer reads the tripod %s of B", peer.tripodName)
+	}
+}
+
+func TestBridgedTokenIDIsUnambiguous(t *testing.T) {
+	if BridgedTokenID("yu:a-b", "c") == BridgedTokenID("yu:a", "b-c") {
+		t.Fatal("two tokens of different chains share a bridged token id")
+	}
+}
+
+func TestTakenTokenNameBounces(t *testing.T) {
+	a := newTestChain(t, "yu:A", "yu:B", 3, 0)
+	b := newTestChain(t, "yu:B", "yu:A", 3, 0)
+	connect(a, b)
+	bridged := BridgedTokenID("yu:A", "JingChou")
+	// someone on B created a UDT under the name first
+	squatted := &udt.UDT{Name: bridged, Creator: "mallory", Total: big.NewInt(0), Locked: big.NewInt(0), Issued: big.NewInt(0)}
+	mustOk(t, b.udt.AddUdt(squatted))
+
+	a.fund(t, "alice", "JingChou", 100)
+	mustOk(t, a.transfer(t, 1, "alice", "JingChou", 100, "bob"))
+	a.chain.finalized = 1
+	b.relayer().FinalizeBlock(newBlock(1))
+	for _, err := range b.produce(t, 2) {
+		mustOk(t, err)
+	}
+	if balance := b.balance(t, "bob", bridged); balance != 0 {
+		t.Fatalf("bob got %d of the squatted udt", balance)
+	}
+	mapping, err := b.relayer().Registry.Lookup(b.relayer().origin("JingChou"))
+	mustOk(t, err)
+	if mapping != nil {
+		t.Fatal("mapped the token of A to the squatted udt")
+	}
+
+	// the transfer comes back to alice
+	b.chain.finalized = 2
+	a.relayer().FinalizeBlock(newBlock(2))
+	for _, err = range a.produce(t, 3) {
+		mustOk(t, err)
+	}
+	if balance := a.balance(t, "alice", "JingChou"); balance != 100 {
+		t.Fatalf("alice has %d after the bounce, want 100", balance)
+	}
+}
+
+func newBlock(height common.BlockNum) *types.Block {
+	return &types.Block{Header: &types.Header{Height: height, Timestamp: uint64(height)}}
+}
+
+func writeCtx(t *testing.T, block *types.Block, req any) *context.WriteContext {
+	t.Helper()
+	byt, err := json.Marshal(req)
+	mustOk(t, err)
+	params, err := context.NewParamsResponseFromStr(string(byt))
+	mustOk(t, err)
+	return &context.WriteContext{ParamsResponse: params, Block: block}
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
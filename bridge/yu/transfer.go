@@ -0,0 +1,243 @@
+package yu
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/yu-org/JingChou/bridge"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	yucontext "github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/types"
+)
+
+// Transfer moves Amount of Token from the account From on the chain of its outbox to the account Recipient
+// on the other chain.
+type Transfer struct {
+	Nonce     uint64 `json:"nonce"`
+	From      string `json:"from"`
+	Recipient string `json:"recipient"`
+	// Token is the UDT on the chain it is native to, the source chain unless Returning.
+	Token udt.TokenID `json:"token"`
+	// Returning tells that Token was bridged from the other chain and goes back to it, unlocked there instead of minted.
+	Returning bool            `json:"returning,omitempty"`
+	Amount    *big.Int        `json:"amount"`
+	Height    common.BlockNum `json:"height"`
+	// Bounced tells that the other chain could not credit the transfer and sent it back to From.
+	Bounced bool `json:"bounced,omitempty"`
+}
+
+// Outbox is the state of the transfers to the other chain.
+type Outbox struct {
+	// Next is the nonce of the next transfer.
+	Next uint64 `json:"next"`
+	// Published is the count of the transfers of the ended blocks, the other chain reads only these.
+	Published uint64 `json:"published"`
+}
+
+type TransferRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	Token     udt.TokenID `json:"token"`
+	Amount    *big.Int    `json:"amount"`
+	// Recipient is the account on the other chain receiving the tokens.
+	Recipient string `json:"recipient"`
+}
+
+// Transfer sends tokens to the other chain. A token bridged from the other chain is burned and goes back to it,
+// any other token is locked until it comes back.
+func (r *YuRelayer) Transfer(ctx *yucontext.WriteContext) error {
+	req := new(TransferRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := r.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if req.Amount == nil || req.Amount.Sign() <= 0 {
+		return errors.New("transfer amount must be positive")
+	}
+	if req.Recipient == "" {
+		return errors.New("empty recipient")
+	}
+	if err := r.Account.SubBalance(req.FromID, req.Token, req.Amount); err != nil {
+		return err
+	}
+	transfer := &Transfer{
+		From:      req.FromID,
+		Recipient: req.Recipient,
+		Token:     req.Token,
+		Amount:    req.Amount,
+		Height:    ctx.Block.Height,
+	}
+
+	mapping, err := r.Registry.LookupToken(req.Token)
+	if err != nil {
+		return err
+	}
+	if mapping != nil && mapping.Origin.ChainURL == r.cfg.PeerChainURL {
+		token, err := r.UDT.GetUdt(req.Token)
+		if err != nil {
+			return err
+		}
+		token.Total.Sub(token.Total, req.Amount)
+		token.Issued.Sub(token.Issued, req.Amount)
+		if err = r.UDT.AddUdt(token); err != nil {
+			return err
+		}
+		transfer.Token = udt.TokenID(mapping.Origin.TokenAddress)
+		transfer.Returning = true
+	} else {
+		locked, err := r.getLocked(req.Token)
+		if err != nil {
+			return err
+		}
+		if err = bridge.SetJson(r, lockedKey(req.Token), locked.Add(locked, req.Amount)); err != nil {
+			return err
+		}
+	}
+	return r.appendTransfer(transfer)
+}
+
+// appendTransfer gives the transfer the next nonce of the outbox.
+func (r *YuRelayer) appendTransfer(transfer *Transfer) error {
+	outbox, err := r.getOutbox()
+	if err != nil {
+		return err
+	}
+	transfer.Nonce = outbox.Next
+	if err = bridge.SetJson(r, transferKey(transfer.Nonce), transfer); err != nil {
+		return err
+	}
+	outbox.Next++
+	return bridge.SetJson(r, outboxKey, outbox)
+}
+
+// SubmitWithdrawals publishes the transfers made in the block, the other chain pulls them once the block is finalized.
+func (r *YuRelayer) SubmitWithdrawals(*types.Block) error {
+	outbox, err := r.getOutbox()
+	if err != nil || outbox.Published == outbox.Next {
+		return err
+	}
+	outbox.Published = outbox.Next
+	return bridge.SetJson(r, outboxKey, outbox)
+}
+
+// transfers returns the published transfers from nonce from on, at most limit of them.
+func (r *YuRelayer) transfers(from, limit uint64) ([]*Transfer, error) {
+	outbox, err := r.getOutbox()
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*Transfer, 0)
+	for nonce := from; nonce < outbox.Published && uint64(len(transfers)) < limit; nonce++ {
+		transfer, err := r.getTransfer(transferKey(nonce))
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// GetTransfer returns the Transfer of nonce in the outbox.
+func (r *YuRelayer) GetTransfer(ctx *yucontext.ReadContext) {
+	nonce, err := strconv.ParseUint(ctx.GetString("nonce"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	transfer, err := r.getTransfer(transferKey(nonce))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(transfer)
+}
+
+type GetTransfersRequest struct {
+	From  uint64 `json:"from"`
+	Limit uint64 `json:"limit"`
+}
+
+// GetTransfers returns the published transfers in the outbox from nonce from on, at most limit of them.
+// The other chain relays them through it, so the params are bound as json rather than read by GetString.
+func (r *YuRelayer) GetTransfers(ctx *yucontext.ReadContext) {
+	req := new(GetTransfersRequest)
+	if err := ctx.BindJson(req); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	transfers, err := r.transfers(req.From, req.Limit)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(transfers)
+}
+
+// GetInboundTransfer returns the relayed Transfer of nonce from the other chain.
+func (r *YuRelayer) GetInboundTransfer(ctx *yucontext.ReadContext) {
+	nonce, err := strconv.ParseUint(ctx.GetString("nonce"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	transfer, err := r.getTransfer(inboundKey(nonce))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(transfer)
+}
+
+// GetLocked returns the amount of token locked for the other chain.
+func (r *YuRelayer) GetLocked(ctx *yucontext.ReadContext) {
+	locked, err := r.getLocked(udt.TokenID(ctx.GetString("token")))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(locked)
+}
+
+var outboxKey = []byte("outbox")
+
+func transferKey(nonce uint64) []byte {
+	return []byte("transfer/" + strconv.FormatUint(nonce, 10))
+}
+
+func inboundKey(nonce uint64) []byte {
+	return []byte("inbound/" + strconv.FormatUint(nonce, 10))
+}
+
+func lockedKey(token udt.TokenID) []byte {
+	return []byte("locked/" + string(token))
+}
+
+func (r *YuRelayer) getOutbox() (*Outbox, error) {
+	outbox := new(Outbox)
+	_, err := bridge.GetJson(r, outboxKey, outbox)
+	return outbox, err
+}
+
+func (r *YuRelayer) getTransfer(key []byte) (*Transfer, error) {
+	byt, err := r.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, errors.New("transfer not found")
+	}
+	transfer := new(Transfer)
+	err = json.Unmarshal(byt, transfer)
+	return transfer, err
+}
+
+func (r *YuRelayer) getLocked(token udt.TokenID) (*big.Int, error) {
+	locked := big.NewInt(0)
+	_, err := bridge.GetJson(r, lockedKey(token), locked)
+	return locked, err
+}
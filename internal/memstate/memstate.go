@@ -0,0 +1,127 @@
+// Package memstate is a yu chain state in memory for tests. Like SpmtKV it keeps the writes of each txn
+// in a stash of their own: NextTxn seals the last stash, Discard drops it, and Commit applies every stash.
+package memstate
+
+import (
+	"sort"
+
+	"github.com/yu-org/yu/core/state"
+	"github.com/yu-org/yu/core/types"
+)
+
+var _ state.IState = (*State)(nil)
+
+// State is a state.IState in memory. A stashed key mapped to nil is deleted.
+type State struct {
+	committed map[string][]byte
+	stashes   []map[string][]byte
+}
+
+func New() *State {
+	return &State{committed: make(map[string][]byte)}
+}
+
+func key(t state.NameString, k []byte) string {
+	return t.Name() + "/" + string(k)
+}
+
+func (s *State) mute(k string, v []byte) {
+	if len(s.stashes) == 0 {
+		s.NextTxn()
+	}
+	s.stashes[len(s.stashes)-1][k] = v
+}
+
+func (s *State) get(k string) []byte {
+	for i := len(s.stashes) - 1; i >= 0; i-- {
+		if v, ok := s.stashes[i][k]; ok {
+			return v
+		}
+	}
+	return s.committed[k]
+}
+
+func (s *State) Set(t state.NameString, k, v []byte) { s.mute(key(t, k), v) }
+func (s *State) Delete(t state.NameString, k []byte) { s.mute(key(t, k), nil) }
+
+func (s *State) Get(t state.NameString, k []byte) ([]byte, error) {
+	return s.get(key(t, k)), nil
+}
+
+func (s *State) Exist(t state.NameString, k []byte) bool {
+	return s.get(key(t, k)) != nil
+}
+
+// GetFinalized and GetByBlockHash read the committed state, the same as SpmtKV.
+func (s *State) GetFinalized(t state.NameString, k []byte) ([]byte, error) {
+	return s.committed[key(t, k)], nil
+}
+
+func (s *State) GetByBlockHash(t state.NameString, k []byte, _ *types.Block) ([]byte, error) {
+	return s.committed[key(t, k)], nil
+}
+
+func (s *State) Commit() ([]byte, error) {
+	for _, stash := range s.stashes {
+		for k, v := range stash {
+			if v == nil {
+				delete(s.committed, k)
+			} else {
+				s.committed[k] = v
+			}
+		}
+	}
+	s.stashes = nil
+	return nil, nil
+}
+
+func (s *State) NextTxn() {
+	s.stashes = append(s.stashes, make(map[string][]byte))
+}
+
+func (s *State) Discard() {
+	if len(s.stashes) > 0 {
+		s.stashes = s.stashes[:len(s.stashes)-1]
+	}
+}
+
+func (s *State) DiscardAll() {
+	s.stashes = nil
+}
+
+func (s *State) StartBlock(*types.Block)    {}
+func (s *State) FinalizeBlock(*types.Block) {}
+
+// Execute runs a writing the way kernel.OrderedExecute does: its writes are discarded if it fails,
+// and sealed in their stash otherwise.
+func (s *State) Execute(writing func() error) error {
+	if err := writing(); err != nil {
+		s.Discard()
+		return err
+	}
+	s.NextTxn()
+	return nil
+}
+
+// Keys returns the keys holding a value, committed or stashed, as "tripod/key".
+func (s *State) Keys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for i := len(s.stashes) - 1; i >= 0; i-- {
+		for k, v := range s.stashes[i] {
+			if !seen[k] {
+				seen[k] = true
+				if v != nil {
+					keys = append(keys, k)
+				}
+			}
+		}
+	}
+	for k := range s.committed {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,199 @@
+package orderbook
+
+import (
+	"math/big"
+	"sort"
+)
+
+// auction runs the batch auction of pair over its resting orders and the orders added in this block.
+// All crossing orders fill at one clearing price, so the order of transactions inside a block
+// gives no advantage. Post-only orders never take liquidity in an auction, so they join it as others.
+func (ob *Orderbook) auction(pair OrderPair, incoming Orders) error {
+	buys, err := ob.getBook(buyBook, pair)
+	if err != nil {
+		return err
+	}
+	sells, err := ob.getBook(sellBook, pair)
+	if err != nil {
+		return err
+	}
+	for _, order := range incoming {
+		if order.Type == Buy {
+			buys = buys.insert(order)
+		} else {
+			sells = sells.insert(order)
+		}
+	}
+
+	lastPrice, err := ob.getLastPrice(pair)
+	if err != nil {
+		return err
+	}
+	var (
+		price, volume       *big.Int
+		buyFills, sellFills []*big.Int
+	)
+	// fill-or-kill orders that cannot fill completely are removed, and the price is found again without them.
+	for {
+		price, volume = clearingPrice(buys, sells, lastPrice)
+		buyFills, sellFills = allocate(buys, volume), allocate(sells, volume)
+		var killed []*Order
+		buys, killed = killUnfilled(buys, buyFills, killed)
+		sells, killed = killUnfilled(sells, sellFills, killed)
+		if len(killed) == 0 {
+			break
+		}
+		for _, order := range killed {
+			if err := ob.release(order, OrderKilled); err != nil {
+				return err
+			}
+		}
+	}
+
+	// pair the allocations of both sides in priority order.
+	i, j := 0, 0
+	for i < len(buys) && j < len(sells) {
+		if buyFills[i].Sign() == 0 {
+			i++
+			continue
+		}
+		if sellFills[j].Sign() == 0 {
+			j++
+			continue
+		}
+		amount := new(big.Int).Set(buyFills[i])
+		if sellFills[j].Cmp(amount) < 0 {
+			amount.Set(sellFills[j])
+		}
+		if err := ob.settle(sells[j], buys[i], amount, price); err != nil {
+			return err
+		}
+		buyFills[i].Sub(buyFills[i], amount)
+		sellFills[j].Sub(sellFills[j], amount)
+	}
+
+	if buys, err = ob.restAfterAuction(buys); err != nil {
+		return err
+	}
+	if err = ob.setBook(buyBook, pair, buys); err != nil {
+		return err
+	}
+	if sells, err = ob.restAfterAuction(sells); err != nil {
+		return err
+	}
+	return ob.setBook(sellBook, pair, sells)
+}
+
+// restAfterAuction keeps the orders of one side that can rest in the book and releases the others.
+func (ob *Orderbook) restAfterAuction(orders Orders) (Orders, error) {
+	book := make(Orders, 0, len(orders))
+	for _, order := range orders {
+		if order.remaining.Sign() > 0 && order.rests() {
+			book = append(book, order)
+			continue
+		}
+		reason := OrderFilled
+		if order.remaining.Sign() > 0 {
+			reason = OrderKilled
+		}
+		if err := ob.release(order, reason); err != nil {
+			return book, err
+		}
+	}
+	return book, nil
+}
+
+// clearingPrice returns the price that maximizes the matched volume of buys and sells, and that volume.
+// Ties are broken in this order:
+//  1. the smallest surplus, i.e. the unmatched amount on the larger side at that price;
+//  2. the price nearest to lastPrice, the last traded price of the pair, if there is one;
+//  3. the lowest price.
+//
+// Only limit prices are candidates, so the volume is zero if no limit price exists.
+func clearingPrice(buys, sells Orders, lastPrice *big.Int) (price, volume *big.Int) {
+	candidates := make([]*big.Int, 0, len(buys)+len(sells))
+	for _, order := range append(append(Orders(nil), buys...), sells...) {
+		if order.limitPrice().Sign() > 0 {
+			candidates = append(candidates, order.limitPrice())
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Cmp(candidates[j]) < 0
+	})
+
+	volume = big.NewInt(0)
+	var surplus *big.Int
+	for i, candidate := range candidates {
+		if i > 0 && candidate.Cmp(candidates[i-1]) == 0 {
+			continue
+		}
+		demand, supply := big.NewInt(0), big.NewInt(0)
+		for _, buy := range buys {
+			if buy.limitPrice().Cmp(candidate) >= 0 {
+				demand.Add(demand, buy.remaining)
+			}
+		}
+		for _, sell := range sells {
+			if sell.limitPrice().Cmp(candidate) <= 0 {
+				supply.Add(supply, sell.remaining)
+			}
+		}
+		matched, diff := supply, new(big.Int).Sub(demand, supply)
+		if demand.Cmp(supply) < 0 {
+			matched = demand
+		}
+		diff.Abs(diff)
+		if price == nil || betterClearing(matched, diff, candidate, volume, surplus, price, lastPrice) {
+			price, volume, surplus = candidate, matched, diff
+		}
+	}
+	if price == nil {
+		return Zero, big.NewInt(0)
+	}
+	return price, volume
+}
+
+// betterClearing reports whether the candidate price beats the best price found so far.
+// Candidates come in ascending order, so an equal candidate never replaces a lower price.
+func betterClearing(volume, surplus, price, bestVolume, bestSurplus, bestPrice, lastPrice *big.Int) bool {
+	if cmp := volume.Cmp(bestVolume); cmp != 0 {
+		return cmp > 0
+	}
+	if cmp := surplus.Cmp(bestSurplus); cmp != 0 {
+		return cmp < 0
+	}
+	if lastPrice == nil {
+		return false
+	}
+	distance := new(big.Int).Sub(price, lastPrice)
+	bestDistance := new(big.Int).Sub(bestPrice, lastPrice)
+	return distance.Abs(distance).Cmp(bestDistance.Abs(bestDistance)) < 0
+}
+
+// allocate splits volume among the orders of one side in priority order.
+func allocate(orders Orders, volume *big.Int) []*big.Int {
+	left := new(big.Int).Set(volume)
+	fills := make([]*big.Int, len(orders))
+	for i, order := range orders {
+		fill := new(big.Int).Set(order.remaining)
+		if left.Cmp(fill) < 0 {
+			fill.Set(left)
+		}
+		left.Sub(left, fill)
+		fills[i] = fill
+	}
+	return fills
+}
+
+// killUnfilled removes the fill-or-kill orders which would not fill completely and appends them to killed.
+func killUnfilled(orders Orders, fills []*big.Int, killed []*Order) (Orders, []*Order) {
+	kept := make(Orders, 0, len(orders))
+	for i, order := range orders {
+		if order.TimeInForce == FillOrKill && fills[i].Cmp(order.remaining) < 0 {
+			killed = append(killed, order)
+			continue
+		}
+		kept = append(kept, order)
+	}
+	return kept, killed
+}
@@ -0,0 +1,239 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// open returns a limit order as it is in the books, with nothing filled yet.
+func open(typ OrderType, amount, price int64, seq uint64) *Order {
+	order := limit(typ, amount, price, "alice")
+	order.id = fmt.Sprintf("order-%d", seq)
+	order.seq = seq
+	order.remaining = big.NewInt(amount)
+	order.escrow = order.EscrowAmount()
+	return order
+}
+
+func withTimeInForce(order *Order, tif TimeInForce) *Order {
+	order.TimeInForce = tif
+	return order
+}
+
+func TestClearingPrice(t *testing.T) {
+	marketSell := open(Sell, 5, 0, 9)
+	marketSell.Kind, marketSell.Price = Market, nil
+	tests := []struct {
+		name       string
+		buys       Orders
+		sells      Orders
+		lastPrice  int64
+		wantPrice  int64
+		wantVolume int64
+	}{
+		{
+			name:      "no orders",
+			wantPrice: 0,
+		},
+		{
+			name:      "no cross",
+			buys:      Orders{open(Buy, 5, 40, 1)},
+			sells:     Orders{open(Sell, 5, 50, 2)},
+			wantPrice: 40,
+		},
+		{
+			name:       "max volume",
+			buys:       Orders{open(Buy, 10, 50, 1), open(Buy, 5, 45, 2)},
+			sells:      Orders{open(Sell, 8, 44, 3), open(Sell, 10, 48, 4)},
+			wantPrice:  48,
+			wantVolume: 10,
+		},
+		{
+			name:       "smallest surplus beats the last price",
+			buys:       Orders{open(Buy, 10, 50, 1), open(Buy, 4, 45, 2)},
+			sells:      Orders{open(Sell, 10, 40, 3)},
+			lastPrice:  40,
+			wantPrice:  50,
+			wantVolume: 10,
+		},
+		{
+			name:       "lowest price without a last price",
+			buys:       Orders{open(Buy, 10, 50, 1)},
+			sells:      Orders{open(Sell, 10, 40, 2)},
+			wantPrice:  40,
+			wantVolume: 10,
+		},
+		{
+			name:       "nearest to the last price",
+			buys:       Orders{open(Buy, 10, 50, 1)},
+			sells:      Orders{open(Sell, 10, 40, 2)},
+			lastPrice:  48,
+			wantPrice:  50,
+			wantVolume: 10,
+		},
+		{
+			name:       "lowest price when as near to the last price",
+			buys:       Orders{open(Buy, 10, 50, 1)},
+			sells:      Orders{open(Sell, 10, 40, 2)},
+			lastPrice:  45,
+			wantPrice:  40,
+			wantVolume: 10,
+		},
+		{
+			name:       "market sell is no candidate",
+			buys:       Orders{open(Buy, 5, 50, 1)},
+			sells:      Orders{marketSell},
+			wantPrice:  50,
+			wantVolume: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lastPrice *big.Int
+			if tt.lastPrice != 0 {
+				lastPrice = big.NewInt(tt.lastPrice)
+			}
+			price, volume := clearingPrice(tt.buys, tt.sells, lastPrice)
+			if price.Int64() != tt.wantPrice || volume.Int64() != tt.wantVolume {
+				t.Fatalf("cleared %s at %s, want %d at %d", volume, price, tt.wantVolume, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	orders := Orders{open(Sell, 5, 40, 1), open(Sell, 3, 41, 2), open(Sell, 4, 42, 3)}
+	tests := []struct {
+		volume int64
+		want   []int64
+	}{
+		{volume: 0, want: []int64{0, 0, 0}},
+		{volume: 6, want: []int64{5, 1, 0}},
+		{volume: 12, want: []int64{5, 3, 4}},
+		{volume: 20, want: []int64{5, 3, 4}},
+	}
+	for _, tt := range tests {
+		fills := allocate(orders, big.NewInt(tt.volume))
+		for i, fill := range fills {
+			if fill.Int64() != tt.want[i] {
+				t.Fatalf("allocate(%d) = %v, want %v", tt.volume, fills, tt.want)
+			}
+		}
+	}
+}
+
+func TestKillUnfilled(t *testing.T) {
+	orders := Orders{
+		open(Buy, 5, 50, 1),
+		withTimeInForce(open(Buy, 3, 49, 2), FillOrKill),
+		withTimeInForce(open(Buy, 4, 48, 3), FillOrKill),
+		withTimeInForce(open(Buy, 2, 47, 4), ImmediateOrCancel),
+	}
+	tests := []struct {
+		name       string
+		fills      []int64
+		wantKept   []uint64
+		wantKilled []uint64
+	}{
+		{name: "all filled", fills: []int64{5, 3, 4, 2}, wantKept: []uint64{1, 2, 3, 4}},
+		{name: "partly filled", fills: []int64{5, 1, 4, 0}, wantKept: []uint64{1, 3, 4}, wantKilled: []uint64{2}},
+		{name: "nothing filled", fills: []int64{0, 0, 0, 0}, wantKept: []uint64{1, 4}, wantKilled: []uint64{2, 3}},
+	}
+	seqs := func(orders Orders) []uint64 {
+		var seqs []uint64
+		for _, order := range orders {
+			seqs = append(seqs, order.seq)
+		}
+		return seqs
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fills := make([]*big.Int, len(tt.fills))
+			for i, fill := range tt.fills {
+				fills[i] = big.NewInt(fill)
+			}
+			kept, killed := killUnfilled(orders, fills, nil)
+			if fmt.Sprint(seqs(kept)) != fmt.Sprint(tt.wantKept) || fmt.Sprint(seqs(killed)) != fmt.Sprint(tt.wantKilled) {
+				t.Fatalf("kept %v and killed %v, want %v and %v", seqs(kept), seqs(killed), tt.wantKept, tt.wantKilled)
+			}
+		})
+	}
+}
+
+func TestRestAfterAuction(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.SetIndexer(new(recordingIndexer))
+	filled := open(Sell, 4, 40, 1)
+	filled.remaining, filled.escrow = big.NewInt(0), big.NewInt(0)
+	partial := open(Sell, 4, 41, 2)
+	partial.remaining, partial.escrow = big.NewInt(1), big.NewInt(1)
+	ioc := withTimeInForce(open(Sell, 3, 42, 3), ImmediateOrCancel)
+	market := open(Sell, 2, 43, 4)
+	market.Kind = Market
+	orders := Orders{filled, partial, ioc, market}
+	for _, order := range orders {
+		mustOk(t, tb.setOrder(order))
+	}
+
+	var book Orders
+	mustOk(t, tb.state.Execute(func() (err error) {
+		book, err = tb.restAfterAuction(orders)
+		return err
+	}))
+	if len(book) != 1 || book[0] != partial {
+		t.Fatalf("book after the auction: %v, want the partly filled order only", book)
+	}
+	// the unfilled ioc and market orders are refunded
+	if got := tb.balance(t, "alice", "BTC"); got != 5 {
+		t.Fatalf("alice has %d BTC back, want 5", got)
+	}
+	for _, order := range []*Order{filled, ioc, market} {
+		if _, err := tb.getOrder(order.id); !errors.Is(err, ErrOrderNotFound) {
+			t.Fatalf("order %s still open: %v", order.id, err)
+		}
+	}
+	wantEvents := []OrderEventType{OrderFilled, OrderKilled, OrderKilled}
+	if len(tb.events.Events) != len(wantEvents) {
+		t.Fatalf("%d events, want %d", len(tb.events.Events), len(wantEvents))
+	}
+	for i, event := range tb.events.Events {
+		if event.Type != wantEvents[i] {
+			t.Fatalf("event %d is %s, want %s", i, event.Type, wantEvents[i])
+		}
+	}
+}
+
+func TestAuctionClearsAtOnePrice(t *testing.T) {
+	cfg := &Config{Governor: "gov"}
+	tb := newTestBook(t, cfg)
+	tb.fund(t, "gov", "USD", 0)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 10)
+	tb.fund(t, "carol", "BTC", 10)
+	mustOk(t, tb.state.Execute(func() error {
+		return tb.SetMatchMode(writeCtx(t, 1, &SetMatchModeRequest{Pair: btcUsd, Mode: BatchAuction}))
+	}))
+	mustOk(t, tb.add(t, 1, limit(Sell, 6, 40, "bob")))
+	mustOk(t, tb.add(t, 1, limit(Sell, 6, 45, "carol")))
+	mustOk(t, tb.add(t, 1, limit(Buy, 10, 50, "alice")))
+	tb.endBlock(1)
+
+	// 10 BTC clear at 45, where the 12 BTC offered leave the smallest surplus
+	if got := tb.balance(t, "alice", "BTC"); got != 10 {
+		t.Fatalf("alice has %d BTC, want 10", got)
+	}
+	if got := tb.balance(t, "alice", "USD"); got != 550 {
+		t.Fatalf("alice has %d USD, want 550 after paying 45 a BTC", got)
+	}
+	if got := tb.balance(t, "bob", "USD"); got != 270 {
+		t.Fatalf("bob has %d USD, want 270", got)
+	}
+	if got := tb.balance(t, "carol", "USD"); got != 180 {
+		t.Fatalf("carol has %d USD, want 180", got)
+	}
+	if sells := tb.book(t, sellBook); len(sells) != 1 || sells[0].Account != "carol" || sells[0].remaining.Int64() != 2 {
+		t.Fatalf("sell book after the auction: %v", sells)
+	}
+}
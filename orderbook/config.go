@@ -0,0 +1,12 @@
+package orderbook
+
+import "github.com/yu-org/yu/common"
+
+type Config struct {
+	// Governor is the account allowed to update the fee schedules.
+	Governor string `toml:"governor"`
+	// Treasury is the account collecting the trading fees and paying the maker rebates.
+	Treasury string `toml:"treasury"`
+	// FeePeriod is the number of blocks of one fee accounting period.
+	FeePeriod common.BlockNum `toml:"fee_period"`
+}
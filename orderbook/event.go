@@ -0,0 +1,102 @@
+package orderbook
+
+import (
+	"math/big"
+
+	"github.com/yu-org/yu/common"
+)
+
+type OrderEventType string
+
+const (
+	OrderAdded     OrderEventType = "added"
+	OrderTriggered OrderEventType = "triggered"
+	OrderFilled    OrderEventType = "filled"
+	OrderCanceled  OrderEventType = "canceled"
+	OrderExpired   OrderEventType = "expired"
+	// OrderKilled is an order closed by its execution flags, e.g. the rest of an IOC order.
+	OrderKilled OrderEventType = "killed"
+)
+
+// OrderEvent is a change in the lifecycle of an order.
+type OrderEvent struct {
+	OrderID   string         `json:"order_id"`
+	Type      OrderEventType `json:"type"`
+	Pair      OrderPair      `json:"pair"`
+	Side      OrderType      `json:"side"`
+	Account   string         `json:"account"`
+	Remaining *big.Int       `json:"remaining"`
+}
+
+// Fill is one trade between two orders.
+type Fill struct {
+	Pair         OrderPair `json:"pair"`
+	Price        *big.Int  `json:"price"`
+	Amount       *big.Int  `json:"amount"`
+	MakerOrderID string    `json:"maker_order_id"`
+	TakerOrderID string    `json:"taker_order_id"`
+	Maker        string    `json:"maker"`
+	Taker        string    `json:"taker"`
+	TakerSide    OrderType `json:"taker_side"`
+}
+
+// BlockEvents are the fills and order events of one block, in the order they happened.
+type BlockEvents struct {
+	Height    common.BlockNum `json:"height"`
+	Timestamp uint64          `json:"timestamp"`
+	Fills     []*Fill         `json:"fills"`
+	Events    []*OrderEvent   `json:"events"`
+}
+
+// Indexer receives the events of every block from the orderbook, e.g. to keep an off-chain trade history.
+// IndexBlock is called inside EndBlock and must not block on I/O.
+type Indexer interface {
+	IndexBlock(events *BlockEvents)
+}
+
+// SetIndexer registers the indexer of the orderbook, nil disables indexing.
+func (ob *Orderbook) SetIndexer(indexer Indexer) {
+	ob.indexer = indexer
+}
+
+func (ob *Orderbook) emitOrderEvent(order *Order, typ OrderEventType) {
+	if ob.indexer == nil {
+		return
+	}
+	ob.events.Events = append(ob.events.Events, &OrderEvent{
+		OrderID:   order.id,
+		Type:      typ,
+		Pair:      order.Pair(),
+		Side:      order.Type,
+		Account:   order.Account,
+		Remaining: order.Remaining(),
+	})
+}
+
+func (ob *Orderbook) emitFill(maker, taker *Order, amount, price *big.Int) {
+	if ob.indexer == nil {
+		return
+	}
+	ob.events.Fills = append(ob.events.Fills, &Fill{
+		Pair:         taker.Pair(),
+		Price:        new(big.Int).Set(price),
+		Amount:       new(big.Int).Set(amount),
+		MakerOrderID: maker.id,
+		TakerOrderID: taker.id,
+		Maker:        maker.Account,
+		Taker:        taker.Account,
+		TakerSide:    taker.Type,
+	})
+}
+
+// flushEvents hands the events of block to the indexer.
+func (ob *Orderbook) flushEvents(height common.BlockNum, timestamp uint64) {
+	if ob.indexer == nil {
+		return
+	}
+	events := ob.events
+	ob.events = new(BlockEvents)
+	events.Height = height
+	events.Timestamp = timestamp
+	ob.indexer.IndexBlock(events)
+}
@@ -0,0 +1,145 @@
+package orderbook
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/udt"
+)
+
+const BpsDenominator = 10000
+
+// FeeSchedule is the trading fee of a pair in basis points of the token each side receives.
+// A negative MakerBps is a rebate paid by the treasury, it cannot exceed the taker fee.
+// In batch auctions there is no maker, both sides pay TakerBps.
+type FeeSchedule struct {
+	MakerBps int64 `json:"maker_bps"`
+	TakerBps int64 `json:"taker_bps"`
+}
+
+func (fs *FeeSchedule) Validate() error {
+	if fs.TakerBps < 0 || fs.TakerBps > BpsDenominator {
+		return errors.New("taker fee out of range")
+	}
+	if fs.MakerBps < -fs.TakerBps || fs.MakerBps > BpsDenominator {
+		return errors.New("maker fee out of range")
+	}
+	return nil
+}
+
+// FeeStats accumulates the fees of a pair in one fee period.
+type FeeStats struct {
+	Pair      OrderPair                `json:"pair"`
+	Period    uint64                   `json:"period"`
+	Collected map[udt.TokenID]*big.Int `json:"collected"`
+	Rebated   map[udt.TokenID]*big.Int `json:"rebated"`
+	// Unpaid is the rebates the treasury could not afford, the makers received their trades without them.
+	Unpaid map[udt.TokenID]*big.Int `json:"unpaid"`
+}
+
+func newFeeStats(pair OrderPair, period uint64) *FeeStats {
+	return &FeeStats{
+		Pair:      pair,
+		Period:    period,
+		Collected: make(map[udt.TokenID]*big.Int),
+		Rebated:   make(map[udt.TokenID]*big.Int),
+		Unpaid:    make(map[udt.TokenID]*big.Int),
+	}
+}
+
+func (fs *FeeStats) add(totals map[udt.TokenID]*big.Int, token udt.TokenID, amount *big.Int) {
+	if total, ok := totals[token]; ok {
+		total.Add(total, amount)
+		return
+	}
+	totals[token] = new(big.Int).Set(amount)
+}
+
+// chargeFee charges fee bps on amount of token received by order and credits the rest to its account.
+func (ob *Orderbook) chargeFee(order *Order, token udt.TokenID, amount *big.Int, bps int64) error {
+	fee := new(big.Int).Mul(amount, big.NewInt(bps))
+	fee.Quo(fee, big.NewInt(BpsDenominator))
+	stats, err := ob.getFeeStats(order.Pair(), ob.feePeriod())
+	if err != nil {
+		return err
+	}
+	switch fee.Sign() {
+	case -1:
+		rebate := fee.Neg(fee)
+		// rebates are paid only as long as the treasury can afford them, the rest is recorded as unpaid.
+		if err := ob.Account.SubBalance(ob.cfg.Treasury, token, rebate); err != nil {
+			logrus.Warnf("treasury can not pay the rebate %s %s of order(%s): %v", rebate, token, order.id, err)
+			stats.add(stats.Unpaid, token, rebate)
+			break
+		}
+		stats.add(stats.Rebated, token, rebate)
+		amount = new(big.Int).Add(amount, rebate)
+	case 1:
+		if err := ob.Account.AddBalance(ob.cfg.Treasury, token, fee); err != nil {
+			return err
+		}
+		stats.add(stats.Collected, token, fee)
+		amount = new(big.Int).Sub(amount, fee)
+	}
+	if fee.Sign() != 0 {
+		if err = ob.setFeeStats(stats); err != nil {
+			return err
+		}
+	}
+	return ob.Account.AddBalance(order.Account, token, amount)
+}
+
+// getFeeSchedule returns the fee schedule of pair, pairs without one trade without fees.
+func (ob *Orderbook) getFeeSchedule(pair OrderPair) (*FeeSchedule, error) {
+	schedule := new(FeeSchedule)
+	_, err := ob.getJson(feeScheduleKey(pair), schedule)
+	return schedule, err
+}
+
+func feeScheduleKey(pair OrderPair) []byte {
+	return pairKey("fee_schedule/", pair)
+}
+
+func feeStatsKey(pair OrderPair, period uint64) []byte {
+	return append(pairKey("fees/", pair), "/"+strconv.FormatUint(period, 10)...)
+}
+
+// feePeriodsKey keeps the fee periods in which pair paid fees, in ascending order.
+func feePeriodsKey(pair OrderPair) []byte {
+	return pairKey("fee_periods/", pair)
+}
+
+// getFeeStats returns the fee stats of pair in period, empty if it paid no fees in it.
+func (ob *Orderbook) getFeeStats(pair OrderPair, period uint64) (*FeeStats, error) {
+	stats := newFeeStats(pair, period)
+	_, err := ob.getJson(feeStatsKey(pair, period), stats)
+	return stats, err
+}
+
+func (ob *Orderbook) setFeeStats(stats *FeeStats) error {
+	periods, err := ob.getFeePeriods(stats.Pair)
+	if err != nil {
+		return err
+	}
+	if len(periods) == 0 || periods[len(periods)-1] < stats.Period {
+		if err = ob.setJson(feePeriodsKey(stats.Pair), append(periods, stats.Period)); err != nil {
+			return err
+		}
+	}
+	return ob.setJson(feeStatsKey(stats.Pair, stats.Period), stats)
+}
+
+func (ob *Orderbook) getFeePeriods(pair OrderPair) ([]uint64, error) {
+	var periods []uint64
+	_, err := ob.getJson(feePeriodsKey(pair), &periods)
+	return periods, err
+}
+
+func (ob *Orderbook) feePeriod() uint64 {
+	if ob.cfg.FeePeriod == 0 {
+		return 0
+	}
+	return uint64(ob.height / ob.cfg.FeePeriod)
+}
@@ -0,0 +1,98 @@
+package orderbook
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/udt"
+)
+
+func TestMakerRebate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     MatchMode
+		treasury int64
+		// the balances once bob sold 1000 BTC at 10 to alice, bob resting first
+		wantBobUSD, wantAliceBTC, wantTreasuryUSD int64
+		wantCollected, wantRebated, wantUnpaid    map[udt.TokenID]int64
+	}{
+		{
+			name:            "rebate paid",
+			treasury:        100,
+			wantBobUSD:      10_010,
+			wantAliceBTC:    997,
+			wantTreasuryUSD: 90,
+			wantCollected:   map[udt.TokenID]int64{"BTC": 3},
+			wantRebated:     map[udt.TokenID]int64{"USD": 10},
+		},
+		{
+			name:            "treasury short",
+			treasury:        5,
+			wantBobUSD:      10_000,
+			wantAliceBTC:    997,
+			wantTreasuryUSD: 5,
+			wantCollected:   map[udt.TokenID]int64{"BTC": 3},
+			wantUnpaid:      map[udt.TokenID]int64{"USD": 10},
+		},
+		{
+			name:            "no maker in a batch auction",
+			mode:            BatchAuction,
+			treasury:        100,
+			wantBobUSD:      9_970,
+			wantAliceBTC:    997,
+			wantTreasuryUSD: 130,
+			wantCollected:   map[udt.TokenID]int64{"BTC": 3, "USD": 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Governor: "gov", Treasury: "treasury"}
+			tb := newTestBook(t, cfg)
+			tb.fund(t, "gov", "USD", 0)
+			tb.fund(t, "treasury", "USD", tt.treasury)
+			tb.fund(t, "alice", "USD", 10_000)
+			tb.fund(t, "bob", "BTC", 1_000)
+			mustOk(t, tb.state.Execute(func() error {
+				err := tb.SetFeeSchedule(writeCtx(t, 1, &SetFeeScheduleRequest{Pair: btcUsd, Schedule: &FeeSchedule{MakerBps: -10, TakerBps: 30}}))
+				if err != nil {
+					return err
+				}
+				return tb.SetMatchMode(writeCtx(t, 1, &SetMatchModeRequest{Pair: btcUsd, Mode: tt.mode}))
+			}))
+			mustOk(t, tb.add(t, 1, limit(Sell, 1_000, 10, "bob")))
+			tb.endBlock(1)
+			mustOk(t, tb.add(t, 2, limit(Buy, 1_000, 10, "alice")))
+			tb.endBlock(2)
+
+			if got := tb.balance(t, "bob", "USD"); got != tt.wantBobUSD {
+				t.Fatalf("bob has %d USD, want %d", got, tt.wantBobUSD)
+			}
+			if got := tb.balance(t, "alice", "BTC"); got != tt.wantAliceBTC {
+				t.Fatalf("alice has %d BTC, want %d", got, tt.wantAliceBTC)
+			}
+			if got := tb.balance(t, "treasury", "USD"); got != tt.wantTreasuryUSD {
+				t.Fatalf("treasury has %d USD, want %d", got, tt.wantTreasuryUSD)
+			}
+			stats, err := tb.getFeeStats(btcUsd, 0)
+			mustOk(t, err)
+			for _, totals := range []struct {
+				name string
+				got  map[udt.TokenID]*big.Int
+				want map[udt.TokenID]int64
+			}{
+				{"collected", stats.Collected, tt.wantCollected},
+				{"rebated", stats.Rebated, tt.wantRebated},
+				{"unpaid", stats.Unpaid, tt.wantUnpaid},
+			} {
+				if len(totals.got) != len(totals.want) {
+					t.Fatalf("%s fees %v, want %v", totals.name, totals.got, totals.want)
+				}
+				for token, want := range totals.want {
+					if got := totals.got[token]; got == nil || got.Int64() != want {
+						t.Fatalf("%s %s fees %v, want %d", totals.name, token, got, want)
+					}
+				}
+			}
+		})
+	}
+}
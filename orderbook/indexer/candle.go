@@ -0,0 +1,126 @@
+package indexer
+
+import (
+	"database/sql"
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/orderbook"
+)
+
+// Period is the length of a candle.
+type Period struct {
+	Name    string
+	Seconds uint64
+}
+
+var Periods = []Period{
+	{Name: "1m", Seconds: 60},
+	{Name: "1h", Seconds: 60 * 60},
+	{Name: "1d", Seconds: 24 * 60 * 60},
+}
+
+func PeriodByName(name string) (Period, error) {
+	for _, period := range Periods {
+		if period.Name == name {
+			return period, nil
+		}
+	}
+	return Period{}, errors.New("unknown candle period: " + name)
+}
+
+type Candle struct {
+	Pair        orderbook.OrderPair `json:"pair"`
+	Period      string              `json:"period"`
+	StartTime   uint64              `json:"start_time"`
+	Open        *big.Int            `json:"open"`
+	High        *big.Int            `json:"high"`
+	Low         *big.Int            `json:"low"`
+	Close       *big.Int            `json:"close"`
+	Volume      *big.Int            `json:"volume"`
+	QuoteVolume *big.Int            `json:"quote_volume"`
+	Trades      uint64              `json:"trades"`
+}
+
+// updateCandle folds fill into the candle of period that contains timestamp.
+func updateCandle(tx *sql.Tx, fill *orderbook.Fill, period Period, timestamp uint64) error {
+	start := timestamp - timestamp%period.Seconds
+	quote := new(big.Int).Mul(fill.Price, fill.Amount)
+
+	row := tx.QueryRow(
+		`SELECT open_price, high_price, low_price, close_price, volume, quote_volume, trades FROM candles
+			WHERE order_token = ? AND pricing_token = ? AND period = ? AND start_time = ?`,
+		fill.Pair.OrderToken, fill.Pair.PricingToken, period.Name, start,
+	)
+	candle := &Candle{Pair: fill.Pair, Period: period.Name, StartTime: start}
+	err := scanCandle(row, candle)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = tx.Exec(
+			`INSERT INTO candles (order_token, pricing_token, period, start_time, open_price, high_price, low_price,
+				close_price, volume, quote_volume, trades) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			fill.Pair.OrderToken, fill.Pair.PricingToken, period.Name, start,
+			fill.Price.String(), fill.Price.String(), fill.Price.String(), fill.Price.String(),
+			fill.Amount.String(), quote.String(), 1,
+		)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if fill.Price.Cmp(candle.High) > 0 {
+		candle.High = fill.Price
+	}
+	if fill.Price.Cmp(candle.Low) < 0 {
+		candle.Low = fill.Price
+	}
+	candle.Volume.Add(candle.Volume, fill.Amount)
+	candle.QuoteVolume.Add(candle.QuoteVolume, quote)
+	_, err = tx.Exec(
+		`UPDATE candles SET high_price = ?, low_price = ?, close_price = ?, volume = ?, quote_volume = ?, trades = ?
+			WHERE order_token = ? AND pricing_token = ? AND period = ? AND start_time = ?`,
+		candle.High.String(), candle.Low.String(), fill.Price.String(), candle.Volume.String(),
+		candle.QuoteVolume.String(), candle.Trades+1,
+		fill.Pair.OrderToken, fill.Pair.PricingToken, period.Name, start,
+	)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCandle scans the prices, volumes and trades of a candle, after the leading columns scanned into dest.
+func scanCandle(row rowScanner, candle *Candle, dest ...any) error {
+	var open, high, low, closePrice, volume, quoteVolume string
+	dest = append(dest, &open, &high, &low, &closePrice, &volume, &quoteVolume, &candle.Trades)
+	err := row.Scan(dest...)
+	if err != nil {
+		return err
+	}
+	if candle.Open, err = parseInt(open); err != nil {
+		return err
+	}
+	if candle.High, err = parseInt(high); err != nil {
+		return err
+	}
+	if candle.Low, err = parseInt(low); err != nil {
+		return err
+	}
+	if candle.Close, err = parseInt(closePrice); err != nil {
+		return err
+	}
+	if candle.Volume, err = parseInt(volume); err != nil {
+		return err
+	}
+	candle.QuoteVolume, err = parseInt(quoteVolume)
+	return err
+}
+
+func parseInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.New("invalid integer in index: " + s)
+	}
+	return i, nil
+}
@@ -0,0 +1,12 @@
+package indexer
+
+type Config struct {
+	// Driver is the database/sql driver, "mysql" or "sqlite".
+	Driver string `toml:"driver"`
+	// DSN is the data source name, e.g. "user:pass@tcp(127.0.0.1:3306)/jingchou" or "file:orderbook.db".
+	DSN string `toml:"dsn"`
+	// HttpAddr serves the query endpoints if it is not empty, e.g. ":7999".
+	HttpAddr string `toml:"http_addr"`
+	// QueueSize is the number of blocks buffered for the database, default 1024. Blocks beyond it are dropped.
+	QueueSize int `toml:"queue_size"`
+}
@@ -0,0 +1,140 @@
+package indexer
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"sync"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/orderbook"
+	_ "modernc.org/sqlite"
+)
+
+const defaultQueueSize = 1024
+
+// SQLIndexer writes the fills, order events and OHLCV candles of the orderbook into a SQL database.
+// It runs off-chain: blocks are written by a background worker and never affect the chain state.
+type SQLIndexer struct {
+	cfg   *Config
+	db    *sql.DB
+	queue chan *orderbook.BlockEvents
+	wg    sync.WaitGroup
+}
+
+func NewSQLIndexer(cfg *Config) (*SQLIndexer, error) {
+	if cfg.Driver == "" || cfg.DSN == "" {
+		return nil, errors.New("indexer driver and dsn are required")
+	}
+	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Driver == "sqlite" {
+		// sqlite allows a single writer.
+		db.SetMaxOpenConns(1)
+	}
+	if err = migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	idx := &SQLIndexer{
+		cfg:   cfg,
+		db:    db,
+		queue: make(chan *orderbook.BlockEvents, queueSize),
+	}
+	idx.wg.Add(1)
+	go idx.run()
+	if cfg.HttpAddr != "" {
+		go func() {
+			err := http.ListenAndServe(cfg.HttpAddr, idx.Handler())
+			logrus.Errorf("orderbook indexer http server stopped: %v", err)
+		}()
+	}
+	return idx, nil
+}
+
+// IndexBlock queues the block for the background worker without waiting.
+// A block arriving with the queue full is dropped and counted by DroppedBlocksCounter, leaving a gap in the history.
+func (idx *SQLIndexer) IndexBlock(events *orderbook.BlockEvents) {
+	select {
+	case idx.queue <- events:
+	default:
+		DroppedBlocksCounter.Inc()
+		logrus.Warnf("orderbook indexer queue is full, drop block(%d)", events.Height)
+	}
+}
+
+// Close writes the queued blocks and closes the database.
+func (idx *SQLIndexer) Close() error {
+	close(idx.queue)
+	idx.wg.Wait()
+	return idx.db.Close()
+}
+
+func (idx *SQLIndexer) run() {
+	defer idx.wg.Done()
+	for events := range idx.queue {
+		if err := idx.writeBlock(events); err != nil {
+			logrus.Errorf("index orderbook block(%d) failed: %v", events.Height, err)
+		}
+	}
+}
+
+// writeBlock writes one block in a single transaction, blocks already indexed are skipped.
+func (idx *SQLIndexer) writeBlock(events *orderbook.BlockEvents) error {
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var indexed int
+	err = tx.QueryRow(`SELECT COUNT(*) FROM indexed_blocks WHERE height = ?`, events.Height).Scan(&indexed)
+	if err != nil {
+		return err
+	}
+	if indexed > 0 {
+		return nil
+	}
+	_, err = tx.Exec(`INSERT INTO indexed_blocks (height, block_time) VALUES (?, ?)`, events.Height, events.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	for i, fill := range events.Fills {
+		_, err = tx.Exec(
+			`INSERT INTO fills (height, seq, block_time, order_token, pricing_token, price, amount,
+				maker_order_id, taker_order_id, maker, taker, taker_side) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			events.Height, i, events.Timestamp, fill.Pair.OrderToken, fill.Pair.PricingToken,
+			fill.Price.String(), fill.Amount.String(),
+			fill.MakerOrderID, fill.TakerOrderID, fill.Maker, fill.Taker, fill.TakerSide,
+		)
+		if err != nil {
+			return err
+		}
+		for _, period := range Periods {
+			if err = updateCandle(tx, fill, period, events.Timestamp); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, event := range events.Events {
+		_, err = tx.Exec(
+			`INSERT INTO order_events (height, seq, block_time, order_id, event_type, order_token, pricing_token,
+				side, account, remaining) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			events.Height, i, events.Timestamp, event.OrderID, string(event.Type),
+			event.Pair.OrderToken, event.Pair.PricingToken, event.Side, event.Account, event.Remaining.String(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,186 @@
+package indexer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/yu-org/JingChou/orderbook"
+	"github.com/yu-org/yu/common"
+)
+
+var btcUsd = orderbook.OrderPair{OrderToken: "BTC", PricingToken: "USD"}
+
+// day2 is 01:00:10 of the third day, so that no candle starts at 0.
+const day2 = 2*24*60*60 + 60*60 + 10
+
+func newTestIndexer(t *testing.T, queueSize int) *SQLIndexer {
+	idx, err := NewSQLIndexer(&Config{Driver: "sqlite", DSN: "file::memory:", QueueSize: queueSize})
+	mustOk(t, err)
+	return idx
+}
+
+// flush waits for the worker to write every queued block, leaving the database open for queries.
+func (idx *SQLIndexer) flush(t *testing.T) {
+	close(idx.queue)
+	idx.wg.Wait()
+	t.Cleanup(func() { idx.db.Close() })
+}
+
+func block(height common.BlockNum, timestamp uint64, fills ...*orderbook.Fill) *orderbook.BlockEvents {
+	return &orderbook.BlockEvents{Height: height, Timestamp: timestamp, Fills: fills}
+}
+
+func fill(price, amount int64) *orderbook.Fill {
+	return &orderbook.Fill{
+		Pair:         btcUsd,
+		Price:        big.NewInt(price),
+		Amount:       big.NewInt(amount),
+		MakerOrderID: "maker",
+		TakerOrderID: "taker",
+		Maker:        "bob",
+		Taker:        "alice",
+		TakerSide:    orderbook.Buy,
+	}
+}
+
+func TestIndexFillsAndOrderEvents(t *testing.T) {
+	idx := newTestIndexer(t, 0)
+	first := block(1, day2, fill(10, 2), fill(12, 1))
+	first.Events = []*orderbook.OrderEvent{
+		{OrderID: "taker", Type: orderbook.OrderAdded, Pair: btcUsd, Side: orderbook.Buy, Account: "alice", Remaining: big.NewInt(3)},
+		{OrderID: "taker", Type: orderbook.OrderFilled, Pair: btcUsd, Side: orderbook.Buy, Account: "alice", Remaining: big.NewInt(0)},
+	}
+	idx.IndexBlock(first)
+	idx.IndexBlock(block(2, day2+30, fill(8, 1)))
+	// a block indexed again, e.g. after a restart, is skipped
+	idx.IndexBlock(block(2, day2+30, fill(100, 100)))
+	idx.flush(t)
+
+	fills, err := idx.Fills(btcUsd, 10)
+	mustOk(t, err)
+	want := []struct {
+		height        common.BlockNum
+		price, amount int64
+	}{{2, 8, 1}, {1, 12, 1}, {1, 10, 2}}
+	if len(fills) != len(want) {
+		t.Fatalf("%d fills indexed, want %d", len(fills), len(want))
+	}
+	for i, w := range want {
+		if fills[i].Height != w.height || fills[i].Price.Int64() != w.price || fills[i].Amount.Int64() != w.amount {
+			t.Fatalf("fill %d is %d at %s in block %d, want %d at %d in block %d",
+				i, fills[i].Amount, fills[i].Price, fills[i].Height, w.amount, w.price, w.height)
+		}
+	}
+	if fills, err = idx.Fills(btcUsd, 1); err != nil || len(fills) != 1 || fills[0].Height != 2 {
+		t.Fatalf("the latest fill: %v, %v", fills, err)
+	}
+
+	events, err := idx.OrderEvents("taker")
+	mustOk(t, err)
+	if len(events) != 2 || events[0].Type != orderbook.OrderAdded || events[1].Type != orderbook.OrderFilled ||
+		events[1].Remaining.Sign() != 0 || events[1].Account != "alice" {
+		t.Fatalf("order events %+v", events)
+	}
+}
+
+func TestIndexCandles(t *testing.T) {
+	idx := newTestIndexer(t, 0)
+	idx.IndexBlock(block(1, day2, fill(10, 2), fill(12, 1)))
+	idx.IndexBlock(block(2, day2+30, fill(8, 1)))
+	idx.IndexBlock(block(3, day2+120, fill(11, 1)))
+	idx.IndexBlock(block(4, day2+60*60, fill(9, 1)))
+	idx.flush(t)
+
+	type candle struct {
+		start                                         uint64
+		open, high, low, close, volume, quote, trades int64
+	}
+	minute, hour, day := uint64(day2-10), uint64(day2-10), uint64(2*24*60*60)
+	tests := []struct {
+		period string
+		want   []candle
+	}{
+		{"1m", []candle{
+			{minute, 10, 12, 8, 8, 4, 40, 3},
+			{minute + 120, 11, 11, 11, 11, 1, 11, 1},
+			{minute + 60*60, 9, 9, 9, 9, 1, 9, 1},
+		}},
+		{"1h", []candle{
+			{hour, 10, 12, 8, 11, 5, 51, 4},
+			{hour + 60*60, 9, 9, 9, 9, 1, 9, 1},
+		}},
+		{"1d", []candle{
+			{day, 10, 12, 8, 9, 6, 60, 5},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			period, err := PeriodByName(tt.period)
+			mustOk(t, err)
+			candles, err := idx.Candles(btcUsd, period, 0, 1<<62)
+			mustOk(t, err)
+			if len(candles) != len(tt.want) {
+				t.Fatalf("%d candles, want %d", len(candles), len(tt.want))
+			}
+			for i, w := range tt.want {
+				c := candles[i]
+				got := candle{c.StartTime, c.Open.Int64(), c.High.Int64(), c.Low.Int64(), c.Close.Int64(),
+					c.Volume.Int64(), c.QuoteVolume.Int64(), int64(c.Trades)}
+				if got != w {
+					t.Fatalf("candle %d is %+v, want %+v", i, got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestDroppedBlocks(t *testing.T) {
+	idx := newTestIndexer(t, 1)
+	// hold the only connection, so that the worker blocks on the first block it takes
+	tx, err := idx.db.Begin()
+	mustOk(t, err)
+	dropped := droppedBlocks(t)
+
+	idx.IndexBlock(block(1, day2, fill(10, 1)))
+	for deadline := time.Now().Add(5 * time.Second); len(idx.queue) > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("the worker took no block")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	idx.IndexBlock(block(2, day2, fill(10, 1)))
+	idx.IndexBlock(block(3, day2, fill(10, 1)))
+	if got := droppedBlocks(t) - dropped; got != 1 {
+		t.Fatalf("%v blocks dropped, want 1", got)
+	}
+
+	mustOk(t, tx.Rollback())
+	idx.flush(t)
+	var heights []common.BlockNum
+	rows, err := idx.db.Query(`SELECT height FROM indexed_blocks ORDER BY height`)
+	mustOk(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var height common.BlockNum
+		mustOk(t, rows.Scan(&height))
+		heights = append(heights, height)
+	}
+	if len(heights) != 2 || heights[0] != 1 || heights[1] != 2 {
+		t.Fatalf("indexed blocks %v, want 1 and 2", heights)
+	}
+}
+
+func droppedBlocks(t *testing.T) float64 {
+	metric := new(dto.Metric)
+	mustOk(t, DroppedBlocksCounter.Write(metric))
+	return metric.GetCounter().GetValue()
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
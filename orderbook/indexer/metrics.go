@@ -0,0 +1,17 @@
+package indexer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DroppedBlocksCounter counts the blocks not indexed because the queue was full.
+var DroppedBlocksCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "jingchou",
+	Subsystem: "orderbook_indexer",
+	Name:      "dropped_blocks_count",
+	Help:      "Counter of the orderbook blocks dropped by the indexer",
+})
+
+func init() {
+	prometheus.MustRegister(DroppedBlocksCounter)
+}
@@ -0,0 +1,93 @@
+package indexer
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations are applied in order and recorded in schema_migrations, never edit an applied one, append a new one.
+// Amounts and prices are decimal strings, so the schema works on both MySQL and SQLite.
+var migrations = [][]string{
+	{
+		`CREATE TABLE indexed_blocks (
+			height BIGINT NOT NULL PRIMARY KEY,
+			block_time BIGINT NOT NULL
+		)`,
+		`CREATE TABLE fills (
+			height BIGINT NOT NULL,
+			seq INTEGER NOT NULL,
+			block_time BIGINT NOT NULL,
+			order_token VARCHAR(128) NOT NULL,
+			pricing_token VARCHAR(128) NOT NULL,
+			price VARCHAR(80) NOT NULL,
+			amount VARCHAR(80) NOT NULL,
+			maker_order_id VARCHAR(64) NOT NULL,
+			taker_order_id VARCHAR(64) NOT NULL,
+			maker VARCHAR(128) NOT NULL,
+			taker VARCHAR(128) NOT NULL,
+			taker_side SMALLINT NOT NULL,
+			PRIMARY KEY (height, seq)
+		)`,
+		`CREATE INDEX idx_fills_pair ON fills (order_token, pricing_token, height)`,
+		`CREATE TABLE order_events (
+			height BIGINT NOT NULL,
+			seq INTEGER NOT NULL,
+			block_time BIGINT NOT NULL,
+			order_id VARCHAR(64) NOT NULL,
+			event_type VARCHAR(16) NOT NULL,
+			order_token VARCHAR(128) NOT NULL,
+			pricing_token VARCHAR(128) NOT NULL,
+			side SMALLINT NOT NULL,
+			account VARCHAR(128) NOT NULL,
+			remaining VARCHAR(80) NOT NULL,
+			PRIMARY KEY (height, seq)
+		)`,
+		`CREATE INDEX idx_order_events_order ON order_events (order_id)`,
+		`CREATE TABLE candles (
+			order_token VARCHAR(128) NOT NULL,
+			pricing_token VARCHAR(128) NOT NULL,
+			period VARCHAR(8) NOT NULL,
+			start_time BIGINT NOT NULL,
+			open_price VARCHAR(80) NOT NULL,
+			high_price VARCHAR(80) NOT NULL,
+			low_price VARCHAR(80) NOT NULL,
+			close_price VARCHAR(80) NOT NULL,
+			volume VARCHAR(80) NOT NULL,
+			quote_volume VARCHAR(80) NOT NULL,
+			trades INTEGER NOT NULL,
+			PRIMARY KEY (order_token, pricing_token, period, start_time)
+		)`,
+	},
+}
+
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+	var applied int
+	err = db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	for version := applied; version < len(migrations); version++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, stmt := range migrations[version] {
+			if _, err = tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("migration %d failed: %w", version+1, err)
+			}
+		}
+		if _, err = tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, version+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package indexer
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/yu-org/JingChou/orderbook"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+)
+
+const defaultQueryLimit = 100
+
+type FillRecord struct {
+	*orderbook.Fill
+	Height    common.BlockNum `json:"height"`
+	Timestamp uint64          `json:"timestamp"`
+}
+
+type OrderEventRecord struct {
+	*orderbook.OrderEvent
+	Height    common.BlockNum `json:"height"`
+	Timestamp uint64          `json:"timestamp"`
+}
+
+// Fills returns the latest fills of pair, newest first.
+func (idx *SQLIndexer) Fills(pair orderbook.OrderPair, limit int) ([]*FillRecord, error) {
+	rows, err := idx.db.Query(
+		`SELECT height, block_time, price, amount, maker_order_id, taker_order_id, maker, taker, taker_side FROM fills
+			WHERE order_token = ? AND pricing_token = ? ORDER BY height DESC, seq DESC LIMIT ?`,
+		pair.OrderToken, pair.PricingToken, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*FillRecord, 0)
+	for rows.Next() {
+		var price, amount string
+		record := &FillRecord{Fill: &orderbook.Fill{Pair: pair}}
+		err = rows.Scan(&record.Height, &record.Timestamp, &price, &amount, &record.MakerOrderID,
+			&record.TakerOrderID, &record.Maker, &record.Taker, &record.TakerSide)
+		if err != nil {
+			return nil, err
+		}
+		if record.Price, err = parseInt(price); err != nil {
+			return nil, err
+		}
+		if record.Amount, err = parseInt(amount); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// OrderEvents returns the lifecycle of an order, oldest first.
+func (idx *SQLIndexer) OrderEvents(orderID string) ([]*OrderEventRecord, error) {
+	rows, err := idx.db.Query(
+		`SELECT height, block_time, event_type, order_token, pricing_token, side, account, remaining FROM order_events
+			WHERE order_id = ? ORDER BY height, seq`,
+		orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*OrderEventRecord, 0)
+	for rows.Next() {
+		var eventType, remaining string
+		record := &OrderEventRecord{OrderEvent: &orderbook.OrderEvent{OrderID: orderID}}
+		err = rows.Scan(&record.Height, &record.Timestamp, &eventType, &record.Pair.OrderToken,
+			&record.Pair.PricingToken, &record.Side, &record.Account, &remaining)
+		if err != nil {
+			return nil, err
+		}
+		record.Type = orderbook.OrderEventType(eventType)
+		if record.Remaining, err = parseInt(remaining); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Candles returns the candles of pair in period which start in [from, to], oldest first.
+func (idx *SQLIndexer) Candles(pair orderbook.OrderPair, period Period, from, to uint64) ([]*Candle, error) {
+	rows, err := idx.db.Query(
+		`SELECT start_time, open_price, high_price, low_price, close_price, volume, quote_volume, trades FROM candles
+			WHERE order_token = ? AND pricing_token = ? AND period = ? AND start_time >= ? AND start_time <= ?
+			ORDER BY start_time`,
+		pair.OrderToken, pair.PricingToken, period.Name, from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candles := make([]*Candle, 0)
+	for rows.Next() {
+		candle := &Candle{Pair: pair, Period: period.Name}
+		if err = scanCandle(rows, candle, &candle.StartTime); err != nil {
+			return nil, err
+		}
+		candles = append(candles, candle)
+	}
+	return candles, rows.Err()
+}
+
+// Handler serves the query endpoints:
+//
+//	GET /fills?order_token=&pricing_token=&limit=
+//	GET /orders/events?order_id=
+//	GET /candles?order_token=&pricing_token=&period=1m|1h|1d&from=&to=
+func (idx *SQLIndexer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fills", func(w http.ResponseWriter, r *http.Request) {
+		limit, err := queryUint(r, "limit", defaultQueryLimit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		fills, err := idx.Fills(queryPair(r), int(limit))
+		writeResult(w, fills, err)
+	})
+	mux.HandleFunc("GET /orders/events", func(w http.ResponseWriter, r *http.Request) {
+		events, err := idx.OrderEvents(r.URL.Query().Get("order_id"))
+		writeResult(w, events, err)
+	})
+	mux.HandleFunc("GET /candles", func(w http.ResponseWriter, r *http.Request) {
+		period, err := PeriodByName(r.URL.Query().Get("period"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		from, err := queryUint(r, "from", 0)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		to, err := queryUint(r, "to", 1<<63-1)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		candles, err := idx.Candles(queryPair(r), period, from, to)
+		writeResult(w, candles, err)
+	})
+	return mux
+}
+
+func queryPair(r *http.Request) orderbook.OrderPair {
+	return orderbook.OrderPair{
+		OrderToken:   udt.TokenID(r.URL.Query().Get("order_token")),
+		PricingToken: udt.TokenID(r.URL.Query().Get("pricing_token")),
+	}
+}
+
+func queryUint(r *http.Request, key string, defaultValue uint64) (uint64, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func writeResult(w http.ResponseWriter, result any, err error) {
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"err": err.Error()})
+}
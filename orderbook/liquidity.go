@@ -0,0 +1,157 @@
+package orderbook
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+// Liquidity is an outside venue, e.g. the AMM pools of the swap package, that trades with new orders
+// as a passive maker during continuous matching. A taker fills from it while its marginal price
+// is better than the best maker in the book, so the taker gets the best price of both venues.
+// Fills from it only pay the fee of the venue, not the fee schedule of the pair.
+// Orders of pairs in batch auction mode do not trade with it.
+type Liquidity interface {
+	// Depth returns how much of orderToken the venue sells to a buyer (buy is true), or buys from a seller,
+	// before its marginal price in pricingToken per orderToken passes price, at most max.
+	Depth(orderToken, pricingToken udt.TokenID, buy bool, price, max *big.Int) (*big.Int, error)
+	// Trade trades amount of orderToken and returns the pricingToken paid by a buyer, at most limit,
+	// or received by a seller, at least limit.
+	Trade(orderToken, pricingToken udt.TokenID, buy bool, amount, limit *big.Int, height common.BlockNum) (*big.Int, error)
+}
+
+// LiquidityMaker is the maker of the fills against Liquidity in the trade history.
+const LiquidityMaker = "liquidity"
+
+// SetLiquidity registers the outside liquidity of the orderbook, nil disables it.
+func (ob *Orderbook) SetLiquidity(liquidity Liquidity) {
+	ob.liquidity = liquidity
+}
+
+// Execution is how a routed order filled in the book and from the liquidity, the event of its RouteOrder txn.
+// The quotes are before the fees of the pair.
+type Execution struct {
+	OrderID         string   `json:"order_id"`
+	BookAmount      *big.Int `json:"book_amount"`
+	BookQuote       *big.Int `json:"book_quote"`
+	LiquidityAmount *big.Int `json:"liquidity_amount"`
+	LiquidityQuote  *big.Int `json:"liquidity_quote"`
+	// AvgPrice is the quote of both venues per OrderToken filled, rounded down, nil if nothing filled.
+	AvgPrice *big.Int `json:"avg_price,omitempty"`
+}
+
+func (e *Execution) add(liquidity bool, amount, quote *big.Int) {
+	if liquidity {
+		e.LiquidityAmount.Add(e.LiquidityAmount, amount)
+		e.LiquidityQuote.Add(e.LiquidityQuote, quote)
+	} else {
+		e.BookAmount.Add(e.BookAmount, amount)
+		e.BookQuote.Add(e.BookQuote, quote)
+	}
+}
+
+// RouteOrder fills a market order right away in its txn, from the makers of the book and the liquidity
+// at the best price of both venues, instead of matching it in EndBlock as AddOrder does.
+// What is left unfilled is refunded, the Execution is emitted as the event of the txn.
+func (ob *Orderbook) RouteOrder(ctx *context.WriteContext) error {
+	req := new(AddOrderRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if req.Order == nil || req.Order.Kind != Market || req.Order.Condition != nil || req.Order.PostOnly {
+		return errors.New("only an unconditional market order can be routed")
+	}
+	if ob.matchMode(req.Order.Pair()) == BatchAuction {
+		return errors.New("orders of a pair in batch auction mode can not be routed")
+	}
+	order, err := ob.openOrder(req, ctx.Block.Height)
+	if err != nil {
+		return err
+	}
+	ob.height = ctx.Block.Height
+	order.execution = &Execution{
+		OrderID:         order.id,
+		BookAmount:      big.NewInt(0),
+		BookQuote:       big.NewInt(0),
+		LiquidityAmount: big.NewInt(0),
+		LiquidityQuote:  big.NewInt(0),
+	}
+	if err = ob.execute(order); err != nil {
+		return err
+	}
+	execution := order.execution
+	if filled := new(big.Int).Add(execution.BookAmount, execution.LiquidityAmount); filled.Sign() > 0 {
+		execution.AvgPrice = new(big.Int).Add(execution.BookQuote, execution.LiquidityQuote)
+		execution.AvgPrice.Quo(execution.AvgPrice, filled)
+	}
+	return ctx.EmitJsonEvent(execution)
+}
+
+// liquidityDepth returns how much of the remaining amount of taker the liquidity fills up to price.
+func (ob *Orderbook) liquidityDepth(taker *Order, price *big.Int) *big.Int {
+	if ob.liquidity == nil || ob.matchMode(taker.Pair()) == BatchAuction {
+		return big.NewInt(0)
+	}
+	depth, err := ob.liquidity.Depth(taker.OrderToken, taker.PricingToken, taker.Type == Buy, price, taker.remaining)
+	if err != nil {
+		logrus.Errorf("query liquidity depth of order(%s) failed: %v", taker.id, err)
+		return big.NewInt(0)
+	}
+	return depth
+}
+
+// takeLiquidity fills taker from the liquidity until its marginal price reaches price,
+// the price of the best maker or the limit price of taker. It reports false if the liquidity failed to trade,
+// the taker then keeps matching against the book only.
+func (ob *Orderbook) takeLiquidity(taker *Order, price *big.Int) (bool, error) {
+	amount := ob.liquidityDepth(taker, price)
+	if amount.Sign() == 0 {
+		return true, nil
+	}
+	limit := new(big.Int).Mul(amount, taker.limitPrice())
+	quote, err := ob.liquidity.Trade(taker.OrderToken, taker.PricingToken, taker.Type == Buy, amount, limit, ob.height)
+	if err != nil {
+		logrus.Warnf("order(%s) trade with liquidity failed: %v", taker.id, err)
+		return false, nil
+	}
+	if taker.Type == Buy {
+		taker.escrow.Sub(taker.escrow, quote)
+		err = ob.Account.AddBalance(taker.Account, taker.OrderToken, amount)
+	} else {
+		taker.escrow.Sub(taker.escrow, amount)
+		err = ob.Account.AddBalance(taker.Account, taker.PricingToken, quote)
+	}
+	if err != nil {
+		return false, err
+	}
+	taker.remaining.Sub(taker.remaining, amount)
+	// the average price of the fill, rounded down
+	avgPrice := new(big.Int).Quo(quote, amount)
+	if avgPrice.Sign() > 0 {
+		ob.setLastPrice(taker.Pair(), avgPrice)
+	}
+	ob.emitLiquidityFill(taker, amount, avgPrice)
+	if taker.execution != nil {
+		taker.execution.add(true, amount, quote)
+	}
+	return true, nil
+}
+
+func (ob *Orderbook) emitLiquidityFill(taker *Order, amount, price *big.Int) {
+	if ob.indexer == nil {
+		return
+	}
+	ob.events.Fills = append(ob.events.Fills, &Fill{
+		Pair:         taker.Pair(),
+		Price:        new(big.Int).Set(price),
+		Amount:       new(big.Int).Set(amount),
+		TakerOrderID: taker.id,
+		Maker:        LiquidityMaker,
+		Taker:        taker.Account,
+		TakerSide:    taker.Type,
+	})
+}
@@ -0,0 +1,120 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+)
+
+// fixedLiquidity sells up to depth of OrderToken to buyers at price.
+type fixedLiquidity struct {
+	price, depth *big.Int
+}
+
+func (fl *fixedLiquidity) Depth(orderToken, pricingToken udt.TokenID, buy bool, price, max *big.Int) (*big.Int, error) {
+	if !buy || fl.price.Cmp(price) > 0 {
+		return big.NewInt(0), nil
+	}
+	if fl.depth.Cmp(max) > 0 {
+		return new(big.Int).Set(max), nil
+	}
+	return new(big.Int).Set(fl.depth), nil
+}
+
+func (fl *fixedLiquidity) Trade(orderToken, pricingToken udt.TokenID, buy bool, amount, limit *big.Int, height common.BlockNum) (*big.Int, error) {
+	fl.depth.Sub(fl.depth, amount)
+	return new(big.Int).Mul(amount, fl.price), nil
+}
+
+func market(typ OrderType, amount, price int64, account string) *Order {
+	order := limit(typ, amount, price, account)
+	order.Kind = Market
+	return order
+}
+
+func TestRouteOrderFillsFromBothVenues(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.SetLiquidity(&fixedLiquidity{price: big.NewInt(45), depth: big.NewInt(10)})
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 5)
+	mustOk(t, tb.add(t, 1, limit(Sell, 5, 50, "bob")))
+	tb.endBlock(1)
+
+	ctx := writeCtx(t, 2, &AddOrderRequest{Order: market(Buy, 12, 60, "alice")})
+	mustOk(t, tb.state.Execute(func() error { return tb.RouteOrder(ctx) }))
+
+	// the liquidity sells its 10 BTC at 45 before the maker at 50 fills the rest, without waiting for EndBlock
+	if got := tb.balance(t, "alice", "BTC"); got != 12 {
+		t.Fatalf("alice has %d BTC, want 12", got)
+	}
+	if got := tb.balance(t, "alice", "USD"); got != 1_000-450-100 {
+		t.Fatalf("alice has %d USD, want %d", got, 1_000-450-100)
+	}
+	if sells := tb.book(t, sellBook); len(sells) != 1 || sells[0].remaining.Int64() != 3 {
+		t.Fatalf("sell book after the routed order: %v", sells)
+	}
+	if len(ctx.Events) != 1 {
+		t.Fatalf("RouteOrder emitted %d events, want 1", len(ctx.Events))
+	}
+	execution := new(Execution)
+	mustOk(t, json.Unmarshal(ctx.Events[0].Value, execution))
+	if execution.LiquidityAmount.Int64() != 10 || execution.LiquidityQuote.Int64() != 450 ||
+		execution.BookAmount.Int64() != 2 || execution.BookQuote.Int64() != 100 || execution.AvgPrice.Int64() != 45 {
+		t.Fatalf("execution %+v", execution)
+	}
+}
+
+func TestRouteOrderRefundsUnfilled(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.SetLiquidity(&fixedLiquidity{price: big.NewInt(45), depth: big.NewInt(4)})
+	tb.fund(t, "alice", "USD", 1_000)
+
+	ctx := writeCtx(t, 1, &AddOrderRequest{Order: market(Buy, 10, 50, "alice")})
+	mustOk(t, tb.state.Execute(func() error { return tb.RouteOrder(ctx) }))
+	if got := tb.balance(t, "alice", "BTC"); got != 4 {
+		t.Fatalf("alice has %d BTC, want 4", got)
+	}
+	if got := tb.balance(t, "alice", "USD"); got != 1_000-180 {
+		t.Fatalf("alice has %d USD, want the unfilled escrow back", got)
+	}
+	if buys := tb.book(t, buyBook); len(buys) != 0 {
+		t.Fatalf("the routed order rests in the book: %v", buys)
+	}
+}
+
+func TestRouteOrderRejects(t *testing.T) {
+	tb := newTestBook(t, &Config{Governor: "gov"})
+	tb.fund(t, "gov", "USD", 0)
+	tb.fund(t, "alice", "USD", 1_000)
+	conditional := market(Buy, 1, 50, "alice")
+	conditional.Condition = &Condition{TriggerPrice: big.NewInt(40)}
+	tests := []struct {
+		name    string
+		order   *Order
+		auction bool
+		wantErr string
+	}{
+		{"limit order", limit(Buy, 1, 50, "alice"), false, "only an unconditional market order can be routed"},
+		{"conditional order", conditional, false, "only an unconditional market order can be routed"},
+		{"batch auction pair", market(Buy, 1, 50, "alice"), true, "orders of a pair in batch auction mode can not be routed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.auction {
+				mustOk(t, tb.state.Execute(func() error {
+					return tb.SetMatchMode(writeCtx(t, 1, &SetMatchModeRequest{Pair: btcUsd, Mode: BatchAuction}))
+				}))
+			}
+			err := tb.RouteOrder(writeCtx(t, 1, &AddOrderRequest{Order: tt.order}))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("RouteOrder returned %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+	if got := tb.balance(t, "alice", "USD"); got != 1_000 {
+		t.Fatalf("alice has %d USD after the rejected orders, want 1000", got)
+	}
+}
@@ -2,11 +2,16 @@ package orderbook
 
 import (
 	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+
 	"github.com/yu-org/JingChou/udt"
 	"github.com/yu-org/yu/common"
-	"math/big"
 )
 
+var Zero = big.NewInt(0)
+
 type Order struct {
 	Type OrderType `json:"type"`
 
@@ -14,12 +19,33 @@ type Order struct {
 	Amount     *big.Int    `json:"amount"`
 
 	PricingToken udt.TokenID `json:"pricing_token"`
-	Price        *big.Int    `json:"price"`
+	// Price is the limit price. For market orders it is the worst acceptable price,
+	// a market buy must set it to bound the escrow, a market sell may leave it empty.
+	Price *big.Int `json:"price"`
+
+	Kind        OrderKind   `json:"kind"`
+	TimeInForce TimeInForce `json:"time_in_force"`
+	// PostOnly orders are canceled instead of taking liquidity from the book.
+	PostOnly bool `json:"post_only,omitempty"`
+	// ExpireHeight is the last block height the order can rest in the book, 0 means never expire.
+	ExpireHeight common.BlockNum `json:"expire_height,omitempty"`
+	// Condition keeps the order dormant until the last traded price of its pair reaches the trigger price.
+	Condition *Condition `json:"condition,omitempty"`
 
-	Owner *OrderScript `json:"owner"`
+	// Account holds the escrow of the order and receives the fills.
+	Account string       `json:"account"`
+	Owner   *OrderScript `json:"owner"`
 	// Taker *OrderScript `json:"taker"`
 
 	Capacity uint64 `json:"capacity"`
+
+	id        string
+	seq       uint64
+	remaining *big.Int
+	escrow    *big.Int
+	triggered bool
+	// execution tracks the fills of a routed order by venue, see RouteOrder.
+	execution *Execution
 }
 
 func (o *Order) ID() (string, error) {
@@ -37,6 +63,104 @@ func (o *Order) Pair() OrderPair {
 	}
 }
 
+func (o *Order) Validate() error {
+	if o.Amount == nil || o.Amount.Sign() <= 0 {
+		return errors.New("order amount must be positive")
+	}
+	if o.OrderToken == o.PricingToken {
+		return errors.New("order token and pricing token are the same")
+	}
+	if o.Type != Buy && o.Type != Sell {
+		return errors.New("unknown order type")
+	}
+	if o.Price != nil && o.Price.Sign() < 0 {
+		return errors.New("order price is negative")
+	}
+	switch o.Kind {
+	case Limit:
+		if o.Price == nil || o.Price.Sign() == 0 {
+			return errors.New("limit order needs a price")
+		}
+	case Market:
+		if o.Type == Buy && (o.Price == nil || o.Price.Sign() == 0) {
+			return errors.New("market buy order needs a worst price")
+		}
+	default:
+		return errors.New("unknown order kind")
+	}
+	if o.TimeInForce > FillOrKill {
+		return errors.New("unknown time in force")
+	}
+	if o.PostOnly && (o.Kind == Market || o.TimeInForce != GoodTillCancel) {
+		return errors.New("post-only order must be a good-till-cancel limit order")
+	}
+	if o.Condition != nil {
+		return o.Condition.Validate()
+	}
+	return nil
+}
+
+// dormant reports whether the order still waits for its condition.
+func (o *Order) dormant() bool {
+	return o.Condition != nil && !o.triggered
+}
+
+// EscrowToken is the token locked while the order is open.
+func (o *Order) EscrowToken() udt.TokenID {
+	if o.Type == Buy {
+		return o.PricingToken
+	}
+	return o.OrderToken
+}
+
+// EscrowAmount is the amount of EscrowToken an order locks when it is added.
+func (o *Order) EscrowAmount() *big.Int {
+	if o.Type == Buy {
+		return new(big.Int).Mul(o.Amount, o.Price)
+	}
+	return new(big.Int).Set(o.Amount)
+}
+
+// Remaining returns the amount of OrderToken not filled yet.
+func (o *Order) Remaining() *big.Int {
+	if o.remaining == nil {
+		return new(big.Int).Set(o.Amount)
+	}
+	return new(big.Int).Set(o.remaining)
+}
+
+// rests reports whether the unfilled part of the order can stay in the book.
+func (o *Order) rests() bool {
+	return o.Kind == Limit && o.TimeInForce == GoodTillCancel
+}
+
+// limitPrice returns the price of o, a market sell without a worst price is priced at zero.
+func (o *Order) limitPrice() *big.Int {
+	if o.Price == nil {
+		return Zero
+	}
+	return o.Price
+}
+
+// crosses reports whether taker o can trade against the resting maker.
+func (o *Order) crosses(maker *Order) bool {
+	if o.Type == Buy {
+		return maker.Price.Cmp(o.Price) <= 0
+	}
+	return maker.Price.Cmp(o.limitPrice()) >= 0
+}
+
+// before reports whether o has priority over other in the book of its side.
+func (o *Order) before(other *Order) bool {
+	if cmp := o.limitPrice().Cmp(other.limitPrice()); cmp != 0 {
+		if o.Type == Buy {
+			return cmp > 0
+		}
+		return cmp < 0
+	}
+	return o.seq < other.seq
+}
+
 type OrderPair struct {
 	OrderToken   udt.TokenID `json:"order_token"`
 	PricingToken udt.TokenID `json:"pricing_token"`
@@ -56,6 +180,41 @@ func (o Orders) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
 
+// insert puts order into a book kept in priority order, best first.
+func (o Orders) insert(order *Order) Orders {
+	i := sort.Search(len(o), func(i int) bool {
+		return order.before(o[i])
+	})
+	o = append(o, nil)
+	copy(o[i+1:], o[i:])
+	o[i] = order
+	return o
+}
+
+func (o Orders) remove(id string) Orders {
+	for i, order := range o {
+		if order.id == id {
+			return append(o[:i], o[i+1:]...)
+		}
+	}
+	return o
+}
+
+// fillable returns how much of amount the book can fill for taker.
+func (o Orders) fillable(taker *Order, amount *big.Int) *big.Int {
+	total := big.NewInt(0)
+	for _, maker := range o {
+		if !taker.crosses(maker) || total.Cmp(amount) >= 0 {
+			break
+		}
+		total.Add(total, maker.remaining)
+	}
+	if total.Cmp(amount) > 0 {
+		return new(big.Int).Set(amount)
+	}
+	return total
+}
+
 type OrderType uint8
 
 const (
@@ -63,28 +222,87 @@ const (
 	Sell
 )
 
-type MatchedOrders struct {
-	Buys  []*Order `json:"buy"`
-	Sells []*Order `json:"sell"`
-}
-
-func MatchOrders(buys, sells []*Order) (matched []*MatchedOrders) {
-	// TODO: Optimize the matching algorithm
-	for _, buy := range buys {
-		for _, sell := range sells {
-			if buy.PricingToken != sell.PricingToken {
-				continue
-			}
-			if buy.OrderToken != sell.OrderToken {
-				continue
-			}
-			if buy.Price.Cmp(sell.Price) < 0 {
-				continue
-			}
+type OrderKind uint8
 
-		}
+const (
+	Limit OrderKind = iota
+	// Market orders take liquidity at any price up to their worst price and never rest in the book.
+	Market
+)
+
+type TimeInForce uint8
+
+const (
+	GoodTillCancel TimeInForce = iota
+	// ImmediateOrCancel orders fill what they can in their block and cancel the rest.
+	ImmediateOrCancel
+	// FillOrKill orders fill completely in their block or are canceled.
+	FillOrKill
+)
+
+type MatchMode uint8
+
+const (
+	// Continuous matches every new order against the book in arrival order.
+	Continuous MatchMode = iota
+	// BatchAuction clears all crossing orders of a block at a single price, see clearingPrice.
+	BatchAuction
+)
+
+type ConditionType uint8
+
+const (
+	// StopLoss triggers a sell when the price falls to the trigger price, or a buy when it rises to it.
+	StopLoss ConditionType = iota
+	// TakeProfit triggers a sell when the price rises to the trigger price, or a buy when it falls to it.
+	TakeProfit
+)
+
+type Condition struct {
+	Type         ConditionType `json:"type"`
+	TriggerPrice *big.Int      `json:"trigger_price"`
+}
+
+func (c *Condition) Validate() error {
+	if c.Type != StopLoss && c.Type != TakeProfit {
+		return errors.New("unknown condition type")
+	}
+	if c.TriggerPrice == nil || c.TriggerPrice.Sign() <= 0 {
+		return errors.New("trigger price must be positive")
+	}
+	return nil
+}
+
+// triggers reports whether lastPrice activates the conditional order o.
+func (o *Order) triggers(lastPrice *big.Int) bool {
+	if lastPrice == nil {
+		return false
+	}
+	cmp := lastPrice.Cmp(o.Condition.TriggerPrice)
+	// a stop-loss sell and a take-profit buy wait for the price to fall.
+	if (o.Condition.Type == StopLoss) == (o.Type == Sell) {
+		return cmp <= 0
+	}
+	return cmp >= 0
+}
+
+// OrderStatus is the view of an order returned by readings.
+type OrderStatus struct {
+	ID        string   `json:"id"`
+	Order     *Order   `json:"order"`
+	Remaining *big.Int `json:"remaining"`
+	Escrow    *big.Int `json:"escrow"`
+	Dormant   bool     `json:"dormant,omitempty"`
+}
+
+func (o *Order) Status() *OrderStatus {
+	return &OrderStatus{
+		ID:        o.id,
+		Order:     o,
+		Remaining: o.Remaining(),
+		Escrow:    new(big.Int).Set(o.escrow),
+		Dormant:   o.dormant(),
 	}
-	return
 }
 
 type OrderScript struct {
@@ -0,0 +1,40 @@
+package orderbook
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTriggers(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       OrderType
+		condition ConditionType
+		lastPrice int64
+		want      bool
+	}{
+		{name: "stop-loss sell above", typ: Sell, condition: StopLoss, lastPrice: 101},
+		{name: "stop-loss sell at", typ: Sell, condition: StopLoss, lastPrice: 100, want: true},
+		{name: "stop-loss sell below", typ: Sell, condition: StopLoss, lastPrice: 99, want: true},
+		{name: "stop-loss buy below", typ: Buy, condition: StopLoss, lastPrice: 99},
+		{name: "stop-loss buy above", typ: Buy, condition: StopLoss, lastPrice: 101, want: true},
+		{name: "take-profit sell below", typ: Sell, condition: TakeProfit, lastPrice: 99},
+		{name: "take-profit sell above", typ: Sell, condition: TakeProfit, lastPrice: 101, want: true},
+		{name: "take-profit buy above", typ: Buy, condition: TakeProfit, lastPrice: 101},
+		{name: "take-profit buy at", typ: Buy, condition: TakeProfit, lastPrice: 100, want: true},
+		{name: "never traded", typ: Sell, condition: StopLoss},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := limit(tt.typ, 1, 100, "alice")
+			order.Condition = &Condition{Type: tt.condition, TriggerPrice: big.NewInt(100)}
+			var lastPrice *big.Int
+			if tt.lastPrice != 0 {
+				lastPrice = big.NewInt(tt.lastPrice)
+			}
+			if got := order.triggers(lastPrice); got != tt.want {
+				t.Fatalf("triggers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package orderbook
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+var (
+	// pairsKey keeps every pair that has had an order in its books, sorted.
+	pairsKey = []byte("pairs")
+	// incomingKey keeps the ids of the orders added in the current block in arrival order, they are matched in EndBlock.
+	incomingKey = []byte("incoming")
+	seqKey      = []byte("seq")
+)
+
+// book is the key prefix of one side of the books of a pair, which keeps the ids of its orders in priority order.
+type book string
+
+const (
+	buyBook  book = "buy_orders/"
+	sellBook book = "sell_orders/"
+	// conditionalBook keeps the dormant conditional orders in placement order.
+	conditionalBook book = "conditional_orders/"
+)
+
+// bookOf returns the book an open order rests in.
+func bookOf(order *Order) book {
+	if order.dormant() {
+		return conditionalBook
+	}
+	if order.Type == Buy {
+		return buyBook
+	}
+	return sellBook
+}
+
+// pairKey encodes the pair as json, so that no two pairs share a key whatever their token ids contain.
+func pairKey(prefix string, pair OrderPair) []byte {
+	byt, _ := json.Marshal(pair)
+	return append([]byte(prefix), byt...)
+}
+
+func orderKey(id string) []byte {
+	return []byte("order/" + id)
+}
+
+// orderRecord is an open order as kept in the state, with the fields updated by the matching.
+type orderRecord struct {
+	Order     *Order   `json:"order"`
+	Seq       uint64   `json:"seq"`
+	Remaining *big.Int `json:"remaining"`
+	Escrow    *big.Int `json:"escrow"`
+	Triggered bool     `json:"triggered,omitempty"`
+}
+
+// getOrder loads an open order, it returns ErrOrderNotFound once the order is closed.
+func (ob *Orderbook) getOrder(id string) (*Order, error) {
+	record := new(orderRecord)
+	ok, err := ob.getJson(orderKey(id), record)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	order := record.Order
+	order.id = id
+	order.seq = record.Seq
+	order.remaining = record.Remaining
+	order.escrow = record.Escrow
+	order.triggered = record.Triggered
+	return order, nil
+}
+
+func (ob *Orderbook) setOrder(order *Order) error {
+	return ob.setJson(orderKey(order.id), &orderRecord{
+		Order:     order,
+		Seq:       order.seq,
+		Remaining: order.remaining,
+		Escrow:    order.escrow,
+		Triggered: order.triggered,
+	})
+}
+
+// getBook loads the orders of one book of pair in priority order.
+func (ob *Orderbook) getBook(b book, pair OrderPair) (Orders, error) {
+	var ids []string
+	if _, err := ob.getJson(pairKey(string(b), pair), &ids); err != nil {
+		return nil, err
+	}
+	return ob.getOrders(ids)
+}
+
+// setBook stores the ids of the orders of one book of pair, an empty book is deleted.
+func (ob *Orderbook) setBook(b book, pair OrderPair, orders Orders) error {
+	key := pairKey(string(b), pair)
+	if len(orders) == 0 {
+		ob.Delete(key)
+		return nil
+	}
+	if err := ob.addPair(pair); err != nil {
+		return err
+	}
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.id
+	}
+	return ob.setJson(key, ids)
+}
+
+func (ob *Orderbook) getOrders(ids []string) (Orders, error) {
+	orders := make(Orders, 0, len(ids))
+	for _, id := range ids {
+		order, err := ob.getOrder(id)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (ob *Orderbook) getPairs() ([]OrderPair, error) {
+	var pairs []OrderPair
+	_, err := ob.getJson(pairsKey, &pairs)
+	return pairs, err
+}
+
+// addPair records pair in the sorted list of pairs, so that the books are walked in a deterministic order.
+func (ob *Orderbook) addPair(pair OrderPair) error {
+	pairs, err := ob.getPairs()
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(pairs), func(i int) bool {
+		return !pairLess(pairs[i], pair)
+	})
+	if i < len(pairs) && pairs[i] == pair {
+		return nil
+	}
+	pairs = append(pairs, OrderPair{})
+	copy(pairs[i+1:], pairs[i:])
+	pairs[i] = pair
+	return ob.setJson(pairsKey, pairs)
+}
+
+// getLastPrice returns the price of the last trade of pair, nil if it has not traded yet.
+func (ob *Orderbook) getLastPrice(pair OrderPair) (*big.Int, error) {
+	byt, err := ob.Get(pairKey("last_price/", pair))
+	if err != nil || byt == nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(byt), nil
+}
+
+func (ob *Orderbook) setLastPrice(pair OrderPair, price *big.Int) {
+	ob.Set(pairKey("last_price/", pair), price.Bytes())
+}
+
+func (ob *Orderbook) getIncoming() ([]string, error) {
+	var ids []string
+	_, err := ob.getJson(incomingKey, &ids)
+	return ids, err
+}
+
+// addIncoming queues order to be matched in EndBlock.
+func (ob *Orderbook) addIncoming(order *Order) error {
+	ids, err := ob.getIncoming()
+	if err != nil {
+		return err
+	}
+	return ob.setJson(incomingKey, append(ids, order.id))
+}
+
+// nextSeq returns the next sequence number of the time priority of the books.
+func (ob *Orderbook) nextSeq() (uint64, error) {
+	byt, err := ob.Get(seqKey)
+	if err != nil {
+		return 0, err
+	}
+	var seq uint64
+	if len(byt) == 8 {
+		seq = binary.BigEndian.Uint64(byt)
+	}
+	seq++
+	ob.Set(seqKey, binary.BigEndian.AppendUint64(nil, seq))
+	return seq, nil
+}
+
+// getJson unmarshals the value of key into v and reports whether the key exists.
+func (ob *Orderbook) getJson(key []byte, v any) (bool, error) {
+	byt, err := ob.Get(key)
+	if err != nil || byt == nil {
+		return false, err
+	}
+	return true, json.Unmarshal(byt, v)
+}
+
+func (ob *Orderbook) setJson(key []byte, v any) error {
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	ob.Set(key, byt)
+	return nil
+}
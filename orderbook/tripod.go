@@ -1,45 +1,94 @@
 package orderbook
 
 import (
-	_ "github.com/go-sql-driver/mysql"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yu-org/JingChou/account"
 	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
 	"github.com/yu-org/yu/core/context"
 	"github.com/yu-org/yu/core/tripod"
 	"github.com/yu-org/yu/core/types"
-	"sort"
 )
 
 type Orderbook struct {
 	*tripod.Tripod
 
-	BuyOrders  map[OrderPair]Orders
-	SellOrders map[OrderPair]Orders
+	Account *account.AccountTripod `tripod:"account"`
+
+	cfg *Config
+
+	height common.BlockNum
+
+	indexer   Indexer
+	events    *BlockEvents
+	liquidity Liquidity
 }
 
 func (ob *Orderbook) StartBlock(block *types.Block) {}
 
+// EndBlock matches the orders added in the block. Its writes are sealed in a stash of their own,
+// so that they are not discarded with the first txn of the next block.
 func (ob *Orderbook) EndBlock(block *types.Block) {
-	matched := make([]*MatchedOrders, 0)
-	for pair, buys := range ob.BuyOrders {
-		if sells, ok := ob.SellOrders[pair]; ok {
-			matches := MatchOrders(buys, sells)
-			matched = append(matched, matches...)
+	ob.height = block.Height
+	ob.matchIncoming()
+	ob.removeExpired(block.Height)
+	ob.triggerConditional()
+	ob.flushEvents(block.Height, block.Timestamp)
+	ob.NextTxn()
+}
+
+func (ob *Orderbook) matchIncoming() {
+	ids, err := ob.getIncoming()
+	if err != nil {
+		logrus.Errorf("load incoming orders failed: %v", err)
+		return
+	}
+	ob.Delete(incomingKey)
+	auctions := make(map[OrderPair]Orders)
+	for _, id := range ids {
+		order, err := ob.getOrder(id)
+		if errors.Is(err, ErrOrderNotFound) {
+			// canceled in the same block
+			continue
+		}
+		if err != nil {
+			logrus.Errorf("load order(%s) failed: %v", id, err)
+			continue
+		}
+		pair := order.Pair()
+		if ob.matchMode(pair) == BatchAuction {
+			auctions[pair] = append(auctions[pair], order)
+			continue
+		}
+		if err := ob.execute(order); err != nil {
+			logrus.Errorf("execute order(%s) failed: %v", order.id, err)
+		}
+	}
+	for _, pair := range sortedPairs(auctions) {
+		if err := ob.auction(pair, auctions[pair]); err != nil {
+			logrus.Errorf("batch auction of pair(%s/%s) failed: %v", pair.OrderToken, pair.PricingToken, err)
 		}
 	}
-	// settle matched orders
-
 }
 
 func (ob *Orderbook) FinalizeBlock(block *types.Block) {}
 
-func NewOrderbook() *Orderbook {
+// NewOrderbook creates the orderbook tripod, a nil cfg trades without fees or governance.
+func NewOrderbook(cfg *Config) *Orderbook {
+	if cfg == nil {
+		cfg = new(Config)
+	}
 	ob := &Orderbook{
-		Tripod:     tripod.NewTripod(),
-		BuyOrders:  make(map[OrderPair]Orders),
-		SellOrders: make(map[OrderPair]Orders),
+		Tripod: tripod.NewTripod(),
+		cfg:    cfg,
+		events: new(BlockEvents),
 	}
-	ob.SetWritings(ob.AddOrder, ob.CancelOrder)
-	ob.SetReadings(ob.QueryOrder)
+	ob.SetWritings(ob.AddOrder, ob.RouteOrder, ob.CancelOrder, ob.SetFeeSchedule, ob.SetMatchMode)
+	ob.SetReadings(ob.QueryOrder, ob.QueryFeeSchedule, ob.QueryFees, ob.QueryMatchMode)
 	return ob
 }
 
@@ -55,16 +104,58 @@ func (ob *Orderbook) AddOrder(ctx *context.WriteContext) error {
 	if err := ctx.BindJson(req); err != nil {
 		return err
 	}
-	pair := req.Order.Pair()
-	switch req.Order.Type {
-	case Buy:
-		ob.BuyOrders[pair] = append(ob.BuyOrders[pair], req.Order)
-		sort.Sort(ob.BuyOrders[pair])
-	case Sell:
-		ob.SellOrders[pair] = append(ob.SellOrders[pair], req.Order)
-		sort.Sort(ob.SellOrders[pair])
+	order, err := ob.openOrder(req, ctx.Block.Height)
+	if err != nil {
+		return err
 	}
-	return nil
+	if order.Condition != nil {
+		dormant, err := ob.getBook(conditionalBook, order.Pair())
+		if err != nil {
+			return err
+		}
+		return ob.setBook(conditionalBook, order.Pair(), append(dormant, order))
+	}
+	return ob.addIncoming(order)
+}
+
+// openOrder validates the order of req and takes its escrow from the account.
+func (ob *Orderbook) openOrder(req *AddOrderRequest, height common.BlockNum) (*Order, error) {
+	order := req.Order
+	if order == nil {
+		return nil, errors.New("order is nil")
+	}
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+	if order.ExpireHeight != 0 && order.ExpireHeight < height {
+		return nil, errors.New("order already expired")
+	}
+	id, err := order.ID()
+	if err != nil {
+		return nil, err
+	}
+	if ob.Exist(orderKey(id)) {
+		return nil, errors.New("order already exists")
+	}
+	if err = ob.Account.VerifyOwner(order.Account, req.Args); err != nil {
+		return nil, err
+	}
+	escrow := order.EscrowAmount()
+	if err = ob.Account.SubBalance(order.Account, order.EscrowToken(), escrow); err != nil {
+		return nil, err
+	}
+
+	if order.seq, err = ob.nextSeq(); err != nil {
+		return nil, err
+	}
+	order.id = id
+	order.remaining = new(big.Int).Set(order.Amount)
+	order.escrow = escrow
+	if err = ob.setOrder(order); err != nil {
+		return nil, err
+	}
+	ob.emitOrderEvent(order, OrderAdded)
+	return order, nil
 }
 
 type CancelOrderRequest struct {
@@ -77,9 +168,401 @@ func (ob *Orderbook) CancelOrder(ctx *context.WriteContext) error {
 	if err := ctx.BindJson(req); err != nil {
 		return err
 	}
-	return nil
+	order, err := ob.getOrder(req.OrderID)
+	if err != nil {
+		return err
+	}
+	if err = ob.Account.VerifyOwner(order.Account, req.CancelArgs); err != nil {
+		return err
+	}
+	return ob.closeOrder(order, OrderCanceled)
 }
 
 func (ob *Orderbook) QueryOrder(ctx *context.ReadContext) {
+	id := ctx.GetString("order_id")
+	order, err := ob.getOrder(id)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(order.Status())
+}
+
+type SetFeeScheduleRequest struct {
+	Pair         OrderPair    `json:"pair"`
+	Schedule     *FeeSchedule `json:"schedule"`
+	GovernorArgs []byte       `json:"governor_args"`
+}
+
+// SetFeeSchedule updates the fee schedule of a pair, only the governor can call it.
+func (ob *Orderbook) SetFeeSchedule(ctx *context.WriteContext) error {
+	req := new(SetFeeScheduleRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if ob.cfg.Governor == "" || ob.cfg.Treasury == "" {
+		return errors.New("fee governance is not configured")
+	}
+	if err := ob.Account.VerifyOwner(ob.cfg.Governor, req.GovernorArgs); err != nil {
+		return err
+	}
+	if req.Schedule == nil {
+		ob.Delete(feeScheduleKey(req.Pair))
+		return nil
+	}
+	if err := req.Schedule.Validate(); err != nil {
+		return err
+	}
+	return ob.setJson(feeScheduleKey(req.Pair), req.Schedule)
+}
+
+type SetMatchModeRequest struct {
+	Pair         OrderPair `json:"pair"`
+	Mode         MatchMode `json:"mode"`
+	GovernorArgs []byte    `json:"governor_args"`
+}
+
+// SetMatchMode switches how the orders of a pair are matched from the next EndBlock on, only the governor can call it.
+// The mode is kept in the state, pairs without one match continuously.
+func (ob *Orderbook) SetMatchMode(ctx *context.WriteContext) error {
+	req := new(SetMatchModeRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if ob.cfg.Governor == "" {
+		return errors.New("governance is not configured")
+	}
+	if err := ob.Account.VerifyOwner(ob.cfg.Governor, req.GovernorArgs); err != nil {
+		return err
+	}
+	switch req.Mode {
+	case Continuous:
+		ob.Delete(matchModeKey(req.Pair))
+	case BatchAuction:
+		ob.Set(matchModeKey(req.Pair), []byte{byte(req.Mode)})
+	default:
+		return errors.New("unknown match mode")
+	}
+	return nil
+}
+
+func (ob *Orderbook) QueryMatchMode(ctx *context.ReadContext) {
+	pair := new(OrderPair)
+	if err := ctx.BindJson(pair); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(ob.matchMode(*pair))
+}
+
+// matchMode returns the match mode of pair set by SetMatchMode.
+func (ob *Orderbook) matchMode(pair OrderPair) MatchMode {
+	byt, err := ob.Get(matchModeKey(pair))
+	if err != nil || len(byt) == 0 {
+		return Continuous
+	}
+	return MatchMode(byt[0])
+}
+
+func matchModeKey(pair OrderPair) []byte {
+	return pairKey("match_mode/", pair)
+}
+
+func (ob *Orderbook) QueryFeeSchedule(ctx *context.ReadContext) {
+	pair := new(OrderPair)
+	if err := ctx.BindJson(pair); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	schedule, err := ob.getFeeSchedule(*pair)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(schedule)
+}
+
+type QueryFeesRequest struct {
+	Pair OrderPair `json:"pair"`
+	// Period selects one fee period, all periods are returned if it is nil.
+	Period *uint64 `json:"period,omitempty"`
+}
+
+// QueryFees returns the fees collected and rebated on a pair, by fee period.
+func (ob *Orderbook) QueryFees(ctx *context.ReadContext) {
+	req := new(QueryFeesRequest)
+	if err := ctx.BindJson(req); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	periods, err := ob.getFeePeriods(req.Pair)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	stats := make([]*FeeStats, 0, len(periods))
+	for _, period := range periods {
+		if req.Period != nil && *req.Period != period {
+			continue
+		}
+		periodStats, err := ob.getFeeStats(req.Pair, period)
+		if err != nil {
+			ctx.ErrOk(err)
+			return
+		}
+		stats = append(stats, periodStats)
+	}
+	ctx.JsonOk(stats)
+}
+
+// execute matches a new order against the opposite side of its book,
+// makers trade at their own price in price-time priority.
+// Before each maker the taker fills from the outside liquidity while its price is better, see Liquidity.
+func (ob *Orderbook) execute(taker *Order) error {
+	pair := taker.Pair()
+	side := sellBook
+	if taker.Type == Sell {
+		side = buyBook
+	}
+	book, err := ob.getBook(side, pair)
+	if err != nil {
+		return err
+	}
+
+	if taker.PostOnly && (len(book) > 0 && taker.crosses(book[0]) || ob.liquidityDepth(taker, taker.Price).Sign() > 0) {
+		return ob.closeOrder(taker, OrderKilled)
+	}
+	if taker.TimeInForce == FillOrKill {
+		// the liquidity fills up to the limit price whatever the book fills, so the depths add up.
+		fillable := book.fillable(taker, taker.remaining)
+		fillable.Add(fillable, ob.liquidityDepth(taker, taker.limitPrice()))
+		if fillable.Cmp(taker.remaining) < 0 {
+			return ob.closeOrder(taker, OrderKilled)
+		}
+	}
+
+	book, err = ob.take(taker, book)
+	if setErr := ob.setBook(side, pair, book); err == nil {
+		err = setErr
+	}
+	if err != nil {
+		return err
+	}
 
+	switch {
+	case taker.remaining.Sign() == 0:
+		return ob.release(taker, OrderFilled)
+	case !taker.rests():
+		return ob.closeOrder(taker, OrderKilled)
+	}
+	book, err = ob.getBook(bookOf(taker), pair)
+	if err != nil {
+		return err
+	}
+	return ob.setBook(bookOf(taker), pair, book.insert(taker))
+}
+
+// take fills taker from the makers of book and the outside liquidity, and returns the makers left.
+func (ob *Orderbook) take(taker *Order, book Orders) (Orders, error) {
+	useLiquidity := ob.liquidity != nil
+	for taker.remaining.Sign() > 0 {
+		crosses := len(book) > 0 && taker.crosses(book[0])
+		if useLiquidity {
+			price := taker.limitPrice()
+			if crosses {
+				price = book[0].Price
+			}
+			var err error
+			if useLiquidity, err = ob.takeLiquidity(taker, price); err != nil {
+				return book, err
+			}
+		}
+		if taker.remaining.Sign() == 0 || !crosses {
+			break
+		}
+		maker := book[0]
+		amount := taker.remaining
+		if maker.remaining.Cmp(amount) < 0 {
+			amount = maker.remaining
+		}
+		if err := ob.settle(maker, taker, new(big.Int).Set(amount), maker.Price); err != nil {
+			return book, err
+		}
+		if maker.remaining.Sign() == 0 {
+			book = book[1:]
+			if err := ob.release(maker, OrderFilled); err != nil {
+				return book, err
+			}
+		}
+	}
+	return book, nil
+}
+
+// settle trades amount of OrderToken between maker and taker at price,
+// each side pays its fee in the token it receives.
+func (ob *Orderbook) settle(maker, taker *Order, amount, price *big.Int) error {
+	buyer, seller := maker, taker
+	if taker.Type == Buy {
+		buyer, seller = taker, maker
+	}
+	pair := buyer.Pair()
+	quote := new(big.Int).Mul(amount, price)
+
+	schedule, err := ob.getFeeSchedule(pair)
+	if err != nil {
+		return err
+	}
+	makerBps := schedule.MakerBps
+	if ob.matchMode(pair) == BatchAuction {
+		makerBps = schedule.TakerBps
+	}
+	buyerBps, sellerBps := makerBps, schedule.TakerBps
+	if taker.Type == Buy {
+		buyerBps, sellerBps = schedule.TakerBps, makerBps
+	}
+
+	if err := ob.chargeFee(buyer, buyer.OrderToken, amount, buyerBps); err != nil {
+		return err
+	}
+	if err := ob.chargeFee(seller, seller.PricingToken, quote, sellerBps); err != nil {
+		return err
+	}
+	ob.setLastPrice(pair, price)
+	ob.emitFill(maker, taker, amount, price)
+	if taker.execution != nil {
+		taker.execution.add(false, amount, quote)
+	}
+	buyer.escrow.Sub(buyer.escrow, quote)
+	seller.escrow.Sub(seller.escrow, amount)
+	buyer.remaining.Sub(buyer.remaining, amount)
+	seller.remaining.Sub(seller.remaining, amount)
+	if err := ob.setOrder(buyer); err != nil {
+		return err
+	}
+	return ob.setOrder(seller)
+}
+
+// closeOrder removes an open order from the book and refunds its escrow.
+func (ob *Orderbook) closeOrder(order *Order, reason OrderEventType) error {
+	pair := order.Pair()
+	book, err := ob.getBook(bookOf(order), pair)
+	if err != nil {
+		return err
+	}
+	if err = ob.setBook(bookOf(order), pair, book.remove(order.id)); err != nil {
+		return err
+	}
+	return ob.release(order, reason)
+}
+
+// release deletes order from the state and returns the escrow left, e.g. from buys filled below their limit price.
+func (ob *Orderbook) release(order *Order, reason OrderEventType) error {
+	ob.Delete(orderKey(order.id))
+	ob.emitOrderEvent(order, reason)
+	if order.escrow.Sign() == 0 {
+		return nil
+	}
+	refund := order.escrow
+	order.escrow = big.NewInt(0)
+	return ob.Account.AddBalance(order.Account, order.EscrowToken(), refund)
+}
+
+func (ob *Orderbook) removeExpired(height common.BlockNum) {
+	pairs, err := ob.getPairs()
+	if err != nil {
+		logrus.Errorf("load pairs failed: %v", err)
+		return
+	}
+	for _, pair := range pairs {
+		for _, side := range []book{buyBook, sellBook, conditionalBook} {
+			orders, err := ob.getBook(side, pair)
+			if err != nil {
+				logrus.Errorf("load book of pair(%s/%s) failed: %v", pair.OrderToken, pair.PricingToken, err)
+				continue
+			}
+			ob.closeExpired(orders, height)
+		}
+	}
+}
+
+func (ob *Orderbook) closeExpired(orders Orders, height common.BlockNum) {
+	for _, order := range orders {
+		if order.ExpireHeight == 0 || order.ExpireHeight > height {
+			continue
+		}
+		if err := ob.closeOrder(order, OrderExpired); err != nil {
+			logrus.Errorf("remove expired order(%s) failed: %v", order.id, err)
+		}
+	}
+}
+
+// triggerConditional activates the conditional orders reached by the last traded price,
+// they are matched in the next block as new orders.
+func (ob *Orderbook) triggerConditional() {
+	pairs, err := ob.getPairs()
+	if err != nil {
+		logrus.Errorf("load pairs failed: %v", err)
+		return
+	}
+	for _, pair := range pairs {
+		if err = ob.triggerPair(pair); err != nil {
+			logrus.Errorf("trigger orders of pair(%s/%s) failed: %v", pair.OrderToken, pair.PricingToken, err)
+		}
+	}
+}
+
+func (ob *Orderbook) triggerPair(pair OrderPair) error {
+	orders, err := ob.getBook(conditionalBook, pair)
+	if err != nil || len(orders) == 0 {
+		return err
+	}
+	lastPrice, err := ob.getLastPrice(pair)
+	if err != nil {
+		return err
+	}
+	dormant := make(Orders, 0, len(orders))
+	for _, order := range orders {
+		if !order.triggers(lastPrice) {
+			dormant = append(dormant, order)
+			continue
+		}
+		if err = ob.trigger(order); err != nil {
+			return err
+		}
+	}
+	return ob.setBook(conditionalBook, pair, dormant)
+}
+
+// trigger queues a conditional order to be matched as a new order.
+func (ob *Orderbook) trigger(order *Order) error {
+	seq, err := ob.nextSeq()
+	if err != nil {
+		return err
+	}
+	order.seq = seq
+	order.triggered = true
+	if err = ob.setOrder(order); err != nil {
+		return err
+	}
+	ob.emitOrderEvent(order, OrderTriggered)
+	return ob.addIncoming(order)
+}
+
+// sortedPairs returns the pairs of books in a deterministic order.
+func sortedPairs(books map[OrderPair]Orders) []OrderPair {
+	pairs := make([]OrderPair, 0, len(books))
+	for pair := range books {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairLess(pairs[i], pairs[j])
+	})
+	return pairs
+}
+
+func pairLess(a, b OrderPair) bool {
+	if a.OrderToken != b.OrderToken {
+		return a.OrderToken < b.OrderToken
+	}
+	return a.PricingToken < b.PricingToken
 }
@@ -0,0 +1,377 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+	"github.com/yu-org/yu/core/types"
+)
+
+var btcUsd = OrderPair{OrderToken: "BTC", PricingToken: "USD"}
+
+type testBook struct {
+	*Orderbook
+	state   *memstate.State
+	chain   *env.ChainEnv
+	account *account.AccountTripod
+}
+
+func newTestBook(t *testing.T, cfg *Config) *testBook {
+	state := memstate.New()
+	chainEnv := &env.ChainEnv{State: state}
+	acc := account.NewAccountTripod()
+	acc.SetChainEnv(chainEnv)
+	tb := &testBook{state: state, chain: chainEnv, account: acc}
+	tb.restart(cfg)
+	return tb
+}
+
+// restart replaces the orderbook by a new one on the same state, as a node does when it restarts.
+func (tb *testBook) restart(cfg *Config) {
+	ob := NewOrderbook(cfg)
+	ob.SetChainEnv(tb.chain)
+	ob.Account = tb.account
+	tb.Orderbook = ob
+}
+
+func (tb *testBook) fund(t *testing.T, id string, token udt.TokenID, amount int64) {
+	mustOk(t, tb.account.AddBalance(id, token, big.NewInt(amount)))
+	tb.state.NextTxn()
+}
+
+// add runs AddOrder as a txn of the block at height.
+func (tb *testBook) add(t *testing.T, height common.BlockNum, order *Order) error {
+	return tb.state.Execute(func() error {
+		return tb.AddOrder(writeCtx(t, height, &AddOrderRequest{Order: order}))
+	})
+}
+
+func (tb *testBook) endBlock(height common.BlockNum) {
+	tb.EndBlock(newBlock(height))
+}
+
+func (tb *testBook) balance(t *testing.T, id string, token udt.TokenID) int64 {
+	balance, err := tb.account.GetBalance(id, token)
+	mustOk(t, err)
+	return balance.Int64()
+}
+
+func (tb *testBook) book(t *testing.T, side book) Orders {
+	orders, err := tb.getBook(side, btcUsd)
+	mustOk(t, err)
+	return orders
+}
+
+// recordingIndexer keeps the events of every block indexed.
+type recordingIndexer struct {
+	blocks []*BlockEvents
+}
+
+func (ri *recordingIndexer) IndexBlock(events *BlockEvents) {
+	ri.blocks = append(ri.blocks, events)
+}
+
+func limit(typ OrderType, amount, price int64, account string) *Order {
+	return &Order{
+		Type:         typ,
+		OrderToken:   "BTC",
+		PricingToken: "USD",
+		Amount:       big.NewInt(amount),
+		Price:        big.NewInt(price),
+		Account:      account,
+	}
+}
+
+func TestBookSurvivesRestart(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 10)
+	mustOk(t, tb.add(t, 1, limit(Sell, 10, 50, "bob")))
+	tb.endBlock(1)
+	_, err := tb.state.Commit()
+	mustOk(t, err)
+
+	tb.restart(nil)
+	if sells := tb.book(t, sellBook); len(sells) != 1 || sells[0].remaining.Int64() != 10 || sells[0].escrow.Int64() != 10 {
+		t.Fatalf("sell book after the restart: %v", sells)
+	}
+	mustOk(t, tb.add(t, 2, limit(Buy, 4, 50, "alice")))
+	tb.endBlock(2)
+	if got := tb.balance(t, "alice", "BTC"); got != 4 {
+		t.Fatalf("alice has %d BTC, want 4", got)
+	}
+	if got := tb.balance(t, "bob", "USD"); got != 200 {
+		t.Fatalf("bob has %d USD, want 200", got)
+	}
+	if sells := tb.book(t, sellBook); len(sells) != 1 || sells[0].remaining.Int64() != 6 || sells[0].escrow.Int64() != 6 {
+		t.Fatalf("sell book after the fill: %v", sells)
+	}
+}
+
+func TestEndBlockWritesSurviveDiscard(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 10)
+	mustOk(t, tb.add(t, 1, limit(Sell, 10, 50, "bob")))
+	mustOk(t, tb.add(t, 1, limit(Buy, 10, 50, "alice")))
+	tb.endBlock(1)
+
+	// the first txn of the next block fails
+	err := tb.state.Execute(func() error {
+		return tb.CancelOrder(writeCtx(t, 2, &CancelOrderRequest{OrderID: "unknown"}))
+	})
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("cancel an unknown order: %v", err)
+	}
+	if got := tb.balance(t, "alice", "BTC"); got != 10 {
+		t.Fatalf("alice has %d BTC after the discard, want 10", got)
+	}
+	if got := tb.balance(t, "bob", "USD"); got != 500 {
+		t.Fatalf("bob has %d USD after the discard, want 500", got)
+	}
+	if len(tb.book(t, buyBook)) != 0 || len(tb.book(t, sellBook)) != 0 {
+		t.Fatal("filled orders are back in the book after the discard")
+	}
+}
+
+func TestDiscardedOrderIsNotMatched(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 10)
+	mustOk(t, tb.add(t, 1, limit(Sell, 10, 50, "bob")))
+	failed := errors.New("failed after the order was added")
+	err := tb.state.Execute(func() error {
+		mustOk(t, tb.AddOrder(writeCtx(t, 1, &AddOrderRequest{Order: limit(Buy, 10, 50, "alice")})))
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatal(err)
+	}
+	tb.endBlock(1)
+	if got := tb.balance(t, "alice", "USD"); got != 1_000 {
+		t.Fatalf("alice has %d USD, want the escrow back", got)
+	}
+	if got := tb.balance(t, "alice", "BTC"); got != 0 {
+		t.Fatalf("the discarded order of alice bought %d BTC", got)
+	}
+	if sells := tb.book(t, sellBook); len(sells) != 1 || sells[0].remaining.Int64() != 10 {
+		t.Fatalf("sell book: %v", sells)
+	}
+}
+
+func TestFeesSurviveRestart(t *testing.T) {
+	cfg := &Config{Governor: "gov", Treasury: "treasury", FeePeriod: 10}
+	tb := newTestBook(t, cfg)
+	tb.fund(t, "gov", "USD", 0)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 100)
+	mustOk(t, tb.state.Execute(func() error {
+		return tb.SetFeeSchedule(writeCtx(t, 1, &SetFeeScheduleRequest{Pair: btcUsd, Schedule: &FeeSchedule{TakerBps: 100}}))
+	}))
+	mustOk(t, tb.add(t, 1, limit(Sell, 100, 10, "bob")))
+	tb.endBlock(1)
+	_, err := tb.state.Commit()
+	mustOk(t, err)
+
+	tb.restart(cfg)
+	mustOk(t, tb.add(t, 12, limit(Buy, 100, 10, "alice")))
+	tb.endBlock(12)
+	// the buyer takes and pays 1% of the 100 BTC it receives
+	if got := tb.balance(t, "alice", "BTC"); got != 99 {
+		t.Fatalf("alice has %d BTC, want 99", got)
+	}
+
+	tb.restart(cfg)
+	var stats []*FeeStats
+	readJson(t, tb.QueryFees, &QueryFeesRequest{Pair: btcUsd}, &stats)
+	if len(stats) != 1 || stats[0].Period != 1 || stats[0].Collected["BTC"].Int64() != 1 {
+		t.Fatalf("fees after the restart: %s", mustJson(t, stats))
+	}
+	schedule := new(FeeSchedule)
+	readJson(t, tb.QueryFeeSchedule, btcUsd, schedule)
+	if schedule.TakerBps != 100 {
+		t.Fatalf("fee schedule after the restart: %+v", schedule)
+	}
+}
+
+func newBlock(height common.BlockNum) *types.Block {
+	return &types.Block{Header: &types.Header{Height: height, Timestamp: uint64(height)}}
+}
+
+func writeCtx(t *testing.T, height common.BlockNum, req any) *context.WriteContext {
+	params, err := context.NewParamsResponseFromStr(string(mustJson(t, req)))
+	mustOk(t, err)
+	return &context.WriteContext{ParamsResponse: params, Block: newBlock(height)}
+}
+
+// readJson calls a reading with req and unmarshals its json response into resp.
+func readJson(t *testing.T, reading func(*context.ReadContext), req, resp any) {
+	ctx, err := context.NewReadContext(&common.RdCall{Params: string(mustJson(t, req))})
+	mustOk(t, err)
+	reading(ctx)
+	mustOk(t, json.Unmarshal(mustJson(t, ctx.Response().DataInterface), resp))
+}
+
+func mustJson(t *testing.T, v any) []byte {
+	byt, err := json.Marshal(v)
+	mustOk(t, err)
+	return byt
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConditionalOrderSurvivesRestart(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 10)
+	tb.fund(t, "carol", "BTC", 5)
+	stop := limit(Sell, 5, 40, "carol")
+	stop.Condition = &Condition{Type: StopLoss, TriggerPrice: big.NewInt(44)}
+	mustOk(t, tb.add(t, 1, stop))
+	mustOk(t, tb.add(t, 1, limit(Sell, 2, 45, "bob")))
+	mustOk(t, tb.add(t, 1, limit(Buy, 2, 45, "alice")))
+	tb.endBlock(1)
+	_, err := tb.state.Commit()
+	mustOk(t, err)
+
+	tb.restart(nil)
+	lastPrice, err := tb.getLastPrice(btcUsd)
+	mustOk(t, err)
+	if lastPrice == nil || lastPrice.Int64() != 45 {
+		t.Fatalf("last price after the restart: %v", lastPrice)
+	}
+	if dormant := tb.book(t, conditionalBook); len(dormant) != 1 || !dormant[0].dormant() {
+		t.Fatalf("conditional book after the restart: %v", dormant)
+	}
+	mustOk(t, tb.add(t, 2, limit(Sell, 2, 44, "bob")))
+	mustOk(t, tb.add(t, 2, limit(Buy, 7, 44, "alice")))
+	tb.endBlock(2)
+	if len(tb.book(t, conditionalBook)) != 0 {
+		t.Fatal("stop order still dormant after the price fell to its trigger")
+	}
+	// the stop sells into the rest of the bid of alice at 44
+	tb.endBlock(3)
+	if got := tb.balance(t, "carol", "USD"); got != 220 {
+		t.Fatalf("carol has %d USD, want 220 from the triggered stop", got)
+	}
+}
+
+func TestExecutionFlags(t *testing.T) {
+	market := func(amount, price int64) *Order {
+		order := limit(Buy, amount, price, "alice")
+		order.Kind = Market
+		return order
+	}
+	postOnly := func(order *Order) *Order {
+		order.PostOnly = true
+		return order
+	}
+	tests := []struct {
+		name  string
+		order *Order
+		// the balances of alice, and what is left in the books, once the order met a resting sell of 5 BTC at 50
+		wantBTC, wantUSD  int64
+		wantSell, wantBuy int64
+	}{
+		{name: "good till cancel rests", order: limit(Buy, 8, 50, "alice"), wantBTC: 5, wantUSD: 600, wantBuy: 3},
+		{name: "immediate or cancel", order: withTimeInForce(limit(Buy, 8, 50, "alice"), ImmediateOrCancel), wantBTC: 5, wantUSD: 750},
+		{name: "fill or kill filled", order: withTimeInForce(limit(Buy, 5, 50, "alice"), FillOrKill), wantBTC: 5, wantUSD: 750},
+		{name: "fill or kill killed", order: withTimeInForce(limit(Buy, 8, 50, "alice"), FillOrKill), wantUSD: 1_000, wantSell: 5},
+		{name: "post-only crossing", order: postOnly(limit(Buy, 5, 50, "alice")), wantUSD: 1_000, wantSell: 5},
+		{name: "post-only resting", order: postOnly(limit(Buy, 5, 40, "alice")), wantUSD: 800, wantSell: 5, wantBuy: 5},
+		{name: "market below the worst price", order: market(8, 60), wantBTC: 5, wantUSD: 750},
+		{name: "market above the worst price", order: market(8, 45), wantUSD: 1_000, wantSell: 5},
+	}
+	remaining := func(orders Orders) int64 {
+		var total int64
+		for _, order := range orders {
+			total += order.remaining.Int64()
+		}
+		return total
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestBook(t, nil)
+			tb.fund(t, "alice", "USD", 1_000)
+			tb.fund(t, "bob", "BTC", 5)
+			mustOk(t, tb.add(t, 1, limit(Sell, 5, 50, "bob")))
+			tb.endBlock(1)
+			mustOk(t, tb.add(t, 2, tt.order))
+			tb.endBlock(2)
+
+			if btc, usd := tb.balance(t, "alice", "BTC"), tb.balance(t, "alice", "USD"); btc != tt.wantBTC || usd != tt.wantUSD {
+				t.Fatalf("alice has %d BTC and %d USD, want %d and %d", btc, usd, tt.wantBTC, tt.wantUSD)
+			}
+			if sell, buy := remaining(tb.book(t, sellBook)), remaining(tb.book(t, buyBook)); sell != tt.wantSell || buy != tt.wantBuy {
+				t.Fatalf("books hold %d BTC to sell and %d to buy, want %d and %d", sell, buy, tt.wantSell, tt.wantBuy)
+			}
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	expiring := limit(Buy, 5, 40, "alice")
+	expiring.ExpireHeight = 3
+	mustOk(t, tb.add(t, 1, expiring))
+	tb.endBlock(1)
+	tb.endBlock(2)
+	if buys := tb.book(t, buyBook); len(buys) != 1 {
+		t.Fatalf("order removed before its expire height: %v", buys)
+	}
+	tb.endBlock(3)
+	if buys := tb.book(t, buyBook); len(buys) != 0 {
+		t.Fatalf("order still in the book at its expire height: %v", buys)
+	}
+	if got := tb.balance(t, "alice", "USD"); got != 1_000 {
+		t.Fatalf("alice has %d USD, want the escrow of the expired order back", got)
+	}
+
+	late := limit(Buy, 5, 40, "alice")
+	late.ExpireHeight = 3
+	if err := tb.add(t, 4, late); err == nil {
+		t.Fatal("added an order past its expire height")
+	}
+}
+
+func TestTriggeredOrderMatchesNextBlock(t *testing.T) {
+	tb := newTestBook(t, nil)
+	tb.fund(t, "alice", "USD", 1_000)
+	tb.fund(t, "bob", "BTC", 2)
+	tb.fund(t, "carol", "BTC", 5)
+	stop := limit(Sell, 5, 40, "carol")
+	stop.Condition = &Condition{Type: StopLoss, TriggerPrice: big.NewInt(44)}
+	mustOk(t, tb.add(t, 1, stop))
+	mustOk(t, tb.add(t, 1, limit(Sell, 2, 44, "bob")))
+	mustOk(t, tb.add(t, 1, limit(Buy, 7, 44, "alice")))
+	tb.endBlock(1)
+
+	// the trade at 44 triggers the stop, which waits for the next block although the bid of alice crosses it
+	if len(tb.book(t, conditionalBook)) != 0 {
+		t.Fatal("stop order still dormant after the price reached its trigger")
+	}
+	if got := tb.balance(t, "carol", "USD"); got != 0 {
+		t.Fatalf("the stop of carol traded %d USD in the block that triggered it", got)
+	}
+	tb.endBlock(2)
+	if got := tb.balance(t, "carol", "USD"); got != 220 {
+		t.Fatalf("carol has %d USD, want 220 from the triggered stop", got)
+	}
+	if got := tb.balance(t, "alice", "BTC"); got != 7 {
+		t.Fatalf("alice has %d BTC, want 7", got)
+	}
+}
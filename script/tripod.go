@@ -8,15 +8,27 @@ import (
 	"github.com/yu-org/yu/core/tripod"
 )
 
+var (
+	ErrNoVM           = errors.New("no script vm is configured")
+	ErrScriptNotFound = errors.New("script not found")
+)
+
 type ScriptTripod struct {
 	*tripod.Tripod
 
 	vm VM
 }
 
+// NewScriptTripod creates the script tripod without a VM, every script invocation fails with ErrNoVM.
 func NewScriptTripod() *ScriptTripod {
+	return NewScriptTripodWithVM(nil)
+}
+
+// NewScriptTripodWithVM creates the script tripod running the scripts in vm.
+func NewScriptTripodWithVM(vm VM) *ScriptTripod {
 	st := &ScriptTripod{
 		Tripod: tripod.NewTripodWithName("script"),
+		vm:     vm,
 	}
 	st.SetReadings(st.GetScript)
 	return st
@@ -33,6 +45,9 @@ func (st *ScriptTripod) GetScript(ctx *context.ReadContext) {
 }
 
 func (st *ScriptTripod) InvokeScript(id string, args []byte) (*VMResult, error) {
+	if st.vm == nil {
+		return nil, ErrNoVM
+	}
 	script, err := st.GetScriptById(id)
 	if err != nil {
 		return nil, err
@@ -61,6 +76,9 @@ func (st *ScriptTripod) GetScriptById(id string) (*Script, error) {
 	if err != nil {
 		return nil, err
 	}
+	if scptByt == nil {
+		return nil, ErrScriptNotFound
+	}
 	scpt := new(Script)
 	if err = json.Unmarshal(scptByt, scpt); err != nil {
 		return nil, err
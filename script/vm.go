@@ -1,5 +1,7 @@
 package script
 
+import "fmt"
+
 type VM interface {
 	Run(script *Script, args []byte) (*VMResult, error)
 }
@@ -9,3 +11,29 @@ type VMResult struct {
 	Error   string `json:"error"`
 	GasCost uint64 `json:"gas_cost"`
 }
+
+// NativeVM runs the scripts whose Code is the name of a Go function registered in it.
+// Every node must register the same functions, or their blocks diverge.
+type NativeVM struct {
+	funcs map[string]NativeFunc
+}
+
+// NativeFunc is a script of the NativeVM, it gets the arguments of the invocation.
+type NativeFunc func(args []byte) (*VMResult, error)
+
+func NewNativeVM() *NativeVM {
+	return &NativeVM{funcs: make(map[string]NativeFunc)}
+}
+
+// Register makes fn the script whose Code is name.
+func (vm *NativeVM) Register(name string, fn NativeFunc) {
+	vm.funcs[name] = fn
+}
+
+func (vm *NativeVM) Run(script *Script, args []byte) (*VMResult, error) {
+	fn, ok := vm.funcs[string(script.Code)]
+	if !ok {
+		return nil, fmt.Errorf("native script %q is not registered", script.Code)
+	}
+	return fn(args)
+}
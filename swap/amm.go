@@ -3,7 +3,12 @@ package swap
 import (
 	"errors"
 	"math/big"
+	"strconv"
+	"strings"
 	"sync"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
 )
 
 // --------------------------
@@ -15,6 +20,14 @@ var (
 	DefaultFeeDen   = big.NewInt(1000)
 	Zero            = big.NewInt(0)
 	ErrInsufficient = errors.New("insufficient liquidity")
+	// ErrInsufficientOutput 输出少于 minAmountOut
+	ErrInsufficientOutput = errors.New("insufficient output amount")
+	// ErrExcessiveInput 输入多于 maxAmountIn
+	ErrExcessiveInput = errors.New("excessive input amount")
+	// MinimumLiquidity 首次注入时永久锁定的份额，防止份额价格被操纵
+	MinimumLiquidity = big.NewInt(1000)
+	// Q112 累计价格的定点数精度，价格以 UQ112x112 表示
+	Q112 = new(big.Int).Lsh(ONE, 112)
 )
 
 // ceilDiv: ceil(a/b) for big.Int (returns floor((a + b -1)/b))
@@ -28,11 +41,47 @@ func ceilDiv(a, b *big.Int) *big.Int {
 }
 
 type Pool struct {
-	mu       sync.Mutex
-	Reserve0 *big.Int // token0 reserve
-	Reserve1 *big.Int // token1 reserve
-	FeeNum   *big.Int // fee numerator (e.g., 997)
-	FeeDen   *big.Int // fee denominator (e.g., 1000)
+	mu          sync.Mutex
+	Token0      udt.TokenID `json:"token0"`       // token0, sorted so that token0 < token1
+	Token1      udt.TokenID `json:"token1"`       // token1
+	Reserve0    *big.Int    `json:"reserve0"`     // token0 reserve
+	Reserve1    *big.Int    `json:"reserve1"`     // token1 reserve
+	FeeNum      *big.Int    `json:"fee_num"`      // fee numerator (e.g., 997)
+	FeeDen      *big.Int    `json:"fee_den"`      // fee denominator (e.g., 1000)
+	LPToken     udt.TokenID `json:"lp_token"`     // UDT of the liquidity shares
+	TotalShares *big.Int    `json:"total_shares"` // total liquidity shares, including MinimumLiquidity
+
+	// 累计价格: 每个区块开始时的价格乘以经过的区块数之和，UQ112x112
+	Price0CumulativeLast *big.Int        `json:"price0_cumulative_last"` // token0 以 token1 计价
+	Price1CumulativeLast *big.Int        `json:"price1_cumulative_last"` // token1 以 token0 计价
+	BlockHeightLast      common.BlockNum `json:"block_height_last"`      // 上次累计的区块高度
+
+	// ProtocolFeeShare 手续费中归协议的比例，百万分之一为单位，0 表示关闭；
+	// 协议费不在每笔交易时收取，而是在增减流动性时按 k 的增长以 LP 份额的形式铸造给协议
+	ProtocolFeeShare uint32   `json:"protocol_fee_share,omitempty"`
+	KLast            *big.Int `json:"k_last,omitempty"` // 上次增减流动性后的 reserve0 * reserve1，协议费关闭时为空
+
+	// 累计统计
+	Volume0        *big.Int `json:"volume0,omitempty"`         // 所有交换中 token0 的成交量，输入输出都计入
+	Volume1        *big.Int `json:"volume1,omitempty"`         // 所有交换中 token1 的成交量
+	Fees0          *big.Int `json:"fees0,omitempty"`           // 以 token0 支付的手续费
+	Fees1          *big.Int `json:"fees1,omitempty"`           // 以 token1 支付的手续费
+	ProtocolShares *big.Int `json:"protocol_shares,omitempty"` // 铸造给协议的 LP 份额
+}
+
+// LPTokenID 返回 token0/token1 池子的 LP token 名称，每个 token 名称前带上其长度，
+// 避免 ("a-b","c") 与 ("a","b-c") 这样的池子得到同一个名称
+func LPTokenID(token0, token1 udt.TokenID) udt.TokenID {
+	return udt.TokenID("LP-" + lengthPrefixed(token0, token1))
+}
+
+// lengthPrefixed 把 token 名称编码为 "<长度>:<名称>" 并用 "-" 连接，不同的 token 列表得到不同的编码
+func lengthPrefixed(tokens ...udt.TokenID) string {
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		parts[i] = strconv.Itoa(len(token)) + ":" + string(token)
+	}
+	return strings.Join(parts, "-")
 }
 
 // NewPool 创建一个新池（初始储备可以为 0）
@@ -43,19 +92,89 @@ func NewPool(res0, res1 *big.Int, feeNum, feeDen *big.Int) *Pool {
 	fn := new(big.Int).Set(feeNum)
 	fd := new(big.Int).Set(feeDen)
 	return &Pool{
-		Reserve0: r0,
-		Reserve1: r1,
-		FeeNum:   fn,
-		FeeDen:   fd,
+		Reserve0:    r0,
+		Reserve1:    r1,
+		FeeNum:      fn,
+		FeeDen:      fd,
+		TotalShares: big.NewInt(0),
+
+		Price0CumulativeLast: big.NewInt(0),
+		Price1CumulativeLast: big.NewInt(0),
 	}
 }
 
-// AddLiquidity 简单把 token0/token1 加到储备里（不计算 LP token）
-func (p *Pool) AddLiquidity(amount0, amount1 *big.Int) {
+// Quote 按储备比例换算: amountB = amountA * reserveB / reserveA
+func Quote(amountA, reserveA, reserveB *big.Int) (*big.Int, error) {
+	if reserveA.Sign() <= 0 || reserveB.Sign() <= 0 {
+		return nil, ErrInsufficient
+	}
+	return new(big.Int).Div(new(big.Int).Mul(amountA, reserveB), reserveA), nil
+}
+
+// AddLiquidity 按池子当前比例注入 token0/token1，返回实际注入的数量和新增的流动性份额。
+// desired 是最多注入的数量，min 是最少注入的数量；超出比例的部分不会被注入，由调用方退回。
+// 首次注入 shares = sqrt(amount0 * amount1) - MinimumLiquidity，MinimumLiquidity 永久锁定在池子里；
+// 之后 shares = min(amount0 * total / reserve0, amount1 * total / reserve1)
+func (p *Pool) AddLiquidity(desired0, desired1, min0, min1 *big.Int) (amount0, amount1, shares *big.Int, err error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.Reserve0.Add(p.Reserve0, new(big.Int).Set(amount0))
-	p.Reserve1.Add(p.Reserve1, new(big.Int).Set(amount1))
+
+	if p.TotalShares.Sign() == 0 {
+		amount0, amount1 = new(big.Int).Set(desired0), new(big.Int).Set(desired1)
+		shares = new(big.Int).Sqrt(new(big.Int).Mul(amount0, amount1))
+		if shares.Cmp(MinimumLiquidity) <= 0 {
+			return nil, nil, nil, errors.New("insufficient liquidity minted")
+		}
+		p.TotalShares.Set(MinimumLiquidity)
+		shares.Sub(shares, MinimumLiquidity)
+	} else {
+		optimal1, err := Quote(desired0, p.Reserve0, p.Reserve1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if optimal1.Cmp(desired1) <= 0 {
+			if optimal1.Cmp(min1) < 0 {
+				return nil, nil, nil, errors.New("insufficient token1 amount")
+			}
+			amount0, amount1 = new(big.Int).Set(desired0), optimal1
+		} else {
+			optimal0, err := Quote(desired1, p.Reserve1, p.Reserve0)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if optimal0.Cmp(min0) < 0 {
+				return nil, nil, nil, errors.New("insufficient token0 amount")
+			}
+			amount0, amount1 = optimal0, new(big.Int).Set(desired1)
+		}
+		shares = new(big.Int).Div(new(big.Int).Mul(amount0, p.TotalShares), p.Reserve0)
+		shares1 := new(big.Int).Div(new(big.Int).Mul(amount1, p.TotalShares), p.Reserve1)
+		if shares1.Cmp(shares) < 0 {
+			shares = shares1
+		}
+		if shares.Sign() <= 0 {
+			return nil, nil, nil, errors.New("insufficient liquidity minted")
+		}
+	}
+	p.Reserve0.Add(p.Reserve0, amount0)
+	p.Reserve1.Add(p.Reserve1, amount1)
+	p.TotalShares.Add(p.TotalShares, shares)
+	return amount0, amount1, shares, nil
+}
+
+// RemoveLiquidity 销毁份额，按比例取回 token0/token1: amount = shares * reserve / total
+func (p *Pool) RemoveLiquidity(shares *big.Int) (amount0, amount1 *big.Int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if shares.Sign() <= 0 || shares.Cmp(p.TotalShares) > 0 {
+		return nil, nil, ErrInsufficient
+	}
+	amount0 = new(big.Int).Div(new(big.Int).Mul(shares, p.Reserve0), p.TotalShares)
+	amount1 = new(big.Int).Div(new(big.Int).Mul(shares, p.Reserve1), p.TotalShares)
+	p.Reserve0.Sub(p.Reserve0, amount0)
+	p.Reserve1.Sub(p.Reserve1, amount1)
+	p.TotalShares.Sub(p.TotalShares, shares)
+	return amount0, amount1, nil
 }
 
 // GetAmountOut (Uniswap V2 公式):
@@ -116,6 +235,7 @@ func (p *Pool) Swap0For1(amountIn *big.Int) (*big.Int, error) {
 	// update reserves: x += amountIn, y -= amountOut
 	p.Reserve0.Add(p.Reserve0, new(big.Int).Set(amountIn))
 	p.Reserve1.Sub(p.Reserve1, new(big.Int).Set(amountOut))
+	p.recordSwap(true, amountIn, amountOut)
 	return amountOut, nil
 }
 
@@ -136,9 +256,270 @@ func (p *Pool) Swap1For0(amountIn *big.Int) (*big.Int, error) {
 	}
 	p.Reserve1.Add(p.Reserve1, new(big.Int).Set(amountIn))
 	p.Reserve0.Sub(p.Reserve0, new(big.Int).Set(amountOut))
+	p.recordSwap(false, amountIn, amountOut)
 	return amountOut, nil
 }
 
+// reserves 返回交换方向上的 (输入储备, 输出储备)，返回的是池子字段本身
+func (p *Pool) reserves(zeroForOne bool) (reserveIn, reserveOut *big.Int) {
+	if zeroForOne {
+		return p.Reserve0, p.Reserve1
+	}
+	return p.Reserve1, p.Reserve0
+}
+
+// SwapExactIn 用户给 amountIn 输入 token，至少得到 minAmountOut 输出 token；
+// zeroForOne 为 true 时输入 token0。不满足限制时不改动储备并返回错误
+func (p *Pool) SwapExactIn(zeroForOne bool, amountIn, minAmountOut *big.Int) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if amountIn.Sign() <= 0 {
+		return nil, errors.New("amount in must be positive")
+	}
+	reserveIn, reserveOut := p.reserves(zeroForOne)
+	amountOut := p.GetAmountOut(amountIn, reserveIn, reserveOut)
+	if amountOut.Sign() == 0 || amountOut.Cmp(minAmountOut) < 0 {
+		return nil, ErrInsufficientOutput
+	}
+	if amountOut.Cmp(reserveOut) >= 0 {
+		return nil, ErrInsufficient
+	}
+	reserveIn.Add(reserveIn, amountIn)
+	reserveOut.Sub(reserveOut, amountOut)
+	p.recordSwap(zeroForOne, amountIn, amountOut)
+	return amountOut, nil
+}
+
+// SwapExactOut 用户得到 amountOut 输出 token，最多付出 maxAmountIn 输入 token，返回实际付出的数量；
+// 不满足限制时不改动储备并返回错误
+func (p *Pool) SwapExactOut(zeroForOne bool, amountOut, maxAmountIn *big.Int) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if amountOut.Sign() <= 0 {
+		return nil, errors.New("amount out must be positive")
+	}
+	reserveIn, reserveOut := p.reserves(zeroForOne)
+	amountIn, err := p.GetAmountIn(amountOut, reserveIn, reserveOut)
+	if err != nil {
+		return nil, err
+	}
+	if amountIn.Cmp(maxAmountIn) > 0 {
+		return nil, ErrExcessiveInput
+	}
+	reserveIn.Add(reserveIn, amountIn)
+	reserveOut.Sub(reserveOut, amountOut)
+	p.recordSwap(zeroForOne, amountIn, amountOut)
+	return amountIn, nil
+}
+
+// FlashRepayment 返回借出 amount0Out/amount1Out 之后原币种归还时至少要还的数量: ceil(out * feeDen / feeNum)
+func (p *Pool) FlashRepayment(amount0Out, amount1Out *big.Int) (amount0In, amount1In *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	amount0In = ceilDiv(new(big.Int).Mul(amount0Out, p.FeeDen), p.FeeNum)
+	amount1In = ceilDiv(new(big.Int).Mul(amount1Out, p.FeeDen), p.FeeNum)
+	return amount0In, amount1In
+}
+
+// FlashSwap 借出 amount0Out/amount1Out 并收回 amount0In/amount1In 之后更新储备 (Uniswap V2 swap)。
+// 输入部分扣除手续费后 k 不能减少:
+// (balance0 * feeDen - amount0In * (feeDen - feeNum)) * (balance1 * feeDen - amount1In * (feeDen - feeNum)) >= reserve0 * reserve1 * feeDen^2。
+// 不满足时不改动储备并返回错误
+func (p *Pool) FlashSwap(amount0Out, amount1Out, amount0In, amount1In *big.Int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if amount0Out.Sign() < 0 || amount1Out.Sign() < 0 || amount0In.Sign() < 0 || amount1In.Sign() < 0 {
+		return errors.New("flash amounts must not be negative")
+	}
+	if amount0Out.Sign() == 0 && amount1Out.Sign() == 0 {
+		return ErrInsufficientOutput
+	}
+	if amount0Out.Cmp(p.Reserve0) >= 0 || amount1Out.Cmp(p.Reserve1) >= 0 {
+		return ErrInsufficient
+	}
+	balance0 := new(big.Int).Sub(p.Reserve0, amount0Out)
+	balance0.Add(balance0, amount0In)
+	balance1 := new(big.Int).Sub(p.Reserve1, amount1Out)
+	balance1.Add(balance1, amount1In)
+
+	feeRate := new(big.Int).Sub(p.FeeDen, p.FeeNum)
+	adjusted0 := new(big.Int).Mul(balance0, p.FeeDen)
+	adjusted0.Sub(adjusted0, new(big.Int).Mul(amount0In, feeRate))
+	adjusted1 := new(big.Int).Mul(balance1, p.FeeDen)
+	adjusted1.Sub(adjusted1, new(big.Int).Mul(amount1In, feeRate))
+	k := new(big.Int).Mul(p.Reserve0, p.Reserve1)
+	k.Mul(k, new(big.Int).Mul(p.FeeDen, p.FeeDen))
+	if new(big.Int).Mul(adjusted0, adjusted1).Cmp(k) < 0 {
+		return errors.New("flash swap breaks the pool invariant")
+	}
+	p.Reserve0, p.Reserve1 = balance0, balance1
+	p.recordSwap(true, amount0In, amount1Out)
+	p.recordSwap(false, amount1In, amount0Out)
+	return nil
+}
+
+// ceilSqrt: ceil(sqrt(a))
+func ceilSqrt(a *big.Int) *big.Int {
+	s := new(big.Int).Sqrt(a)
+	if new(big.Int).Mul(s, s).Cmp(a) < 0 {
+		s.Add(s, ONE)
+	}
+	return s
+}
+
+// MaxOutAtPrice 返回输出 token 的边际价格（每单位输出需要的输入，含手续费）不超过 price 时最多能换出的数量。
+// 换出 out 后边际价格为 reserveIn * reserveOut * feeDen / ((reserveOut - out)^2 * feeNum)，
+// 所以 out = reserveOut - ceil(sqrt(reserveIn * reserveOut * feeDen / (feeNum * price)))
+func (p *Pool) MaxOutAtPrice(zeroForOne bool, price *big.Int) *big.Int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reserveIn, reserveOut := p.reserves(zeroForOne)
+	if price.Sign() <= 0 || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	n := new(big.Int).Mul(reserveIn, reserveOut)
+	n.Mul(n, p.FeeDen)
+	n = ceilDiv(n, new(big.Int).Mul(p.FeeNum, price))
+	out := new(big.Int).Sub(reserveOut, ceilSqrt(n))
+	if out.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return out
+}
+
+// MaxInAtPrice 返回输入 token 的边际价格（每单位输入换出的输出，扣手续费）不低于 price 时最多能输入的数量；
+// price 为 0 时没有上限，返回 nil。
+// 输入 in 后边际价格为 feeNum * feeDen * reserveIn * reserveOut / (reserveIn * feeDen + in * feeNum)^2，
+// 所以 in = (sqrt(feeNum * feeDen * reserveIn * reserveOut / price) - reserveIn * feeDen) / feeNum
+func (p *Pool) MaxInAtPrice(zeroForOne bool, price *big.Int) *big.Int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reserveIn, reserveOut := p.reserves(zeroForOne)
+	if reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	if price.Sign() <= 0 {
+		return nil
+	}
+	n := new(big.Int).Mul(p.FeeNum, p.FeeDen)
+	n.Mul(n, reserveIn)
+	n.Mul(n, reserveOut)
+	n.Div(n, price)
+	in := new(big.Int).Sqrt(n)
+	in.Sub(in, new(big.Int).Mul(reserveIn, p.FeeDen))
+	if in.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	return in.Div(in, p.FeeNum)
+}
+
+// recordSwap 把一次交换计入成交量和手续费统计，手续费 = amountIn * (feeDen - feeNum) / feeDen
+func (p *Pool) recordSwap(zeroForOne bool, amountIn, amountOut *big.Int) {
+	fee := new(big.Int).Mul(amountIn, new(big.Int).Sub(p.FeeDen, p.FeeNum))
+	fee.Div(fee, p.FeeDen)
+	p.Volume0, p.Volume1 = orZero(p.Volume0), orZero(p.Volume1)
+	p.Fees0, p.Fees1 = orZero(p.Fees0), orZero(p.Fees1)
+	if zeroForOne {
+		p.Volume0 = new(big.Int).Add(p.Volume0, amountIn)
+		p.Volume1 = new(big.Int).Add(p.Volume1, amountOut)
+		p.Fees0 = new(big.Int).Add(p.Fees0, fee)
+	} else {
+		p.Volume1 = new(big.Int).Add(p.Volume1, amountIn)
+		p.Volume0 = new(big.Int).Add(p.Volume0, amountOut)
+		p.Fees1 = new(big.Int).Add(p.Fees1, fee)
+	}
+}
+
+// PendingProtocolFee 返回上次增减流动性以来应铸造给协议的 LP 份额 (Uniswap V2 _mintFee)。
+// 设协议比例为 φ，k 从 kLast 增长到 k 全部来自手续费，协议应得其中 φ 部分:
+// shares = total * φ * (sqrt(k) - sqrt(kLast)) / ((1 - φ) * sqrt(k) + φ * sqrt(kLast))
+func (p *Pool) PendingProtocolFee() *big.Int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pendingProtocolFee()
+}
+
+func (p *Pool) pendingProtocolFee() *big.Int {
+	if p.ProtocolFeeShare == 0 || p.KLast == nil || p.KLast.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	rootK := new(big.Int).Sqrt(new(big.Int).Mul(p.Reserve0, p.Reserve1))
+	rootKLast := new(big.Int).Sqrt(p.KLast)
+	if rootK.Cmp(rootKLast) <= 0 {
+		return big.NewInt(0)
+	}
+	share := big.NewInt(int64(p.ProtocolFeeShare))
+	numerator := new(big.Int).Sub(rootK, rootKLast)
+	numerator.Mul(numerator, p.TotalShares)
+	numerator.Mul(numerator, share)
+	denominator := new(big.Int).Mul(rootK, new(big.Int).Sub(big.NewInt(FeePipsDenominator), share))
+	denominator.Add(denominator, new(big.Int).Mul(rootKLast, share))
+	return numerator.Div(numerator, denominator)
+}
+
+// MintProtocolFee 在增减流动性之前调用，把应得的协议费计入总份额并返回，由调用方发给协议
+func (p *Pool) MintProtocolFee() *big.Int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	shares := p.pendingProtocolFee()
+	if shares.Sign() > 0 {
+		p.TotalShares.Add(p.TotalShares, shares)
+		p.ProtocolShares = new(big.Int).Add(orZero(p.ProtocolShares), shares)
+	}
+	return shares
+}
+
+// SyncKLast 在增减流动性之后调用，记录新的 k；协议费关闭时清空
+func (p *Pool) SyncKLast() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ProtocolFeeShare == 0 {
+		p.KLast = nil
+		return
+	}
+	p.KLast = new(big.Int).Mul(p.Reserve0, p.Reserve1)
+}
+
+// Update 在区块 height 第一次改动储备之前调用，把上次累计以来的价格计入累计价格；
+// 同一区块内后续的交易不再累计，所以单个区块内操纵价格对累计价格没有影响。返回是否累计了
+func (p *Pool) Update(height common.BlockNum) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if height <= p.BlockHeightLast {
+		return false
+	}
+	p.Price0CumulativeLast, p.Price1CumulativeLast = p.cumulative(height)
+	p.BlockHeightLast = height
+	return true
+}
+
+// Cumulative 返回累计到 height 的累计价格，不改动池子
+func (p *Pool) Cumulative(height common.BlockNum) (price0, price1 *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cumulative(height)
+}
+
+func (p *Pool) cumulative(height common.BlockNum) (price0, price1 *big.Int) {
+	price0 = new(big.Int).Set(orZero(p.Price0CumulativeLast))
+	price1 = new(big.Int).Set(orZero(p.Price1CumulativeLast))
+	if height <= p.BlockHeightLast || p.Reserve0.Sign() <= 0 || p.Reserve1.Sign() <= 0 {
+		return price0, price1
+	}
+	elapsed := new(big.Int).SetUint64(uint64(height - p.BlockHeightLast))
+	// price0 += reserve1 / reserve0 * elapsed，price1 += reserve0 / reserve1 * elapsed
+	delta0 := new(big.Int).Mul(p.Reserve1, Q112)
+	delta0.Div(delta0, p.Reserve0)
+	price0.Add(price0, delta0.Mul(delta0, elapsed))
+	delta1 := new(big.Int).Mul(p.Reserve0, Q112)
+	delta1.Div(delta1, p.Reserve1)
+	price1.Add(price1, delta1.Mul(delta1, elapsed))
+	return price0, price1
+}
+
 // Inspect 返回当前储备的拷贝
 func (p *Pool) Inspect() (r0, r1 *big.Int) {
 	p.mu.Lock()
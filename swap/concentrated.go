@@ -0,0 +1,589 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/yu-org/JingChou/udt"
+)
+
+// --------------------------
+// 集中流动性池 (Uniswap V3 模型)
+// 流动性提供者把流动性放在 [tickLower, tickUpper) 价格区间内，只有当前价格落在区间内时才参与交易和分手续费，
+// 稳定币这类价格波动很小的交易对可以用同样的资金提供更深的流动性。
+// 所有数值都是 big.Int 定点数：价格为 Q64.96，手续费累计值为 Q128，结果与 Uniswap V3 的取整方向一致
+// --------------------------
+
+// FeePipsDenominator 手续费以百万分之一为单位
+const FeePipsDenominator = 1_000_000
+
+// FeeTiers 可选的手续费档位及其 tick 间隔：费率越低，价格区间可以划得越细
+var FeeTiers = map[uint32]int32{
+	100:   1,   // 0.01%，稳定币
+	500:   10,  // 0.05%
+	3000:  60,  // 0.3%
+	10000: 200, // 1%
+}
+
+var (
+	feePipsDen = big.NewInt(FeePipsDenominator)
+
+	ErrInvalidTickRange  = errors.New("invalid tick range")
+	ErrInvalidPriceLimit = errors.New("invalid sqrt price limit")
+)
+
+// TickInfo 记录一个被某些仓位用作边界的 tick
+type TickInfo struct {
+	// LiquidityGross 以该 tick 为边界的流动性总和，为 0 时 tick 被删除
+	LiquidityGross *big.Int `json:"liquidity_gross"`
+	// LiquidityNet 价格从左向右穿过该 tick 时池子流动性的变化量，可以为负
+	LiquidityNet *big.Int `json:"liquidity_net"`
+	// FeeGrowthOutside 该 tick 另一侧（相对当前价格）每单位流动性累计的手续费
+	FeeGrowthOutside0X128 *big.Int `json:"fee_growth_outside0_x128"`
+	FeeGrowthOutside1X128 *big.Int `json:"fee_growth_outside1_x128"`
+}
+
+// Position 一个账户在某个价格区间内的流动性仓位
+type Position struct {
+	Owner     string   `json:"owner"`
+	TickLower int32    `json:"tick_lower"`
+	TickUpper int32    `json:"tick_upper"`
+	Liquidity *big.Int `json:"liquidity"`
+	// FeeGrowthInsideLast 上次结算时区间内每单位流动性累计的手续费
+	FeeGrowthInside0LastX128 *big.Int `json:"fee_growth_inside0_last_x128"`
+	FeeGrowthInside1LastX128 *big.Int `json:"fee_growth_inside1_last_x128"`
+	// TokensOwed 已结算但还没有领取的手续费
+	TokensOwed0 *big.Int `json:"tokens_owed0"`
+	TokensOwed1 *big.Int `json:"tokens_owed1"`
+}
+
+// NewPosition 创建一个空仓位
+func NewPosition(owner string, tickLower, tickUpper int32) *Position {
+	return &Position{
+		Owner:                    owner,
+		TickLower:                tickLower,
+		TickUpper:                tickUpper,
+		Liquidity:                big.NewInt(0),
+		FeeGrowthInside0LastX128: big.NewInt(0),
+		FeeGrowthInside1LastX128: big.NewInt(0),
+		TokensOwed0:              big.NewInt(0),
+		TokensOwed1:              big.NewInt(0),
+	}
+}
+
+// Empty 仓位没有流动性也没有未领取的手续费
+func (pos *Position) Empty() bool {
+	return pos.Liquidity.Sign() == 0 && pos.TokensOwed0.Sign() == 0 && pos.TokensOwed1.Sign() == 0
+}
+
+type ConcentratedPool struct {
+	mu     sync.Mutex
+	Token0 udt.TokenID `json:"token0"`
+	Token1 udt.TokenID `json:"token1"`
+	// Fee 百万分之一为单位，见 FeeTiers
+	Fee         uint32 `json:"fee"`
+	TickSpacing int32  `json:"tick_spacing"`
+	// SqrtPriceX96 当前价格 (token1/token0) 的平方根，Q64.96
+	SqrtPriceX96 *big.Int `json:"sqrt_price_x96"`
+	// Tick 满足 GetSqrtRatioAtTick(Tick) <= SqrtPriceX96 的最大 tick
+	Tick int32 `json:"tick"`
+	// Liquidity 当前价格处生效的流动性
+	Liquidity *big.Int `json:"liquidity"`
+	// FeeGrowthGlobal 全局每单位流动性累计的手续费，Q128
+	FeeGrowthGlobal0X128 *big.Int `json:"fee_growth_global0_x128"`
+	FeeGrowthGlobal1X128 *big.Int `json:"fee_growth_global1_x128"`
+	// Reserve 池子持有的 token，包括还没有领取的手续费
+	Reserve0 *big.Int            `json:"reserve0"`
+	Reserve1 *big.Int            `json:"reserve1"`
+	Ticks    map[int32]*TickInfo `json:"ticks"`
+}
+
+// NewConcentratedPool 以初始价格 sqrtPriceX96 创建一个没有流动性的池子
+func NewConcentratedPool(token0, token1 udt.TokenID, fee uint32, sqrtPriceX96 *big.Int) (*ConcentratedPool, error) {
+	spacing, ok := FeeTiers[fee]
+	if !ok {
+		return nil, errors.New("unsupported fee tier")
+	}
+	tick, err := GetTickAtSqrtRatio(sqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	return &ConcentratedPool{
+		Token0:               token0,
+		Token1:               token1,
+		Fee:                  fee,
+		TickSpacing:          spacing,
+		SqrtPriceX96:         new(big.Int).Set(sqrtPriceX96),
+		Tick:                 tick,
+		Liquidity:            big.NewInt(0),
+		FeeGrowthGlobal0X128: big.NewInt(0),
+		FeeGrowthGlobal1X128: big.NewInt(0),
+		Reserve0:             big.NewInt(0),
+		Reserve1:             big.NewInt(0),
+		Ticks:                make(map[int32]*TickInfo),
+	}, nil
+}
+
+// CheckTicks 检查仓位区间：tickLower < tickUpper，都在范围内并且是 TickSpacing 的整数倍
+func (p *ConcentratedPool) CheckTicks(tickLower, tickUpper int32) error {
+	if tickLower >= tickUpper || tickLower < MinTick || tickUpper > MaxTick ||
+		tickLower%p.TickSpacing != 0 || tickUpper%p.TickSpacing != 0 {
+		return ErrInvalidTickRange
+	}
+	return nil
+}
+
+// Mint 给仓位增加 liquidity，返回需要存入的 token0/token1 数量（向上取整）
+func (p *ConcentratedPool) Mint(pos *Position, liquidity *big.Int) (amount0, amount1 *big.Int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if liquidity.Sign() <= 0 {
+		return nil, nil, errors.New("liquidity must be positive")
+	}
+	amount0, amount1, err = p.modifyPosition(pos, liquidity)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.Reserve0.Add(p.Reserve0, amount0)
+	p.Reserve1.Add(p.Reserve1, amount1)
+	return amount0, amount1, nil
+}
+
+// Burn 从仓位移除 liquidity，返回取回的 token0/token1 数量（向下取整）；
+// liquidity 为 0 时只结算仓位的手续费。取回的本金和手续费都由 Collect 领取
+func (p *ConcentratedPool) Burn(pos *Position, liquidity *big.Int) (amount0, amount1 *big.Int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if liquidity.Sign() < 0 || liquidity.Cmp(pos.Liquidity) > 0 {
+		return nil, nil, ErrInsufficient
+	}
+	if liquidity.Sign() == 0 && pos.Liquidity.Sign() == 0 {
+		return big.NewInt(0), big.NewInt(0), nil
+	}
+	amount0, amount1, err = p.modifyPosition(pos, new(big.Int).Neg(liquidity))
+	if err != nil {
+		return nil, nil, err
+	}
+	amount0.Neg(amount0)
+	amount1.Neg(amount1)
+	pos.TokensOwed0.Add(pos.TokensOwed0, amount0)
+	pos.TokensOwed1.Add(pos.TokensOwed1, amount1)
+	return amount0, amount1, nil
+}
+
+// Collect 领取仓位所有未领取的 token，并从储备中扣除
+func (p *ConcentratedPool) Collect(pos *Position) (amount0, amount1 *big.Int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	amount0, amount1 = pos.TokensOwed0, pos.TokensOwed1
+	if amount0.Cmp(p.Reserve0) > 0 || amount1.Cmp(p.Reserve1) > 0 {
+		return nil, nil, ErrInsufficient
+	}
+	p.Reserve0.Sub(p.Reserve0, amount0)
+	p.Reserve1.Sub(p.Reserve1, amount1)
+	pos.TokensOwed0, pos.TokensOwed1 = big.NewInt(0), big.NewInt(0)
+	return amount0, amount1, nil
+}
+
+// modifyPosition 按 liquidityDelta 改变仓位流动性并结算手续费，返回池子 token 的变化量：
+// 增加流动性时为正（向上取整），减少时为负（绝对值向下取整）
+func (p *ConcentratedPool) modifyPosition(pos *Position, liquidityDelta *big.Int) (amount0, amount1 *big.Int, err error) {
+	if err = p.CheckTicks(pos.TickLower, pos.TickUpper); err != nil {
+		return nil, nil, err
+	}
+	sqrtLower, _ := GetSqrtRatioAtTick(pos.TickLower)
+	sqrtUpper, _ := GetSqrtRatioAtTick(pos.TickUpper)
+
+	p.updateTick(pos.TickLower, liquidityDelta, false)
+	p.updateTick(pos.TickUpper, liquidityDelta, true)
+
+	inside0, inside1 := p.feeGrowthInside(pos.TickLower, pos.TickUpper)
+	pos.TokensOwed0.Add(pos.TokensOwed0, feesEarned(pos.Liquidity, inside0, pos.FeeGrowthInside0LastX128))
+	pos.TokensOwed1.Add(pos.TokensOwed1, feesEarned(pos.Liquidity, inside1, pos.FeeGrowthInside1LastX128))
+	pos.FeeGrowthInside0LastX128, pos.FeeGrowthInside1LastX128 = inside0, inside1
+	pos.Liquidity = new(big.Int).Add(pos.Liquidity, liquidityDelta)
+
+	// 退出区间的 tick 不再记录
+	if liquidityDelta.Sign() < 0 {
+		for _, tick := range []int32{pos.TickLower, pos.TickUpper} {
+			if p.Ticks[tick].LiquidityGross.Sign() == 0 {
+				delete(p.Ticks, tick)
+			}
+		}
+	}
+
+	roundUp := liquidityDelta.Sign() > 0
+	switch {
+	case p.Tick < pos.TickLower:
+		// 价格在区间左侧，仓位全部是 token0
+		amount0 = GetAmount0Delta(sqrtLower, sqrtUpper, liquidityDelta, roundUp)
+		amount1 = big.NewInt(0)
+	case p.Tick < pos.TickUpper:
+		amount0 = GetAmount0Delta(p.SqrtPriceX96, sqrtUpper, liquidityDelta, roundUp)
+		amount1 = GetAmount1Delta(sqrtLower, p.SqrtPriceX96, liquidityDelta, roundUp)
+		p.Liquidity.Add(p.Liquidity, liquidityDelta)
+	default:
+		// 价格在区间右侧，仓位全部是 token1
+		amount0 = big.NewInt(0)
+		amount1 = GetAmount1Delta(sqrtLower, sqrtUpper, liquidityDelta, roundUp)
+	}
+	return amount0, amount1, nil
+}
+
+// updateTick 把 liquidityDelta 记到 tick 上，upper 表示 tick 是仓位的上边界
+func (p *ConcentratedPool) updateTick(tick int32, liquidityDelta *big.Int, upper bool) {
+	info, ok := p.Ticks[tick]
+	if !ok {
+		info = &TickInfo{
+			LiquidityGross:        big.NewInt(0),
+			LiquidityNet:          big.NewInt(0),
+			FeeGrowthOutside0X128: big.NewInt(0),
+			FeeGrowthOutside1X128: big.NewInt(0),
+		}
+		// 约定 tick 初始化之前的手续费都发生在它下方
+		if tick <= p.Tick {
+			info.FeeGrowthOutside0X128.Set(p.FeeGrowthGlobal0X128)
+			info.FeeGrowthOutside1X128.Set(p.FeeGrowthGlobal1X128)
+		}
+		p.Ticks[tick] = info
+	}
+	info.LiquidityGross.Add(info.LiquidityGross, liquidityDelta)
+	if upper {
+		info.LiquidityNet.Sub(info.LiquidityNet, liquidityDelta)
+	} else {
+		info.LiquidityNet.Add(info.LiquidityNet, liquidityDelta)
+	}
+}
+
+// feeGrowthInside 返回 [tickLower, tickUpper) 区间内每单位流动性累计的手续费。
+// big.Int 不会溢出，结果可能为负，但两次结算之差就是这段时间内的真实手续费
+func (p *ConcentratedPool) feeGrowthInside(tickLower, tickUpper int32) (inside0, inside1 *big.Int) {
+	lower, upper := p.Ticks[tickLower], p.Ticks[tickUpper]
+	below0, below1 := lower.FeeGrowthOutside0X128, lower.FeeGrowthOutside1X128
+	if p.Tick < tickLower {
+		below0 = new(big.Int).Sub(p.FeeGrowthGlobal0X128, below0)
+		below1 = new(big.Int).Sub(p.FeeGrowthGlobal1X128, below1)
+	}
+	above0, above1 := upper.FeeGrowthOutside0X128, upper.FeeGrowthOutside1X128
+	if p.Tick >= tickUpper {
+		above0 = new(big.Int).Sub(p.FeeGrowthGlobal0X128, above0)
+		above1 = new(big.Int).Sub(p.FeeGrowthGlobal1X128, above1)
+	}
+	inside0 = new(big.Int).Sub(p.FeeGrowthGlobal0X128, below0)
+	inside0.Sub(inside0, above0)
+	inside1 = new(big.Int).Sub(p.FeeGrowthGlobal1X128, below1)
+	inside1.Sub(inside1, above1)
+	return inside0, inside1
+}
+
+// feesEarned = liquidity * (inside - last) / 2^128
+func feesEarned(liquidity, inside, last *big.Int) *big.Int {
+	fees := new(big.Int).Sub(inside, last)
+	fees.Mul(fees, liquidity)
+	return fees.Rsh(fees, 128)
+}
+
+// nextTick 返回交换方向上的下一个已初始化 tick：zeroForOne 时是 <= tick 的最大者，否则是 > tick 的最小者；
+// 没有时返回 MinTick/MaxTick 和 false
+func (p *ConcentratedPool) nextTick(tick int32, zeroForOne bool) (int32, bool) {
+	ticks := make([]int32, 0, len(p.Ticks))
+	for t := range p.Ticks {
+		ticks = append(ticks, t)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	i := sort.Search(len(ticks), func(i int) bool { return ticks[i] > tick })
+	if zeroForOne {
+		if i == 0 {
+			return MinTick, false
+		}
+		return ticks[i-1], true
+	}
+	if i == len(ticks) {
+		return MaxTick, false
+	}
+	return ticks[i], true
+}
+
+// crossTick 价格穿过 tick 时翻转它的 FeeGrowthOutside，返回 LiquidityNet
+func (p *ConcentratedPool) crossTick(tick int32) *big.Int {
+	info := p.Ticks[tick]
+	info.FeeGrowthOutside0X128 = new(big.Int).Sub(p.FeeGrowthGlobal0X128, info.FeeGrowthOutside0X128)
+	info.FeeGrowthOutside1X128 = new(big.Int).Sub(p.FeeGrowthGlobal1X128, info.FeeGrowthOutside1X128)
+	return info.LiquidityNet
+}
+
+// Swap 在池子里交换，amountSpecified 为正时是精确输入，为负时是精确输出；
+// 价格到达 sqrtPriceLimitX96 后停止，此时可能只成交了一部分。
+// 返回实际的输入（含手续费）和输出数量，并更新价格、流动性、手续费累计和储备
+func (p *ConcentratedPool) Swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int) (amountIn, amountOut *big.Int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if amountSpecified.Sign() == 0 {
+		return nil, nil, errors.New("amount must not be zero")
+	}
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(p.SqrtPriceX96) >= 0 || sqrtPriceLimitX96.Cmp(MinSqrtRatio) <= 0 {
+			return nil, nil, ErrInvalidPriceLimit
+		}
+	} else {
+		if sqrtPriceLimitX96.Cmp(p.SqrtPriceX96) <= 0 || sqrtPriceLimitX96.Cmp(MaxSqrtRatio) >= 0 {
+			return nil, nil, ErrInvalidPriceLimit
+		}
+	}
+
+	exactIn := amountSpecified.Sign() > 0
+	remaining := new(big.Int).Set(amountSpecified)
+	amountIn, amountOut = big.NewInt(0), big.NewInt(0)
+	sqrtPrice, tick, liquidity := new(big.Int).Set(p.SqrtPriceX96), p.Tick, new(big.Int).Set(p.Liquidity)
+	feeGrowthGlobal := p.FeeGrowthGlobal1X128
+	if zeroForOne {
+		feeGrowthGlobal = p.FeeGrowthGlobal0X128
+	}
+	feeGrowthGlobal = new(big.Int).Set(feeGrowthGlobal)
+
+	for remaining.Sign() != 0 && sqrtPrice.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtStart := sqrtPrice
+		tickNext, initialized := p.nextTick(tick, zeroForOne)
+		sqrtNext, _ := GetSqrtRatioAtTick(tickNext)
+		target := sqrtNext
+		if (zeroForOne && sqrtNext.Cmp(sqrtPriceLimitX96) < 0) || (!zeroForOne && sqrtNext.Cmp(sqrtPriceLimitX96) > 0) {
+			target = sqrtPriceLimitX96
+		}
+
+		var stepIn, stepOut, stepFee *big.Int
+		sqrtPrice, stepIn, stepOut, stepFee, err = ComputeSwapStep(sqrtPrice, target, liquidity, remaining, p.Fee)
+		if err != nil {
+			return nil, nil, err
+		}
+		if exactIn {
+			remaining.Sub(remaining, stepIn)
+			remaining.Sub(remaining, stepFee)
+		} else {
+			remaining.Add(remaining, stepOut)
+		}
+		amountIn.Add(amountIn, stepIn)
+		amountIn.Add(amountIn, stepFee)
+		amountOut.Add(amountOut, stepOut)
+		if liquidity.Sign() > 0 {
+			feeGrowthGlobal.Add(feeGrowthGlobal, new(big.Int).Div(new(big.Int).Lsh(stepFee, 128), liquidity))
+		}
+
+		if sqrtPrice.Cmp(sqrtNext) == 0 {
+			if initialized {
+				// 穿过 tick 时 FeeGrowthOutside 需要最新的全局累计值
+				if zeroForOne {
+					p.FeeGrowthGlobal0X128 = feeGrowthGlobal
+				} else {
+					p.FeeGrowthGlobal1X128 = feeGrowthGlobal
+				}
+				net := p.crossTick(tickNext)
+				if zeroForOne {
+					liquidity.Sub(liquidity, net)
+				} else {
+					liquidity.Add(liquidity, net)
+				}
+				feeGrowthGlobal = new(big.Int).Set(feeGrowthGlobal)
+			}
+			tick = tickNext
+			if zeroForOne {
+				tick = tickNext - 1
+			}
+		} else if sqrtPrice.Cmp(sqrtStart) != 0 {
+			if tick, err = GetTickAtSqrtRatio(sqrtPrice); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if zeroForOne {
+		if amountOut.Cmp(p.Reserve1) > 0 {
+			return nil, nil, ErrInsufficient
+		}
+		p.FeeGrowthGlobal0X128 = feeGrowthGlobal
+		p.Reserve0.Add(p.Reserve0, amountIn)
+		p.Reserve1.Sub(p.Reserve1, amountOut)
+	} else {
+		if amountOut.Cmp(p.Reserve0) > 0 {
+			return nil, nil, ErrInsufficient
+		}
+		p.FeeGrowthGlobal1X128 = feeGrowthGlobal
+		p.Reserve1.Add(p.Reserve1, amountIn)
+		p.Reserve0.Sub(p.Reserve0, amountOut)
+	}
+	p.SqrtPriceX96, p.Tick, p.Liquidity = sqrtPrice, tick, liquidity
+	return amountIn, amountOut, nil
+}
+
+// --------------------------
+// 价格与数量换算 (Uniswap V3 SqrtPriceMath / SwapMath)
+// --------------------------
+
+func sortSqrtPrices(sqrtA, sqrtB *big.Int) (*big.Int, *big.Int) {
+	if sqrtA.Cmp(sqrtB) > 0 {
+		return sqrtB, sqrtA
+	}
+	return sqrtA, sqrtB
+}
+
+// GetAmount0Delta token0 数量 = liquidity * (sqrtB - sqrtA) / (sqrtA * sqrtB)；
+// liquidity 为负时返回负数，绝对值向下取整
+func GetAmount0Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	if liquidity.Sign() < 0 {
+		amount := GetAmount0Delta(sqrtA, sqrtB, new(big.Int).Neg(liquidity), false)
+		return amount.Neg(amount)
+	}
+	sqrtA, sqrtB = sortSqrtPrices(sqrtA, sqrtB)
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtB, sqrtA)
+	if roundUp {
+		return ceilDiv(ceilDiv(new(big.Int).Mul(numerator1, numerator2), sqrtB), sqrtA)
+	}
+	amount := new(big.Int).Div(new(big.Int).Mul(numerator1, numerator2), sqrtB)
+	return amount.Div(amount, sqrtA)
+}
+
+// GetAmount1Delta token1 数量 = liquidity * (sqrtB - sqrtA)；
+// liquidity 为负时返回负数，绝对值向下取整
+func GetAmount1Delta(sqrtA, sqrtB, liquidity *big.Int, roundUp bool) *big.Int {
+	if liquidity.Sign() < 0 {
+		amount := GetAmount1Delta(sqrtA, sqrtB, new(big.Int).Neg(liquidity), false)
+		return amount.Neg(amount)
+	}
+	sqrtA, sqrtB = sortSqrtPrices(sqrtA, sqrtB)
+	amount := new(big.Int).Mul(liquidity, new(big.Int).Sub(sqrtB, sqrtA))
+	if roundUp {
+		return ceilDiv(amount, Q96)
+	}
+	return amount.Div(amount, Q96)
+}
+
+// nextSqrtPriceFromAmount0 加入(add)或取出 amount 个 token0 后的价格，向上取整
+func nextSqrtPriceFromAmount0(sqrtPrice, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return new(big.Int).Set(sqrtPrice), nil
+	}
+	numerator := new(big.Int).Lsh(liquidity, 96)
+	product := new(big.Int).Mul(amount, sqrtPrice)
+	denominator := new(big.Int).Add(numerator, product)
+	if !add {
+		denominator.Sub(numerator, product)
+		if denominator.Sign() <= 0 {
+			return nil, ErrInsufficient
+		}
+	}
+	return ceilDiv(new(big.Int).Mul(numerator, sqrtPrice), denominator), nil
+}
+
+// nextSqrtPriceFromAmount1 加入(add)或取出 amount 个 token1 后的价格，向下取整
+func nextSqrtPriceFromAmount1(sqrtPrice, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if add {
+		return new(big.Int).Add(sqrtPrice, new(big.Int).Div(new(big.Int).Lsh(amount, 96), liquidity)), nil
+	}
+	next := new(big.Int).Sub(sqrtPrice, ceilDiv(new(big.Int).Lsh(amount, 96), liquidity))
+	if next.Sign() <= 0 {
+		return nil, ErrInsufficient
+	}
+	return next, nil
+}
+
+// ComputeSwapStep 在流动性 liquidity 不变的一段价格内交换，价格从 sqrtCurrent 最多移动到 sqrtTarget。
+// amountRemaining 为正时是剩余的精确输入，为负时是剩余的精确输出。
+// 返回移动后的价格、这一步的输入（不含手续费）、输出和手续费
+func ComputeSwapStep(sqrtCurrent, sqrtTarget, liquidity, amountRemaining *big.Int, feePips uint32) (sqrtNext, amountIn, amountOut, feeAmount *big.Int, err error) {
+	zeroForOne := sqrtCurrent.Cmp(sqrtTarget) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+	fee := big.NewInt(int64(feePips))
+	feeComplement := new(big.Int).Sub(feePipsDen, fee)
+
+	if exactIn {
+		remainingLessFee := new(big.Int).Div(new(big.Int).Mul(amountRemaining, feeComplement), feePipsDen)
+		if zeroForOne {
+			amountIn = GetAmount0Delta(sqrtTarget, sqrtCurrent, liquidity, true)
+		} else {
+			amountIn = GetAmount1Delta(sqrtCurrent, sqrtTarget, liquidity, true)
+		}
+		if remainingLessFee.Cmp(amountIn) >= 0 {
+			sqrtNext = sqrtTarget
+		} else if zeroForOne {
+			sqrtNext, err = nextSqrtPriceFromAmount0(sqrtCurrent, liquidity, remainingLessFee, true)
+		} else {
+			sqrtNext, err = nextSqrtPriceFromAmount1(sqrtCurrent, liquidity, remainingLessFee, true)
+		}
+	} else {
+		if zeroForOne {
+			amountOut = GetAmount1Delta(sqrtTarget, sqrtCurrent, liquidity, false)
+		} else {
+			amountOut = GetAmount0Delta(sqrtCurrent, sqrtTarget, liquidity, false)
+		}
+		wanted := new(big.Int).Neg(amountRemaining)
+		if wanted.Cmp(amountOut) >= 0 {
+			sqrtNext = sqrtTarget
+		} else if zeroForOne {
+			sqrtNext, err = nextSqrtPriceFromAmount1(sqrtCurrent, liquidity, wanted, false)
+		} else {
+			sqrtNext, err = nextSqrtPriceFromAmount0(sqrtCurrent, liquidity, wanted, false)
+		}
+	}
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	reached := sqrtNext.Cmp(sqrtTarget) == 0
+	if zeroForOne {
+		if !reached || !exactIn {
+			amountIn = GetAmount0Delta(sqrtNext, sqrtCurrent, liquidity, true)
+		}
+		if !reached || exactIn {
+			amountOut = GetAmount1Delta(sqrtNext, sqrtCurrent, liquidity, false)
+		}
+	} else {
+		if !reached || !exactIn {
+			amountIn = GetAmount1Delta(sqrtCurrent, sqrtNext, liquidity, true)
+		}
+		if !reached || exactIn {
+			amountOut = GetAmount0Delta(sqrtCurrent, sqrtNext, liquidity, false)
+		}
+	}
+	// 精确输出时不能多给
+	if !exactIn && amountOut.Cmp(new(big.Int).Neg(amountRemaining)) > 0 {
+		amountOut = new(big.Int).Neg(amountRemaining)
+	}
+	if exactIn && !reached {
+		// 没有到达目标价格说明输入用完了，剩下的都是手续费
+		feeAmount = new(big.Int).Sub(amountRemaining, amountIn)
+	} else {
+		feeAmount = ceilDiv(new(big.Int).Mul(amountIn, fee), feeComplement)
+	}
+	return sqrtNext, amountIn, amountOut, feeAmount, nil
+}
+
+// LiquidityForAmounts 返回价格为 sqrtPrice 时，用不超过 amount0/amount1 的 token 能在 [sqrtA, sqrtB) 区间提供的最大流动性
+func LiquidityForAmounts(sqrtPrice, sqrtA, sqrtB, amount0, amount1 *big.Int) *big.Int {
+	sqrtA, sqrtB = sortSqrtPrices(sqrtA, sqrtB)
+	// liquidity0 = amount0 * sqrtA * sqrtB / (sqrtB - sqrtA)
+	liquidity0 := func(sqrtA, sqrtB *big.Int) *big.Int {
+		l := new(big.Int).Mul(amount0, sqrtA)
+		l.Mul(l, sqrtB)
+		return l.Div(l, new(big.Int).Mul(new(big.Int).Sub(sqrtB, sqrtA), Q96))
+	}
+	// liquidity1 = amount1 / (sqrtB - sqrtA)
+	liquidity1 := func(sqrtA, sqrtB *big.Int) *big.Int {
+		l := new(big.Int).Lsh(amount1, 96)
+		return l.Div(l, new(big.Int).Sub(sqrtB, sqrtA))
+	}
+	switch {
+	case sqrtPrice.Cmp(sqrtA) <= 0:
+		return liquidity0(sqrtA, sqrtB)
+	case sqrtPrice.Cmp(sqrtB) < 0:
+		l0, l1 := liquidity0(sqrtPrice, sqrtB), liquidity1(sqrtA, sqrtPrice)
+		if l0.Cmp(l1) < 0 {
+			return l0
+		}
+		return l1
+	default:
+		return liquidity1(sqrtA, sqrtB)
+	}
+}
@@ -0,0 +1,427 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+var ErrPositionNotFound = errors.New("position not found")
+
+// CreateConcentratedPoolRequest creates the concentrated pool of a pair in one fee tier,
+// every pair can have one pool per tier next to its constant-product pool.
+type CreateConcentratedPoolRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	// Fee is one of FeeTiers, in hundredths of a bip.
+	Fee uint32 `json:"fee"`
+	// SqrtPriceX96 is the initial price of token0 in token1 of the sorted pair, see SortTokens.
+	SqrtPriceX96 *big.Int `json:"sqrt_price_x96"`
+}
+
+func (at *AmmTripod) CreateConcentratedPool(ctx *context.WriteContext) error {
+	req := new(CreateConcentratedPoolRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	token0, token1, err := SortTokens(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if req.SqrtPriceX96 == nil {
+		return errors.New("initial price must be set")
+	}
+	if at.Exist(concentratedPoolKey(token0, token1, req.Fee)) {
+		return ErrPoolExists
+	}
+	pool, err := NewConcentratedPool(token0, token1, req.Fee, req.SqrtPriceX96)
+	if err != nil {
+		return err
+	}
+	return at.setConcentratedPool(pool)
+}
+
+// MintPositionRequest adds liquidity to the position of FromID in [TickLower, TickUpper).
+// The liquidity is the most that AmountA and AmountB can provide at the current price.
+type MintPositionRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	Fee       uint32      `json:"fee"`
+	TickLower int32       `json:"tick_lower"`
+	TickUpper int32       `json:"tick_upper"`
+
+	AmountA *big.Int `json:"amount_a"`
+	AmountB *big.Int `json:"amount_b"`
+	// AmountAMin and AmountBMin reject the deposit if the price moved too far.
+	AmountAMin *big.Int `json:"amount_a_min,omitempty"`
+	AmountBMin *big.Int `json:"amount_b_min,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) MintPosition(ctx *context.WriteContext) error {
+	req := new(MintPositionRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	pool, err := at.getConcentratedPool(req.TokenA, req.TokenB, req.Fee)
+	if err != nil {
+		return err
+	}
+	if err = pool.CheckTicks(req.TickLower, req.TickUpper); err != nil {
+		return err
+	}
+	desired0, desired1 := orZero(req.AmountA), orZero(req.AmountB)
+	min0, min1 := orZero(req.AmountAMin), orZero(req.AmountBMin)
+	if req.TokenA != pool.Token0 {
+		desired0, desired1 = desired1, desired0
+		min0, min1 = min1, min0
+	}
+	sqrtLower, _ := GetSqrtRatioAtTick(req.TickLower)
+	sqrtUpper, _ := GetSqrtRatioAtTick(req.TickUpper)
+	liquidity := LiquidityForAmounts(pool.SqrtPriceX96, sqrtLower, sqrtUpper, desired0, desired1)
+	if liquidity.Sign() <= 0 {
+		return errors.New("insufficient liquidity minted")
+	}
+
+	pos, err := at.getPosition(pool, req.FromID, req.TickLower, req.TickUpper)
+	if errors.Is(err, ErrPositionNotFound) {
+		pos, err = NewPosition(req.FromID, req.TickLower, req.TickUpper), nil
+	}
+	if err != nil {
+		return err
+	}
+	amount0, amount1, err := pool.Mint(pos, liquidity)
+	if err != nil {
+		return err
+	}
+	if amount0.Cmp(desired0) > 0 || amount1.Cmp(desired1) > 0 {
+		return ErrExcessiveInput
+	}
+	if amount0.Cmp(min0) < 0 || amount1.Cmp(min1) < 0 {
+		return errors.New("price slippage check")
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.Token0, amount0); err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.Token1, amount1); err != nil {
+		return err
+	}
+	if err = at.setPosition(pool, pos); err != nil {
+		return err
+	}
+	return at.setConcentratedPool(pool)
+}
+
+// BurnPositionRequest removes Liquidity from a position and pays out the removed tokens
+// together with the fees the position earned. A zero Liquidity only collects the fees.
+type BurnPositionRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	Fee       uint32      `json:"fee"`
+	TickLower int32       `json:"tick_lower"`
+	TickUpper int32       `json:"tick_upper"`
+	Liquidity *big.Int    `json:"liquidity,omitempty"`
+
+	// AmountAMin and AmountBMin reject the removal if the price moved too far, fees not included.
+	AmountAMin *big.Int `json:"amount_a_min,omitempty"`
+	AmountBMin *big.Int `json:"amount_b_min,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) BurnPosition(ctx *context.WriteContext) error {
+	req := new(BurnPositionRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	return at.burnPosition(ctx, req)
+}
+
+// CollectFees pays out the fees earned by a position without removing liquidity.
+func (at *AmmTripod) CollectFees(ctx *context.WriteContext) error {
+	req := new(BurnPositionRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	req.Liquidity = nil
+	return at.burnPosition(ctx, req)
+}
+
+func (at *AmmTripod) burnPosition(ctx *context.WriteContext, req *BurnPositionRequest) error {
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	pool, err := at.getConcentratedPool(req.TokenA, req.TokenB, req.Fee)
+	if err != nil {
+		return err
+	}
+	pos, err := at.getPosition(pool, req.FromID, req.TickLower, req.TickUpper)
+	if err != nil {
+		return err
+	}
+	amount0, amount1, err := pool.Burn(pos, orZero(req.Liquidity))
+	if err != nil {
+		return err
+	}
+	min0, min1 := orZero(req.AmountAMin), orZero(req.AmountBMin)
+	if req.TokenA != pool.Token0 {
+		min0, min1 = min1, min0
+	}
+	if amount0.Cmp(min0) < 0 || amount1.Cmp(min1) < 0 {
+		return errors.New("price slippage check")
+	}
+	owed0, owed1, err := pool.Collect(pos)
+	if err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.Token0, owed0); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.Token1, owed1); err != nil {
+		return err
+	}
+	if err = at.setPosition(pool, pos); err != nil {
+		return err
+	}
+	return at.setConcentratedPool(pool)
+}
+
+// SwapConcentratedRequest is an exact-in swap if AmountIn is set, or an exact-out swap if AmountOut is set.
+type SwapConcentratedRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenIn   udt.TokenID `json:"token_in"`
+	TokenOut  udt.TokenID `json:"token_out"`
+	Fee       uint32      `json:"fee"`
+
+	AmountIn     *big.Int `json:"amount_in,omitempty"`
+	MinAmountOut *big.Int `json:"min_amount_out,omitempty"`
+
+	AmountOut   *big.Int `json:"amount_out,omitempty"`
+	MaxAmountIn *big.Int `json:"max_amount_in,omitempty"`
+
+	// SqrtPriceLimitX96 stops the swap when the price reaches it, the swap may then fill partially.
+	// Without it the swap must fill completely.
+	SqrtPriceLimitX96 *big.Int `json:"sqrt_price_limit_x96,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) SwapConcentrated(ctx *context.WriteContext) error {
+	req := new(SwapConcentratedRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	if (req.AmountIn == nil) == (req.AmountOut == nil) {
+		return errors.New("exactly one of amount in and amount out must be set")
+	}
+	pool, err := at.getConcentratedPool(req.TokenIn, req.TokenOut, req.Fee)
+	if err != nil {
+		return err
+	}
+	zeroForOne := req.TokenIn == pool.Token0
+
+	// a negative amount would turn an exact-in swap into an exact-out one without its max amount in, and back
+	amountSpecified := req.AmountIn
+	if amountSpecified != nil {
+		if amountSpecified.Sign() <= 0 {
+			return errors.New("amount in must be positive")
+		}
+	} else {
+		if req.AmountOut.Sign() <= 0 {
+			return errors.New("amount out must be positive")
+		}
+		if req.MaxAmountIn == nil {
+			return errors.New("max amount in must be set for an exact-out swap")
+		}
+		amountSpecified = new(big.Int).Neg(req.AmountOut)
+	}
+	limit := req.SqrtPriceLimitX96
+	if limit == nil {
+		if zeroForOne {
+			limit = new(big.Int).Add(MinSqrtRatio, ONE)
+		} else {
+			limit = new(big.Int).Sub(MaxSqrtRatio, ONE)
+		}
+	}
+	amountIn, amountOut, err := pool.Swap(zeroForOne, amountSpecified, limit)
+	if err != nil {
+		return err
+	}
+	if req.SqrtPriceLimitX96 == nil {
+		if (req.AmountIn != nil && amountIn.Cmp(req.AmountIn) != 0) || (req.AmountOut != nil && amountOut.Cmp(req.AmountOut) != 0) {
+			return ErrInsufficient
+		}
+	}
+	if req.AmountIn != nil && amountOut.Cmp(orZero(req.MinAmountOut)) < 0 {
+		return ErrInsufficientOutput
+	}
+	if req.AmountOut != nil && amountIn.Cmp(req.MaxAmountIn) > 0 {
+		return ErrExcessiveInput
+	}
+	if amountOut.Sign() == 0 {
+		return ErrInsufficientOutput
+	}
+	if err = at.Account.SubBalance(req.FromID, req.TokenIn, amountIn); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, req.TokenOut, amountOut); err != nil {
+		return err
+	}
+	return at.setConcentratedPool(pool)
+}
+
+func (at *AmmTripod) GetConcentratedPool(ctx *context.ReadContext) {
+	fee, err := strconv.ParseUint(ctx.GetString("fee"), 10, 32)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	pool, err := at.getConcentratedPool(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")), uint32(fee))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(pool)
+}
+
+// GetPosition returns a position with the fees it earned so far counted into its owed tokens.
+func (at *AmmTripod) GetPosition(ctx *context.ReadContext) {
+	fee, err := strconv.ParseUint(ctx.GetString("fee"), 10, 32)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	tickLower, err := strconv.ParseInt(ctx.GetString("tick_lower"), 10, 32)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	tickUpper, err := strconv.ParseInt(ctx.GetString("tick_upper"), 10, 32)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	pool, err := at.getConcentratedPool(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")), uint32(fee))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	pos, err := at.getPosition(pool, ctx.GetString("owner"), int32(tickLower), int32(tickUpper))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	if _, _, err = pool.Burn(pos, Zero); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(pos)
+}
+
+// concentratedPoolKey and positionKey length-prefix the token names and the owner like poolKey, so that no two pools
+// or positions share a key.
+func concentratedPoolKey(token0, token1 udt.TokenID, fee uint32) []byte {
+	return []byte("clpool/" + lengthPrefixed(token0, token1) + "/" + strconv.FormatUint(uint64(fee), 10))
+}
+
+func positionKey(pool *ConcentratedPool, owner string, tickLower, tickUpper int32) []byte {
+	return []byte("clposition/" + lengthPrefixed(pool.Token0, pool.Token1) + "/" + strconv.FormatUint(uint64(pool.Fee), 10) +
+		"/" + strconv.Itoa(len(owner)) + ":" + owner + "/" + strconv.Itoa(int(tickLower)) + "/" + strconv.Itoa(int(tickUpper)))
+}
+
+// getConcentratedPool loads the concentrated pool of two tokens given in any order, it must be the pool of exactly
+// these tokens and fee.
+func (at *AmmTripod) getConcentratedPool(tokenA, tokenB udt.TokenID, fee uint32) (*ConcentratedPool, error) {
+	token0, token1, err := SortTokens(tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+	byt, err := at.Get(concentratedPoolKey(token0, token1, fee))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, ErrPoolNotFound
+	}
+	pool := new(ConcentratedPool)
+	if err = json.Unmarshal(byt, pool); err != nil {
+		return nil, err
+	}
+	if pool.Token0 != token0 || pool.Token1 != token1 || pool.Fee != fee {
+		return nil, ErrPoolNotFound
+	}
+	return pool, nil
+}
+
+func (at *AmmTripod) setConcentratedPool(pool *ConcentratedPool) error {
+	byt, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+	at.Set(concentratedPoolKey(pool.Token0, pool.Token1, pool.Fee), byt)
+	return nil
+}
+
+func (at *AmmTripod) getPosition(pool *ConcentratedPool, owner string, tickLower, tickUpper int32) (*Position, error) {
+	byt, err := at.Get(positionKey(pool, owner, tickLower, tickUpper))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, ErrPositionNotFound
+	}
+	pos := new(Position)
+	if err = json.Unmarshal(byt, pos); err != nil {
+		return nil, err
+	}
+	if pos.Owner != owner || pos.TickLower != tickLower || pos.TickUpper != tickUpper {
+		return nil, ErrPositionNotFound
+	}
+	return pos, nil
+}
+
+// setPosition stores pos, or deletes it once it holds nothing.
+func (at *AmmTripod) setPosition(pool *ConcentratedPool, pos *Position) error {
+	key := positionKey(pool, pos.Owner, pos.TickLower, pos.TickUpper)
+	if pos.Empty() {
+		at.Delete(key)
+		return nil
+	}
+	byt, err := json.Marshal(pos)
+	if err != nil {
+		return err
+	}
+	at.Set(key, byt)
+	return nil
+}
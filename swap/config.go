@@ -0,0 +1,8 @@
+package swap
+
+type Config struct {
+	// Governor is the account allowed to set the pool fees and ramp the amplification of stable pools.
+	Governor string `toml:"governor"`
+	// Treasury is the account receiving the protocol fee as LP tokens.
+	Treasury string `toml:"treasury"`
+}
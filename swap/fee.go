@@ -0,0 +1,104 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/core/context"
+)
+
+// SetPoolFeeRequest changes the trading fee of a constant-product pool and the share of it
+// taken by the protocol. Only the governor can set it, see Config.
+type SetPoolFeeRequest struct {
+	GovernorArgs []byte      `json:"governor_args"`
+	TokenA       udt.TokenID `json:"token_a"`
+	TokenB       udt.TokenID `json:"token_b"`
+	// FeeNum and FeeDen keep the current fee if not set.
+	FeeNum *big.Int `json:"fee_num,omitempty"`
+	FeeDen *big.Int `json:"fee_den,omitempty"`
+	// ProtocolFeeShare is the share of the fee minted to the treasury, in millionths, 0 turns it off.
+	ProtocolFeeShare uint32 `json:"protocol_fee_share"`
+}
+
+func (at *AmmTripod) SetPoolFee(ctx *context.WriteContext) error {
+	req := new(SetPoolFeeRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.verifyGovernor(req.GovernorArgs); err != nil {
+		return err
+	}
+	if req.ProtocolFeeShare > FeePipsDenominator {
+		return errors.New("invalid protocol fee share")
+	}
+	if req.ProtocolFeeShare > 0 && at.cfg.Treasury == "" {
+		return errors.New("no treasury configured")
+	}
+	pool, err := at.getPairPool(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if req.FeeNum != nil || req.FeeDen != nil {
+		if req.FeeNum == nil || req.FeeDen == nil || req.FeeNum.Sign() <= 0 || req.FeeNum.Cmp(req.FeeDen) > 0 {
+			return errors.New("invalid pool fee")
+		}
+		pool.FeeNum, pool.FeeDen = req.FeeNum, req.FeeDen
+	}
+	// the fees earned so far go to the treasury at the old share
+	if err = at.mintProtocolFee(pool); err != nil {
+		return err
+	}
+	pool.ProtocolFeeShare = req.ProtocolFeeShare
+	pool.SyncKLast()
+	if err = at.updateLPSupply(pool.LPToken, pool.TotalShares); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+// PoolStats is the fee setting and the cumulative counters of a constant-product pool.
+type PoolStats struct {
+	Token0           udt.TokenID `json:"token0"`
+	Token1           udt.TokenID `json:"token1"`
+	FeeNum           *big.Int    `json:"fee_num"`
+	FeeDen           *big.Int    `json:"fee_den"`
+	ProtocolFeeShare uint32      `json:"protocol_fee_share"`
+	Volume0          *big.Int    `json:"volume0"`
+	Volume1          *big.Int    `json:"volume1"`
+	Fees0            *big.Int    `json:"fees0"`
+	Fees1            *big.Int    `json:"fees1"`
+	// ProtocolShares is the LP minted to the treasury so far, PendingProtocolShares the LP it would get now.
+	ProtocolShares        *big.Int `json:"protocol_shares"`
+	PendingProtocolShares *big.Int `json:"pending_protocol_shares"`
+}
+
+func (at *AmmTripod) GetPoolStats(ctx *context.ReadContext) {
+	pool, err := at.getPairPool(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(&PoolStats{
+		Token0:                pool.Token0,
+		Token1:                pool.Token1,
+		FeeNum:                pool.FeeNum,
+		FeeDen:                pool.FeeDen,
+		ProtocolFeeShare:      pool.ProtocolFeeShare,
+		Volume0:               orZero(pool.Volume0),
+		Volume1:               orZero(pool.Volume1),
+		Fees0:                 orZero(pool.Fees0),
+		Fees1:                 orZero(pool.Fees1),
+		ProtocolShares:        orZero(pool.ProtocolShares),
+		PendingProtocolShares: pool.PendingProtocolFee(),
+	})
+}
+
+// mintProtocolFee mints the protocol fee earned by pool since the last liquidity change to the treasury.
+func (at *AmmTripod) mintProtocolFee(pool *Pool) error {
+	shares := pool.MintProtocolFee()
+	if shares.Sign() == 0 {
+		return nil
+	}
+	return at.Account.AddBalance(at.cfg.Treasury, pool.LPToken, shares)
+}
@@ -0,0 +1,133 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+var ErrPoolLocked = errors.New("pool is locked by a flash swap")
+
+// FlashSwapRequest lends AmountAOut and AmountBOut of a constant-product pool to FromID, invokes the
+// script ScriptID, and then takes AmountAIn and AmountBIn back from FromID.
+// Without AmountAIn and AmountBIn the borrowed tokens are repaid with the pool fee, see Pool.FlashRepayment.
+// If the repayment leaves the pool invariant (including fees) broken the whole transaction reverts.
+// The script runs in the VM of the script tripod, without one FlashSwap fails with script.ErrNoVM.
+type FlashSwapRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+
+	AmountAOut *big.Int `json:"amount_a_out,omitempty"`
+	AmountBOut *big.Int `json:"amount_b_out,omitempty"`
+	AmountAIn  *big.Int `json:"amount_a_in,omitempty"`
+	AmountBIn  *big.Int `json:"amount_b_in,omitempty"`
+
+	ScriptID string `json:"script_id"`
+	Args     []byte `json:"args,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+// FlashCallback is the argument of the script invoked by FlashSwap.
+// The borrowed amounts are already in the borrower's account, the repayment is taken after the script returns.
+type FlashCallback struct {
+	Borrower   string      `json:"borrower"`
+	TokenA     udt.TokenID `json:"token_a"`
+	TokenB     udt.TokenID `json:"token_b"`
+	AmountAOut *big.Int    `json:"amount_a_out"`
+	AmountBOut *big.Int    `json:"amount_b_out"`
+	AmountAIn  *big.Int    `json:"amount_a_in"`
+	AmountBIn  *big.Int    `json:"amount_b_in"`
+	Args       []byte      `json:"args,omitempty"`
+}
+
+func (at *AmmTripod) FlashSwap(ctx *context.WriteContext) error {
+	req := new(FlashSwapRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	pool, err := at.getPairPool(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if at.Exist(flashLockKey(pool.Token0, pool.Token1)) {
+		return ErrPoolLocked
+	}
+	if err = at.accumulate(pool, ctx.Block.Height); err != nil {
+		return err
+	}
+	amountAOut, amountBOut := orZero(req.AmountAOut), orZero(req.AmountBOut)
+	amountAIn, amountBIn := req.AmountAIn, req.AmountBIn
+	if amountAIn == nil && amountBIn == nil {
+		amountAIn, amountBIn = pool.FlashRepayment(amountAOut, amountBOut)
+	}
+	amountAIn, amountBIn = orZero(amountAIn), orZero(amountBIn)
+	if amountAOut.Sign() < 0 || amountBOut.Sign() < 0 || (amountAOut.Sign() == 0 && amountBOut.Sign() == 0) {
+		return errors.New("flash amounts out must be positive")
+	}
+	amount0Out, amount1Out, amount0In, amount1In := amountAOut, amountBOut, amountAIn, amountBIn
+	if req.TokenA != pool.Token0 {
+		amount0Out, amount1Out, amount0In, amount1In = amountBOut, amountAOut, amountBIn, amountAIn
+	}
+	if amount0Out.Cmp(pool.Reserve0) >= 0 || amount1Out.Cmp(pool.Reserve1) >= 0 {
+		return ErrInsufficient
+	}
+
+	if err = at.Account.AddBalance(req.FromID, pool.Token0, amount0Out); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.Token1, amount1Out); err != nil {
+		return err
+	}
+	// the pool can not be changed until it is repaid
+	at.Set(flashLockKey(pool.Token0, pool.Token1), []byte{1})
+	args, err := json.Marshal(&FlashCallback{
+		Borrower:   req.FromID,
+		TokenA:     req.TokenA,
+		TokenB:     req.TokenB,
+		AmountAOut: amountAOut,
+		AmountBOut: amountBOut,
+		AmountAIn:  amountAIn,
+		AmountBIn:  amountBIn,
+		Args:       req.Args,
+	})
+	if err != nil {
+		return err
+	}
+	result, err := at.Script.InvokeScript(req.ScriptID, args)
+	if err != nil {
+		return err
+	}
+	if result != nil && result.Error != "" {
+		return errors.New(result.Error)
+	}
+	at.Delete(flashLockKey(pool.Token0, pool.Token1))
+
+	if err = at.Account.SubBalance(req.FromID, pool.Token0, amount0In); err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.Token1, amount1In); err != nil {
+		return err
+	}
+	if err = pool.FlashSwap(amount0Out, amount1Out, amount0In, amount1In); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+// flashLockKey length-prefixes the token names like poolKey, so that a flash swap locks only its own pool.
+func flashLockKey(token0, token1 udt.TokenID) []byte {
+	return []byte("flashlock/" + lengthPrefixed(token0, token1))
+}
@@ -0,0 +1,162 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/internal/memstate"
+	"github.com/yu-org/JingChou/script"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/env"
+	"github.com/yu-org/yu/core/types"
+)
+
+func newTestAmm(t *testing.T, vm script.VM) *AmmTripod {
+	chainEnv := &env.ChainEnv{State: memstate.New()}
+	u := udt.NewUdtTripod()
+	u.SetChainEnv(chainEnv)
+	acc := account.NewAccountTripod()
+	acc.SetChainEnv(chainEnv)
+	acc.UDT = u
+	st := script.NewScriptTripodWithVM(vm)
+	st.SetChainEnv(chainEnv)
+	acc.Script = st
+	at := NewAmmTripod(&Config{})
+	at.SetChainEnv(chainEnv)
+	at.Account, at.UDT, at.Script = acc, u, st
+
+	for _, id := range []string{"lp", "borrower"} {
+		chainEnv.State.Set(acc, []byte(id), mustJson(t, &account.Account{Owner: id}))
+	}
+	mustOk(t, acc.AddBalance("lp", "A", big.NewInt(1_000_000)))
+	mustOk(t, acc.AddBalance("lp", "B", big.NewInt(4_000_000)))
+	mustOk(t, acc.AddBalance("borrower", "A", big.NewInt(2_000)))
+	mustOk(t, at.CreatePool(writeCtx(t, 1, &CreatePoolRequest{FromID: "lp", TokenA: "A", TokenB: "B"})))
+	mustOk(t, at.AddLiquidity(writeCtx(t, 1, &AddLiquidityRequest{
+		FromID: "lp", TokenA: "A", TokenB: "B", AmountA: big.NewInt(1_000_000), AmountB: big.NewInt(4_000_000),
+	})))
+	_, err := chainEnv.State.Commit()
+	mustOk(t, err)
+	return at
+}
+
+func addScript(t *testing.T, at *AmmTripod, code string) string {
+	scpt := &script.Script{Type: script.Permanent, Code: []byte(code)}
+	mustOk(t, at.Script.AddScript(scpt))
+	id, err := scpt.Id()
+	mustOk(t, err)
+	return id
+}
+
+func TestFlashSwapCallback(t *testing.T) {
+	vm := script.NewNativeVM()
+	at := newTestAmm(t, vm)
+
+	var called bool
+	vm.Register("borrow", func(args []byte) (*script.VMResult, error) {
+		called = true
+		cb := new(FlashCallback)
+		if err := json.Unmarshal(args, cb); err != nil {
+			return nil, err
+		}
+		if cb.Borrower != "borrower" || cb.AmountAOut.Cmp(big.NewInt(4_000)) != 0 {
+			t.Errorf("unexpected callback %+v", cb)
+		}
+		borrowed, err := at.Account.GetBalance("borrower", "B")
+		if err != nil {
+			return nil, err
+		}
+		if borrowed.Cmp(big.NewInt(4_000)) != 0 {
+			t.Errorf("borrower has %s B in the callback, want 4000", borrowed)
+		}
+		// the pool can not be swapped against before it is repaid
+		err = at.Swap(writeCtx(t, 2, &SwapRequest{FromID: "borrower", TokenIn: "A", TokenOut: "B", AmountIn: big.NewInt(10)}))
+		if !errors.Is(err, ErrPoolLocked) {
+			t.Errorf("swap in the callback: %v, want ErrPoolLocked", err)
+		}
+		return &script.VMResult{}, nil
+	})
+	id := addScript(t, at, "borrow")
+
+	// borrow 4000 B and repay it in A
+	err := at.FlashSwap(writeCtx(t, 2, &FlashSwapRequest{
+		FromID:     "borrower",
+		TokenA:     "B",
+		TokenB:     "A",
+		AmountAOut: big.NewInt(4_000),
+		AmountBIn:  big.NewInt(1_010),
+		ScriptID:   id,
+	}))
+	mustOk(t, err)
+	if !called {
+		t.Fatal("flash swap did not run the callback")
+	}
+	pool, err := at.getPairPool("A", "B")
+	mustOk(t, err)
+	if pool.Reserve0.Cmp(big.NewInt(1_001_010)) != 0 || pool.Reserve1.Cmp(big.NewInt(3_996_000)) != 0 {
+		t.Fatalf("reserves %s/%s after the flash swap", pool.Reserve0, pool.Reserve1)
+	}
+	if at.Exist(flashLockKey("A", "B")) {
+		t.Fatal("pool is still locked after the flash swap")
+	}
+}
+
+func TestFlashSwapCallbackFails(t *testing.T) {
+	vm := script.NewNativeVM()
+	at := newTestAmm(t, vm)
+	vm.Register("refuse", func([]byte) (*script.VMResult, error) {
+		return &script.VMResult{Error: "no arbitrage"}, nil
+	})
+	id := addScript(t, at, "refuse")
+	err := at.State.(*memstate.State).Execute(func() error {
+		return at.FlashSwap(writeCtx(t, 2, &FlashSwapRequest{FromID: "borrower", TokenA: "B", TokenB: "A", AmountAOut: big.NewInt(10), ScriptID: id}))
+	})
+	if err == nil || err.Error() != "no arbitrage" {
+		t.Fatalf("flash swap with a failing callback: %v", err)
+	}
+	// the failed writing is discarded, the loan and the lock with it
+	borrowed, err := at.Account.GetBalance("borrower", "B")
+	mustOk(t, err)
+	if borrowed.Sign() != 0 || at.Exist(flashLockKey("A", "B")) {
+		t.Fatalf("borrower keeps %s B and the pool is locked: %v", borrowed, at.Exist(flashLockKey("A", "B")))
+	}
+	pool, err := at.getPairPool("A", "B")
+	mustOk(t, err)
+	if pool.Reserve0.Cmp(big.NewInt(1_000_000)) != 0 || pool.Reserve1.Cmp(big.NewInt(4_000_000)) != 0 {
+		t.Fatalf("reserves %s/%s after the failed flash swap", pool.Reserve0, pool.Reserve1)
+	}
+}
+
+func TestFlashSwapWithoutVM(t *testing.T) {
+	at := newTestAmm(t, nil)
+	id := addScript(t, at, "borrow")
+	err := at.FlashSwap(writeCtx(t, 2, &FlashSwapRequest{FromID: "borrower", TokenA: "B", TokenB: "A", AmountAOut: big.NewInt(10), ScriptID: id}))
+	if !errors.Is(err, script.ErrNoVM) {
+		t.Fatalf("flash swap without a vm: %v, want ErrNoVM", err)
+	}
+}
+
+func writeCtx(t *testing.T, height common.BlockNum, req any) *context.WriteContext {
+	params, err := context.NewParamsResponseFromStr(string(mustJson(t, req)))
+	mustOk(t, err)
+	block := &types.Block{Header: &types.Header{Height: height, Timestamp: uint64(height)}}
+	return &context.WriteContext{ParamsResponse: params, Block: block}
+}
+
+func mustJson(t *testing.T, v any) []byte {
+	byt, err := json.Marshal(v)
+	mustOk(t, err)
+	return byt
+}
+
+func mustOk(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+)
+
+// Depth returns how much of orderToken the pool of the pair sells to a buyer (buy is true),
+// or buys from a seller, before its marginal price in pricingToken per orderToken passes price, at most max.
+// A pair without a pool has no depth. With Trade it lets the orderbook match its orders against
+// the constant-product pools, see orderbook.Liquidity.
+func (at *AmmTripod) Depth(orderToken, pricingToken udt.TokenID, buy bool, price, max *big.Int) (*big.Int, error) {
+	pool, err := at.getPairPool(orderToken, pricingToken)
+	if errors.Is(err, ErrPoolNotFound) {
+		return big.NewInt(0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var depth *big.Int
+	if buy {
+		depth = pool.MaxOutAtPrice(pricingToken == pool.Token0, price)
+	} else {
+		depth = pool.MaxInAtPrice(orderToken == pool.Token0, price)
+	}
+	if depth == nil || depth.Cmp(max) > 0 {
+		return new(big.Int).Set(max), nil
+	}
+	return depth, nil
+}
+
+// Trade swaps amount of orderToken with the pool of the pair in the block at height.
+// A buy returns the pricingToken paid, at most limit; a sell returns the pricingToken received, at least limit.
+// The caller moves the tokens of the trader, the pool only updates its reserves.
+func (at *AmmTripod) Trade(orderToken, pricingToken udt.TokenID, buy bool, amount, limit *big.Int, height common.BlockNum) (*big.Int, error) {
+	pool, err := at.getPairPool(orderToken, pricingToken)
+	if err != nil {
+		return nil, err
+	}
+	if err = at.accumulate(pool, height); err != nil {
+		return nil, err
+	}
+	var quote *big.Int
+	if buy {
+		quote, err = pool.SwapExactOut(pricingToken == pool.Token0, amount, limit)
+	} else {
+		quote, err = pool.SwapExactIn(orderToken == pool.Token0, amount, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return quote, at.setPool(pool)
+}
@@ -0,0 +1,166 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+// MaxObservations bounds the observations kept per pool, so the longest TWAP window
+// covers the last MaxObservations blocks in which the pool was traded.
+const MaxObservations = 1024
+
+var ErrWindowTooLong = errors.New("not enough price observations for the window")
+
+// Observation is the cumulative price of a pool at the first trade of a block.
+// The price is constant between two observations, so the cumulative price of any
+// height between them is their linear interpolation.
+type Observation struct {
+	Height           common.BlockNum `json:"height"`
+	Price0Cumulative *big.Int        `json:"price0_cumulative"`
+	Price1Cumulative *big.Int        `json:"price1_cumulative"`
+}
+
+// Twap is the time-weighted average price of a pair over the blocks [From, To).
+type Twap struct {
+	TokenA udt.TokenID     `json:"token_a"`
+	TokenB udt.TokenID     `json:"token_b"`
+	From   common.BlockNum `json:"from"`
+	To     common.BlockNum `json:"to"`
+	// PriceAX112 is the price of TokenA in TokenB, PriceBX112 the price of TokenB in TokenA, both UQ112x112.
+	PriceAX112 *big.Int `json:"price_a_x112"`
+	PriceBX112 *big.Int `json:"price_b_x112"`
+}
+
+// TWAP returns the average price of tokenA and tokenB over the window blocks before height.
+// Other tripods use it as a price feed that a single block cannot move.
+func (at *AmmTripod) TWAP(tokenA, tokenB udt.TokenID, window, height common.BlockNum) (*Twap, error) {
+	if window == 0 || window > height {
+		return nil, errors.New("invalid twap window")
+	}
+	pool, err := at.getPairPool(tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+	from := height - window
+	start0, start1, err := at.cumulativeAt(pool, from)
+	if err != nil {
+		return nil, err
+	}
+	end0, end1, err := at.cumulativeAt(pool, height)
+	if err != nil {
+		return nil, err
+	}
+	blocks := new(big.Int).SetUint64(uint64(window))
+	price0 := new(big.Int).Div(end0.Sub(end0, start0), blocks)
+	price1 := new(big.Int).Div(end1.Sub(end1, start1), blocks)
+	if tokenA != pool.Token0 {
+		price0, price1 = price1, price0
+	}
+	return &Twap{
+		TokenA:     tokenA,
+		TokenB:     tokenB,
+		From:       from,
+		To:         height,
+		PriceAX112: price0,
+		PriceBX112: price1,
+	}, nil
+}
+
+// GetTwap returns the Twap of token_a and token_b over the last window blocks.
+func (at *AmmTripod) GetTwap(ctx *context.ReadContext) {
+	window, err := strconv.ParseUint(ctx.GetString("window"), 10, 64)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	block, err := at.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	twap, err := at.TWAP(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")), common.BlockNum(window), block.Height)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(twap)
+}
+
+// accumulate updates the cumulative prices of pool before its first trade in the block at height,
+// and records the observation.
+func (at *AmmTripod) accumulate(pool *Pool, height common.BlockNum) error {
+	if !pool.Update(height) {
+		return nil
+	}
+	observations, err := at.getObservations(pool)
+	if err != nil {
+		return err
+	}
+	observations = append(observations, &Observation{
+		Height:           height,
+		Price0Cumulative: new(big.Int).Set(pool.Price0CumulativeLast),
+		Price1Cumulative: new(big.Int).Set(pool.Price1CumulativeLast),
+	})
+	if len(observations) > MaxObservations {
+		observations = observations[len(observations)-MaxObservations:]
+	}
+	byt, err := json.Marshal(observations)
+	if err != nil {
+		return err
+	}
+	at.Set(observationsKey(pool.Token0, pool.Token1), byt)
+	return nil
+}
+
+// cumulativeAt returns the cumulative prices of pool at height.
+func (at *AmmTripod) cumulativeAt(pool *Pool, height common.BlockNum) (price0, price1 *big.Int, err error) {
+	if height >= pool.BlockHeightLast {
+		price0, price1 = pool.Cumulative(height)
+		return price0, price1, nil
+	}
+	observations, err := at.getObservations(pool)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the first observation after height
+	i := sort.Search(len(observations), func(i int) bool {
+		return observations[i].Height > height
+	})
+	if i == 0 || i == len(observations) {
+		return nil, nil, ErrWindowTooLong
+	}
+	prev, next := observations[i-1], observations[i]
+	elapsed := new(big.Int).SetUint64(uint64(height - prev.Height))
+	span := new(big.Int).SetUint64(uint64(next.Height - prev.Height))
+	interpolate := func(prevCumulative, nextCumulative *big.Int) *big.Int {
+		delta := new(big.Int).Sub(nextCumulative, prevCumulative)
+		delta.Mul(delta, elapsed)
+		delta.Div(delta, span)
+		return delta.Add(delta, prevCumulative)
+	}
+	return interpolate(prev.Price0Cumulative, next.Price0Cumulative), interpolate(prev.Price1Cumulative, next.Price1Cumulative), nil
+}
+
+// observationsKey length-prefixes the token names like poolKey, so that no two pools share their observations.
+func observationsKey(token0, token1 udt.TokenID) []byte {
+	return []byte("observations/" + lengthPrefixed(token0, token1))
+}
+
+func (at *AmmTripod) getObservations(pool *Pool) ([]*Observation, error) {
+	byt, err := at.Get(observationsKey(pool.Token0, pool.Token1))
+	if err != nil {
+		return nil, err
+	}
+	var observations []*Observation
+	if byt != nil {
+		err = json.Unmarshal(byt, &observations)
+	}
+	return observations, err
+}
@@ -0,0 +1,424 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+const (
+	DefaultMaxHops = 3
+	// MaxHops bounds the path search of QuoteBestPath.
+	MaxHops = 4
+)
+
+// SwapPathRequest swaps along Path, e.g. [A, JingChou, B], through the constant-product pool of every two adjacent tokens,
+// or through the stable pool StablePools[i] holding both for the hop from Path[i] where it is set.
+// It is an exact-in swap if AmountIn is set, or an exact-out swap if AmountOut is set.
+type SwapPathRequest struct {
+	FromID    string        `json:"from_id"`
+	OwnerArgs []byte        `json:"owner_args"`
+	Path      []udt.TokenID `json:"path"`
+	// StablePools holds the tokens of the stable pool of each hop, nil for a constant-product pool, see PathQuote.
+	StablePools [][]udt.TokenID `json:"stable_pools,omitempty"`
+
+	AmountIn     *big.Int `json:"amount_in,omitempty"`
+	MinAmountOut *big.Int `json:"min_amount_out,omitempty"`
+
+	AmountOut   *big.Int `json:"amount_out,omitempty"`
+	MaxAmountIn *big.Int `json:"max_amount_in,omitempty"`
+
+	// Deadline is the last block height the swap can be executed in, 0 means no deadline.
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) SwapPath(ctx *context.WriteContext) error {
+	req := new(SwapPathRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	if (req.AmountIn == nil) == (req.AmountOut == nil) {
+		return errors.New("exactly one of amount in and amount out must be set")
+	}
+	hops, err := at.getPathHops(req.Path, req.StablePools)
+	if err != nil {
+		return err
+	}
+	height := ctx.Block.Height
+	for _, hop := range hops {
+		if hop.pool == nil {
+			continue
+		}
+		if err = at.accumulate(hop.pool, height); err != nil {
+			return err
+		}
+	}
+
+	var amounts []*big.Int
+	if req.AmountIn != nil {
+		amounts, err = amountsOut(hops, req.AmountIn, height)
+		if err == nil && amounts[len(amounts)-1].Cmp(orZero(req.MinAmountOut)) < 0 {
+			err = ErrInsufficientOutput
+		}
+	} else {
+		if req.MaxAmountIn == nil {
+			return errors.New("max amount in must be set for an exact-out swap")
+		}
+		amounts, err = amountsIn(hops, req.AmountOut, height)
+		if err == nil && amounts[0].Cmp(req.MaxAmountIn) > 0 {
+			err = ErrExcessiveInput
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, hop := range hops {
+		if req.AmountIn != nil {
+			err = hop.swapExactIn(amounts[i], amounts[i+1], height)
+		} else {
+			err = hop.swapExactOut(amounts[i+1], amounts[i], height)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err = at.Account.SubBalance(req.FromID, req.Path[0], amounts[0]); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, req.Path[len(req.Path)-1], amounts[len(amounts)-1]); err != nil {
+		return err
+	}
+	for _, hop := range hops {
+		if hop.stable != nil {
+			err = at.setStablePool(hop.stable)
+		} else {
+			err = at.setPool(hop.pool)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type QuoteBestPathRequest struct {
+	TokenIn  udt.TokenID `json:"token_in"`
+	TokenOut udt.TokenID `json:"token_out"`
+	// exactly one of AmountIn and AmountOut must be set.
+	AmountIn  *big.Int `json:"amount_in,omitempty"`
+	AmountOut *big.Int `json:"amount_out,omitempty"`
+	// MaxHops is the most pools on the path, default DefaultMaxHops and at most MaxHops.
+	MaxHops int `json:"max_hops,omitempty"`
+}
+
+// PathQuote is a path with the amount of every token along it, its Path and StablePools can be swapped as they are
+// by SwapPath.
+type PathQuote struct {
+	Path        []udt.TokenID   `json:"path"`
+	StablePools [][]udt.TokenID `json:"stable_pools,omitempty"`
+	Amounts     []*big.Int      `json:"amounts"`
+}
+
+// QuoteBestPath returns the path with the most output for AmountIn, or the least input for AmountOut,
+// through both the constant-product and the stable pools.
+// Among equal quotes the shorter path wins, then the path found first: through constant-product pools before
+// stable pools, each in pool creation order.
+func (at *AmmTripod) QuoteBestPath(ctx *context.ReadContext) {
+	req := new(QuoteBestPathRequest)
+	if err := ctx.BindJson(req); err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	block, err := at.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	quote, err := at.quoteBestPath(req, block.Height)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(quote)
+}
+
+func (at *AmmTripod) quoteBestPath(req *QuoteBestPathRequest, height common.BlockNum) (*PathQuote, error) {
+	if (req.AmountIn == nil) == (req.AmountOut == nil) {
+		return nil, errors.New("exactly one of amount in and amount out must be set")
+	}
+	maxHops := req.MaxHops
+	if maxHops <= 0 {
+		maxHops = DefaultMaxHops
+	}
+	if maxHops > MaxHops {
+		maxHops = MaxHops
+	}
+	neighbors, err := at.getHops()
+	if err != nil {
+		return nil, err
+	}
+
+	var best *PathQuote
+	visited := map[udt.TokenID]bool{req.TokenIn: true}
+	used := make(map[udt.TokenID]bool)
+	var search func(path []udt.TokenID, hops []*pathHop)
+	search = func(path []udt.TokenID, hops []*pathHop) {
+		last := path[len(path)-1]
+		if last == req.TokenOut {
+			var (
+				amounts  []*big.Int
+				quoteErr error
+			)
+			if req.AmountIn != nil {
+				amounts, quoteErr = amountsOut(hops, req.AmountIn, height)
+			} else {
+				amounts, quoteErr = amountsIn(hops, req.AmountOut, height)
+			}
+			if quoteErr == nil && betterQuote(amounts, best, req.AmountIn != nil) {
+				best = &PathQuote{Path: append([]udt.TokenID(nil), path...), Amounts: amounts}
+				for i, hop := range hops {
+					if hop.stable == nil {
+						continue
+					}
+					if best.StablePools == nil {
+						best.StablePools = make([][]udt.TokenID, len(hops))
+					}
+					best.StablePools[i] = hop.stable.Tokens
+				}
+			}
+			return
+		}
+		if len(hops) >= maxHops {
+			return
+		}
+		for _, hop := range neighbors[last] {
+			if visited[hop.tokenOut] || used[hop.lpToken()] {
+				continue
+			}
+			visited[hop.tokenOut], used[hop.lpToken()] = true, true
+			search(append(path, hop.tokenOut), append(hops, hop))
+			visited[hop.tokenOut], used[hop.lpToken()] = false, false
+		}
+	}
+	search([]udt.TokenID{req.TokenIn}, nil)
+	if best == nil {
+		return nil, errors.New("no path found")
+	}
+	return best, nil
+}
+
+// getHops returns the hops out of every token, through the constant-product pools in creation order
+// and then through the stable pools in creation order.
+func (at *AmmTripod) getHops() (map[udt.TokenID][]*pathHop, error) {
+	pairs, err := at.getPairs()
+	if err != nil {
+		return nil, err
+	}
+	neighbors := make(map[udt.TokenID][]*pathHop)
+	for _, pair := range pairs {
+		pool, err := at.getPairPool(pair.Token0, pair.Token1)
+		if err != nil {
+			return nil, err
+		}
+		neighbors[pair.Token0] = append(neighbors[pair.Token0], &pathHop{tokenIn: pair.Token0, tokenOut: pair.Token1, pool: pool})
+		neighbors[pair.Token1] = append(neighbors[pair.Token1], &pathHop{tokenIn: pair.Token1, tokenOut: pair.Token0, pool: pool})
+	}
+	stablePools, err := at.getStablePools()
+	if err != nil {
+		return nil, err
+	}
+	for _, tokens := range stablePools {
+		pool, err := at.getStablePool(tokens)
+		if err != nil {
+			return nil, err
+		}
+		for i, tokenIn := range pool.Tokens {
+			for j, tokenOut := range pool.Tokens {
+				if i != j {
+					neighbors[tokenIn] = append(neighbors[tokenIn], &pathHop{tokenIn: tokenIn, tokenOut: tokenOut, stable: pool, i: i, j: j})
+				}
+			}
+		}
+	}
+	return neighbors, nil
+}
+
+func betterQuote(amounts []*big.Int, best *PathQuote, exactIn bool) bool {
+	if best == nil {
+		return true
+	}
+	var cmp int
+	if exactIn {
+		cmp = amounts[len(amounts)-1].Cmp(best.Amounts[len(best.Amounts)-1])
+	} else {
+		cmp = best.Amounts[0].Cmp(amounts[0])
+	}
+	if cmp != 0 {
+		return cmp > 0
+	}
+	return len(amounts) < len(best.Amounts)
+}
+
+// pathHop swaps tokenIn for tokenOut through a constant-product pool, or through the stable pool
+// whose i-th and j-th tokens they are.
+type pathHop struct {
+	tokenIn, tokenOut udt.TokenID
+	pool              *Pool
+	stable            *StablePool
+	i, j              int
+}
+
+func (h *pathHop) lpToken() udt.TokenID {
+	if h.stable != nil {
+		return h.stable.LPToken
+	}
+	return h.pool.LPToken
+}
+
+// amountOut quotes the output of amountIn, the stable pool at the amplification of the block height.
+func (h *pathHop) amountOut(amountIn *big.Int, height common.BlockNum) (*big.Int, error) {
+	var amountOut *big.Int
+	if h.stable != nil {
+		dy, _, err := h.stable.GetDy(h.i, h.j, amountIn, height)
+		if err != nil {
+			return nil, err
+		}
+		amountOut = dy
+	} else {
+		reserveIn, reserveOut := h.pool.reserves(h.tokenIn == h.pool.Token0)
+		amountOut = h.pool.GetAmountOut(amountIn, reserveIn, reserveOut)
+	}
+	if amountOut.Sign() == 0 {
+		return nil, ErrInsufficientOutput
+	}
+	return amountOut, nil
+}
+
+// amountIn quotes the input needed for amountOut.
+func (h *pathHop) amountIn(amountOut *big.Int, height common.BlockNum) (*big.Int, error) {
+	if h.stable != nil {
+		return h.stable.GetDx(h.i, h.j, amountOut, height)
+	}
+	reserveIn, reserveOut := h.pool.reserves(h.tokenIn == h.pool.Token0)
+	return h.pool.GetAmountIn(amountOut, reserveIn, reserveOut)
+}
+
+func (h *pathHop) swapExactIn(amountIn, minAmountOut *big.Int, height common.BlockNum) (err error) {
+	if h.stable != nil {
+		_, err = h.stable.SwapExactIn(h.i, h.j, amountIn, minAmountOut, height)
+	} else {
+		_, err = h.pool.SwapExactIn(h.tokenIn == h.pool.Token0, amountIn, minAmountOut)
+	}
+	return err
+}
+
+func (h *pathHop) swapExactOut(amountOut, maxAmountIn *big.Int, height common.BlockNum) (err error) {
+	if h.stable != nil {
+		_, err = h.stable.SwapExactOut(h.i, h.j, amountOut, maxAmountIn, height)
+	} else {
+		_, err = h.pool.SwapExactOut(h.tokenIn == h.pool.Token0, amountOut, maxAmountIn)
+	}
+	return err
+}
+
+// amountsOut returns the amount of every token along hops when swapping amountIn of the first token.
+func amountsOut(hops []*pathHop, amountIn *big.Int, height common.BlockNum) ([]*big.Int, error) {
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[0] = new(big.Int).Set(amountIn)
+	for i, hop := range hops {
+		amountOut, err := hop.amountOut(amounts[i], height)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i+1] = amountOut
+	}
+	return amounts, nil
+}
+
+// amountsIn returns the amount of every token along hops needed to receive amountOut of the last token.
+func amountsIn(hops []*pathHop, amountOut *big.Int, height common.BlockNum) ([]*big.Int, error) {
+	amounts := make([]*big.Int, len(hops)+1)
+	amounts[len(hops)] = new(big.Int).Set(amountOut)
+	for i := len(hops) - 1; i >= 0; i-- {
+		amountIn, err := hops[i].amountIn(amounts[i+1], height)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amountIn
+	}
+	return amounts, nil
+}
+
+// pairHops returns the hops along path through the constant-product pools, pools[i] is the pool of path[i] and path[i+1].
+func pairHops(pools []*Pool, path []udt.TokenID) []*pathHop {
+	hops := make([]*pathHop, len(pools))
+	for i, pool := range pools {
+		hops[i] = &pathHop{tokenIn: path[i], tokenOut: path[i+1], pool: pool}
+	}
+	return hops
+}
+
+// GetAmountsOut returns the amount of every token of path when swapping amountIn of path[0],
+// pools[i] is the pool of path[i] and path[i+1].
+func GetAmountsOut(pools []*Pool, path []udt.TokenID, amountIn *big.Int) ([]*big.Int, error) {
+	return amountsOut(pairHops(pools, path), amountIn, 0)
+}
+
+// GetAmountsIn returns the amount of every token of path needed to receive amountOut of the last token.
+func GetAmountsIn(pools []*Pool, path []udt.TokenID, amountOut *big.Int) ([]*big.Int, error) {
+	return amountsIn(pairHops(pools, path), amountOut, 0)
+}
+
+// getPathHops loads the pools along path, the stable pool stablePools[i] for the hop from path[i] where it is set.
+// A path must not go through the same pool twice.
+func (at *AmmTripod) getPathHops(path []udt.TokenID, stablePools [][]udt.TokenID) ([]*pathHop, error) {
+	if len(path) < 2 {
+		return nil, errors.New("path needs at least two tokens")
+	}
+	if len(stablePools) > len(path)-1 {
+		return nil, errors.New("more stable pools than hops on the path")
+	}
+	seen := make(map[udt.TokenID]bool)
+	hops := make([]*pathHop, 0, len(path)-1)
+	for i := 0; i < len(path)-1; i++ {
+		hop := &pathHop{tokenIn: path[i], tokenOut: path[i+1]}
+		if i < len(stablePools) && len(stablePools[i]) > 0 {
+			if path[i] == path[i+1] {
+				return nil, errors.New("identical tokens")
+			}
+			pool, err := at.getStablePool(stablePools[i])
+			if err != nil {
+				return nil, err
+			}
+			if hop.i, err = pool.Index(path[i]); err != nil {
+				return nil, err
+			}
+			if hop.j, err = pool.Index(path[i+1]); err != nil {
+				return nil, err
+			}
+			hop.stable = pool
+		} else {
+			pool, err := at.getPairPool(path[i], path[i+1])
+			if err != nil {
+				return nil, err
+			}
+			hop.pool = pool
+		}
+		if seen[hop.lpToken()] {
+			return nil, errors.New("path goes through a pool twice")
+		}
+		seen[hop.lpToken()] = true
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
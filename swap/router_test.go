@@ -0,0 +1,98 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/script"
+	"github.com/yu-org/JingChou/udt"
+)
+
+var bcd = []udt.TokenID{"B", "C", "D"}
+
+// newTestRouter adds to the A/B pool of newTestAmm a shallow B/C pool and a deep stable B/C/D pool.
+func newTestRouter(t *testing.T) *AmmTripod {
+	at := newTestAmm(t, script.NewNativeVM())
+	for _, token := range bcd {
+		mustOk(t, at.Account.AddBalance("lp", token, big.NewInt(2_000_000)))
+	}
+	mustOk(t, at.Account.AddBalance("borrower", "B", big.NewInt(10_000)))
+	mustOk(t, at.CreatePool(writeCtx(t, 1, &CreatePoolRequest{FromID: "lp", TokenA: "B", TokenB: "C"})))
+	mustOk(t, at.AddLiquidity(writeCtx(t, 1, &AddLiquidityRequest{
+		FromID: "lp", TokenA: "B", TokenB: "C", AmountA: big.NewInt(10_000), AmountB: big.NewInt(10_000),
+	})))
+	mustOk(t, at.CreateStablePool(writeCtx(t, 1, &CreateStablePoolRequest{FromID: "lp", Tokens: bcd, Amplification: 100})))
+	mustOk(t, at.AddStableLiquidity(writeCtx(t, 1, &AddStableLiquidityRequest{FromID: "lp", Tokens: bcd, Amounts: map[udt.TokenID]*big.Int{
+		"B": big.NewInt(1_000_000), "C": big.NewInt(1_000_000), "D": big.NewInt(1_000_000),
+	}})))
+	return at
+}
+
+func TestQuoteBestPathThroughStablePool(t *testing.T) {
+	at := newTestRouter(t)
+	tests := []struct {
+		name        string
+		req         *QuoteBestPathRequest
+		wantPath    []udt.TokenID
+		wantStables []bool
+	}{
+		{"exact in", &QuoteBestPathRequest{TokenIn: "B", TokenOut: "C", AmountIn: big.NewInt(500)}, []udt.TokenID{"B", "C"}, []bool{true}},
+		{"exact out", &QuoteBestPathRequest{TokenIn: "B", TokenOut: "C", AmountOut: big.NewInt(500)}, []udt.TokenID{"B", "C"}, []bool{true}},
+		{"both kinds", &QuoteBestPathRequest{TokenIn: "A", TokenOut: "D", AmountIn: big.NewInt(500)}, []udt.TokenID{"A", "B", "D"}, []bool{false, true}},
+		{"tiny amount", &QuoteBestPathRequest{TokenIn: "B", TokenOut: "C", AmountIn: big.NewInt(1)}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := at.quoteBestPath(tt.req, 2)
+			if tt.wantPath == nil {
+				if err == nil {
+					t.Fatalf("quoted %+v for an amount no pool can swap", quote)
+				}
+				return
+			}
+			mustOk(t, err)
+			if len(quote.Path) != len(tt.wantPath) {
+				t.Fatalf("quoted the path %v, want %v", quote.Path, tt.wantPath)
+			}
+			for i, token := range tt.wantPath {
+				if quote.Path[i] != token {
+					t.Fatalf("quoted the path %v, want %v", quote.Path, tt.wantPath)
+				}
+			}
+			for i, stable := range tt.wantStables {
+				if got := quote.StablePools != nil && quote.StablePools[i] != nil; got != stable {
+					t.Fatalf("hop %d through a stable pool: %v, want %v", i, got, stable)
+				}
+			}
+		})
+	}
+}
+
+func TestSwapPathThroughStablePool(t *testing.T) {
+	at := newTestRouter(t)
+	quote, err := at.quoteBestPath(&QuoteBestPathRequest{TokenIn: "A", TokenOut: "D", AmountIn: big.NewInt(500)}, 2)
+	mustOk(t, err)
+	mustOk(t, at.SwapPath(writeCtx(t, 2, &SwapPathRequest{
+		FromID: "borrower", Path: quote.Path, StablePools: quote.StablePools, AmountIn: big.NewInt(500), MinAmountOut: quote.Amounts[2],
+	})))
+	got, err := at.Account.GetBalance("borrower", "D")
+	mustOk(t, err)
+	if got.Cmp(quote.Amounts[2]) != 0 {
+		t.Fatalf("borrower received %s D, quoted %s", got, quote.Amounts[2])
+	}
+	pool, err := at.getStablePool(bcd)
+	mustOk(t, err)
+	wantB := new(big.Int).Add(big.NewInt(1_000_000), quote.Amounts[1])
+	wantD := new(big.Int).Sub(big.NewInt(1_000_000), quote.Amounts[2])
+	if pool.Balances[0].Cmp(wantB) != 0 || pool.Balances[2].Cmp(wantD) != 0 {
+		t.Fatalf("stable pool balances %v, want %s B and %s D", pool.Balances, wantB, wantD)
+	}
+
+	// the same stable pool can not take two hops of a path
+	err = at.SwapPath(writeCtx(t, 3, &SwapPathRequest{
+		FromID: "borrower", Path: []udt.TokenID{"B", "C", "D"}, StablePools: [][]udt.TokenID{bcd, bcd}, AmountIn: big.NewInt(100),
+	}))
+	if err == nil || err.Error() != "path goes through a pool twice" {
+		t.Fatalf("swapped through a stable pool twice: %v", err)
+	}
+}
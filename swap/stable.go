@@ -0,0 +1,407 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+)
+
+// --------------------------
+// StableSwap 池 (Curve 模型)
+// 不变量: A·n^n·Σx + D = A·D·n^n + D^(n+1) / (n^n·Πx)
+// A 越大，价格在 1:1 附近越平坦，适合锚定同一资产的 token（例如跨链桥过来的同一种 ETH token）。
+// 所有 token 按原始数量 1:1 计价，各 token 的最小单位必须相同
+// --------------------------
+const (
+	// AmpPrecision A 的精度，池子里保存的是 A * AmpPrecision，便于平滑地调整 A
+	AmpPrecision = 100
+	MaxA         = 1_000_000
+	// MaxAChange 一次调整 A 最多变为原来的 MaxAChange 倍或 1/MaxAChange
+	MaxAChange = 10
+	// MinRampBlocks 调整 A 至少要经过的区块数
+	MinRampBlocks  common.BlockNum = 100
+	MaxStableCoins                 = 8
+	// DefaultStableFee 默认手续费 0.04%，百万分之一为单位
+	DefaultStableFee uint32 = 400
+	// newtonIterations 牛顿迭代的最大次数
+	newtonIterations = 255
+)
+
+var (
+	ampPrecision = big.NewInt(AmpPrecision)
+
+	ErrNotConverged = errors.New("stableswap invariant did not converge")
+)
+
+type StablePool struct {
+	mu sync.Mutex
+	// Tokens 按字典序排列
+	Tokens   []udt.TokenID `json:"tokens"`
+	Balances []*big.Int    `json:"balances"`
+	// Fee 百万分之一为单位
+	Fee         uint32      `json:"fee"`
+	LPToken     udt.TokenID `json:"lp_token"`
+	TotalShares *big.Int    `json:"total_shares"`
+	// A 从 InitialA 在 [InitialAHeight, FutureAHeight] 区块间线性变为 FutureA，都乘了 AmpPrecision
+	InitialA       *big.Int        `json:"initial_a"`
+	FutureA        *big.Int        `json:"future_a"`
+	InitialAHeight common.BlockNum `json:"initial_a_height"`
+	FutureAHeight  common.BlockNum `json:"future_a_height"`
+}
+
+// StableLPTokenID 返回 StableSwap 池的 LP token 名称，tokens 已排序，与 LPTokenID 一样带上每个 token 名称的长度
+func StableLPTokenID(tokens []udt.TokenID) udt.TokenID {
+	return udt.TokenID("SLP-" + lengthPrefixed(tokens...))
+}
+
+// NewStablePool 创建一个空的 StableSwap 池，tokens 已排序且不重复，amp 不含精度
+func NewStablePool(tokens []udt.TokenID, amp uint64, fee uint32) (*StablePool, error) {
+	if len(tokens) < 2 || len(tokens) > MaxStableCoins {
+		return nil, errors.New("invalid number of tokens")
+	}
+	if amp == 0 || amp > MaxA {
+		return nil, errors.New("invalid amplification")
+	}
+	if fee >= FeePipsDenominator/2 {
+		return nil, errors.New("invalid pool fee")
+	}
+	balances := make([]*big.Int, len(tokens))
+	for i := range balances {
+		balances[i] = big.NewInt(0)
+	}
+	a := new(big.Int).Mul(new(big.Int).SetUint64(amp), ampPrecision)
+	return &StablePool{
+		Tokens:      append([]udt.TokenID(nil), tokens...),
+		Balances:    balances,
+		Fee:         fee,
+		LPToken:     StableLPTokenID(tokens),
+		TotalShares: big.NewInt(0),
+		InitialA:    a,
+		FutureA:     new(big.Int).Set(a),
+	}, nil
+}
+
+// Index 返回 token 在池子里的下标
+func (p *StablePool) Index(token udt.TokenID) (int, error) {
+	for i, t := range p.Tokens {
+		if t == token {
+			return i, nil
+		}
+	}
+	return 0, errors.New("token not in pool")
+}
+
+// A 返回 height 时的 A（含精度）
+func (p *StablePool) A(height common.BlockNum) *big.Int {
+	if height >= p.FutureAHeight || p.FutureAHeight <= p.InitialAHeight {
+		return new(big.Int).Set(p.FutureA)
+	}
+	if height <= p.InitialAHeight {
+		return new(big.Int).Set(p.InitialA)
+	}
+	// A = InitialA + (FutureA - InitialA) * (height - start) / (end - start)
+	elapsed := big.NewInt(int64(height - p.InitialAHeight))
+	duration := big.NewInt(int64(p.FutureAHeight - p.InitialAHeight))
+	delta := new(big.Int).Sub(p.FutureA, p.InitialA)
+	delta.Mul(delta, elapsed)
+	delta.Quo(delta, duration)
+	return delta.Add(delta, p.InitialA)
+}
+
+// RampA 从 height 开始把 A 在 endHeight 前线性调整到 futureA（不含精度）
+func (p *StablePool) RampA(height common.BlockNum, futureA uint64, endHeight common.BlockNum) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if endHeight < height+MinRampBlocks {
+		return errors.New("ramp too short")
+	}
+	if futureA == 0 || futureA > MaxA {
+		return errors.New("invalid amplification")
+	}
+	current := p.A(height)
+	future := new(big.Int).Mul(new(big.Int).SetUint64(futureA), ampPrecision)
+	maxChange := big.NewInt(MaxAChange)
+	if future.Cmp(new(big.Int).Mul(current, maxChange)) > 0 || new(big.Int).Mul(future, maxChange).Cmp(current) < 0 {
+		return errors.New("amplification change too large")
+	}
+	p.InitialA, p.FutureA = current, future
+	p.InitialAHeight, p.FutureAHeight = height, endHeight
+	return nil
+}
+
+// StopRampA 把 A 固定在 height 时的值
+func (p *StablePool) StopRampA(height common.BlockNum) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.A(height)
+	p.InitialA, p.FutureA = current, new(big.Int).Set(current)
+	p.InitialAHeight, p.FutureAHeight = height, height
+}
+
+// annOf 返回 A·n^n（含精度）
+func annOf(amp *big.Int, n int) *big.Int {
+	ann := new(big.Int).Set(amp)
+	nn := big.NewInt(int64(n))
+	for i := 0; i < n; i++ {
+		ann.Mul(ann, nn)
+	}
+	return ann
+}
+
+// GetD 用牛顿法求不变量 D
+func GetD(balances []*big.Int, amp *big.Int) (*big.Int, error) {
+	n := len(balances)
+	nn := big.NewInt(int64(n))
+	sum := big.NewInt(0)
+	for _, x := range balances {
+		if x.Sign() == 0 {
+			return big.NewInt(0), nil
+		}
+		sum.Add(sum, x)
+	}
+	ann := annOf(amp, n)
+	d := new(big.Int).Set(sum)
+	for i := 0; i < newtonIterations; i++ {
+		// dP = D^(n+1) / (n^n·Πx)
+		dP := new(big.Int).Set(d)
+		for _, x := range balances {
+			dP.Mul(dP, d)
+			dP.Div(dP, new(big.Int).Mul(x, nn))
+		}
+		prev := d
+		// D = (Ann·S/AP + n·dP)·D / ((Ann - AP)·D/AP + (n+1)·dP)
+		num := new(big.Int).Div(new(big.Int).Mul(ann, sum), ampPrecision)
+		num.Add(num, new(big.Int).Mul(dP, nn))
+		num.Mul(num, d)
+		den := new(big.Int).Mul(new(big.Int).Sub(ann, ampPrecision), d)
+		den.Div(den, ampPrecision)
+		den.Add(den, new(big.Int).Mul(dP, big.NewInt(int64(n+1))))
+		d = num.Div(num, den)
+		if diff := new(big.Int).Sub(d, prev); diff.CmpAbs(ONE) <= 0 {
+			return d, nil
+		}
+	}
+	return nil, ErrNotConverged
+}
+
+// GetY 把第 i 个 token 的余额设为 x 后，保持 D 不变时第 j 个 token 的余额
+func GetY(i, j int, x *big.Int, balances []*big.Int, amp, d *big.Int) (*big.Int, error) {
+	n := len(balances)
+	if i == j || i < 0 || j < 0 || i >= n || j >= n {
+		return nil, errors.New("invalid token index")
+	}
+	nn := big.NewInt(int64(n))
+	ann := annOf(amp, n)
+	c := new(big.Int).Set(d)
+	sum := big.NewInt(0)
+	for k := 0; k < n; k++ {
+		if k == j {
+			continue
+		}
+		xk := balances[k]
+		if k == i {
+			xk = x
+		}
+		if xk.Sign() <= 0 {
+			return nil, ErrInsufficient
+		}
+		sum.Add(sum, xk)
+		c.Mul(c, d)
+		c.Div(c, new(big.Int).Mul(xk, nn))
+	}
+	// c = D^(n+1)·AP / (n^n·Πx·Ann)，b = S + D·AP/Ann
+	c.Mul(c, d)
+	c.Mul(c, ampPrecision)
+	c.Div(c, new(big.Int).Mul(ann, nn))
+	b := new(big.Int).Mul(d, ampPrecision)
+	b.Div(b, ann)
+	b.Add(b, sum)
+
+	y := new(big.Int).Set(d)
+	for it := 0; it < newtonIterations; it++ {
+		prev := y
+		// y = (y^2 + c) / (2y + b - D)
+		num := new(big.Int).Mul(y, y)
+		num.Add(num, c)
+		den := new(big.Int).Lsh(y, 1)
+		den.Add(den, b)
+		den.Sub(den, d)
+		if den.Sign() <= 0 {
+			return nil, ErrInsufficient
+		}
+		y = num.Div(num, den)
+		if diff := new(big.Int).Sub(y, prev); diff.CmpAbs(ONE) <= 0 {
+			return y, nil
+		}
+	}
+	return nil, ErrNotConverged
+}
+
+// GetDy 返回输入 dx 个第 i 个 token 能换出的第 j 个 token 数量（已扣手续费），以及手续费
+func (p *StablePool) GetDy(i, j int, dx *big.Int, height common.BlockNum) (dy, fee *big.Int, err error) {
+	amp := p.A(height)
+	d, err := GetD(p.Balances, amp)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := GetY(i, j, new(big.Int).Add(p.Balances[i], dx), p.Balances, amp, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 多减 1 防止取整误差让池子吃亏
+	dy = new(big.Int).Sub(p.Balances[j], y)
+	dy.Sub(dy, ONE)
+	if dy.Sign() <= 0 {
+		return big.NewInt(0), big.NewInt(0), nil
+	}
+	fee = new(big.Int).Mul(dy, big.NewInt(int64(p.Fee)))
+	fee.Div(fee, feePipsDen)
+	return dy.Sub(dy, fee), fee, nil
+}
+
+// GetDx 返回换出 dy 个第 j 个 token（扣手续费后）需要输入的第 i 个 token 数量，向上取整
+func (p *StablePool) GetDx(i, j int, dy *big.Int, height common.BlockNum) (*big.Int, error) {
+	amp := p.A(height)
+	d, err := GetD(p.Balances, amp)
+	if err != nil {
+		return nil, err
+	}
+	// 扣手续费前的输出
+	dyWithFee := ceilDiv(new(big.Int).Mul(dy, feePipsDen), new(big.Int).Sub(feePipsDen, big.NewInt(int64(p.Fee))))
+	dyWithFee.Add(dyWithFee, ONE)
+	y := new(big.Int).Sub(p.Balances[j], dyWithFee)
+	if y.Sign() <= 0 {
+		return nil, ErrInsufficient
+	}
+	x, err := GetY(j, i, y, p.Balances, amp, d)
+	if err != nil {
+		return nil, err
+	}
+	dx := new(big.Int).Sub(x, p.Balances[i])
+	return dx.Add(dx, ONE), nil
+}
+
+// SwapExactIn 输入 dx 个第 i 个 token，至少换出 minDy 个第 j 个 token；手续费留在池子里归 LP
+func (p *StablePool) SwapExactIn(i, j int, dx, minDy *big.Int, height common.BlockNum) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if dx.Sign() <= 0 {
+		return nil, errors.New("amount in must be positive")
+	}
+	dy, _, err := p.GetDy(i, j, dx, height)
+	if err != nil {
+		return nil, err
+	}
+	if dy.Sign() == 0 || dy.Cmp(minDy) < 0 {
+		return nil, ErrInsufficientOutput
+	}
+	p.Balances[i].Add(p.Balances[i], dx)
+	p.Balances[j].Sub(p.Balances[j], dy)
+	return dy, nil
+}
+
+// SwapExactOut 换出 dy 个第 j 个 token，最多输入 maxDx 个第 i 个 token，返回实际输入数量
+func (p *StablePool) SwapExactOut(i, j int, dy, maxDx *big.Int, height common.BlockNum) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if dy.Sign() <= 0 {
+		return nil, errors.New("amount out must be positive")
+	}
+	dx, err := p.GetDx(i, j, dy, height)
+	if err != nil {
+		return nil, err
+	}
+	if dx.Cmp(maxDx) > 0 {
+		return nil, ErrExcessiveInput
+	}
+	p.Balances[i].Add(p.Balances[i], dx)
+	p.Balances[j].Sub(p.Balances[j], dy)
+	return dx, nil
+}
+
+// AddLiquidity 按任意比例注入 amounts，返回新增份额。
+// 首次注入必须包含所有 token，份额等于 D；之后偏离池子比例的部分按 Fee·n/(4(n-1)) 收取手续费，手续费留在池子里归 LP
+func (p *StablePool) AddLiquidity(amounts []*big.Int, minShares *big.Int, height common.BlockNum) (*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.Balances)
+	if len(amounts) != n {
+		return nil, errors.New("wrong number of amounts")
+	}
+	amp := p.A(height)
+	d0, err := GetD(p.Balances, amp)
+	if err != nil {
+		return nil, err
+	}
+	newBalances := make([]*big.Int, n)
+	for k, amount := range amounts {
+		if amount.Sign() < 0 || (p.TotalShares.Sign() == 0 && amount.Sign() == 0) {
+			return nil, errors.New("invalid liquidity amount")
+		}
+		newBalances[k] = new(big.Int).Add(p.Balances[k], amount)
+	}
+	d1, err := GetD(newBalances, amp)
+	if err != nil {
+		return nil, err
+	}
+	if d1.Cmp(d0) <= 0 {
+		return nil, errors.New("insufficient liquidity minted")
+	}
+
+	shares := d1
+	if p.TotalShares.Sign() > 0 {
+		feeBalances := make([]*big.Int, n)
+		for k := range newBalances {
+			fee := imbalanceFee(p.Fee, n, d0, d1, p.Balances[k], newBalances[k])
+			feeBalances[k] = new(big.Int).Sub(newBalances[k], fee)
+		}
+		d2, err := GetD(feeBalances, amp)
+		if err != nil {
+			return nil, err
+		}
+		// shares = total * (D2 - D0) / D0
+		shares = new(big.Int).Sub(d2, d0)
+		shares.Mul(shares, p.TotalShares)
+		shares.Div(shares, d0)
+	}
+	if shares.Sign() <= 0 || shares.Cmp(minShares) < 0 {
+		return nil, errors.New("insufficient liquidity minted")
+	}
+	p.Balances = newBalances
+	p.TotalShares.Add(p.TotalShares, shares)
+	return shares, nil
+}
+
+// imbalanceFee 第 k 个 token 偏离理想余额 D1·old/D0 部分的手续费
+func imbalanceFee(feePips uint32, n int, d0, d1, oldBalance, newBalance *big.Int) *big.Int {
+	ideal := new(big.Int).Mul(d1, oldBalance)
+	ideal.Div(ideal, d0)
+	diff := ideal.Sub(ideal, newBalance)
+	diff.Abs(diff)
+	fee := diff.Mul(diff, big.NewInt(int64(feePips)*int64(n)))
+	return fee.Div(fee, big.NewInt(4*int64(n-1)*FeePipsDenominator))
+}
+
+// RemoveLiquidity 销毁份额，按比例取回所有 token: amount = shares * balance / total
+func (p *StablePool) RemoveLiquidity(shares *big.Int, minAmounts []*big.Int) ([]*big.Int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if shares.Sign() <= 0 || shares.Cmp(p.TotalShares) > 0 {
+		return nil, ErrInsufficient
+	}
+	amounts := make([]*big.Int, len(p.Balances))
+	for k, balance := range p.Balances {
+		amounts[k] = new(big.Int).Div(new(big.Int).Mul(shares, balance), p.TotalShares)
+		if k < len(minAmounts) && amounts[k].Cmp(minAmounts[k]) < 0 {
+			return nil, ErrInsufficientOutput
+		}
+	}
+	for k, amount := range amounts {
+		p.Balances[k].Sub(p.Balances[k], amount)
+	}
+	p.TotalShares.Sub(p.TotalShares, shares)
+	return amounts, nil
+}
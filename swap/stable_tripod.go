@@ -0,0 +1,408 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+)
+
+// SortStableTokens returns the tokens of a stable pool in pool order.
+func SortStableTokens(tokens []udt.TokenID) ([]udt.TokenID, error) {
+	sorted := append([]udt.TokenID(nil), tokens...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, errors.New("identical tokens")
+		}
+	}
+	return sorted, nil
+}
+
+type CreateStablePoolRequest struct {
+	FromID    string        `json:"from_id"`
+	OwnerArgs []byte        `json:"owner_args"`
+	Tokens    []udt.TokenID `json:"tokens"`
+	// Amplification is the initial A, without AmpPrecision.
+	Amplification uint64 `json:"amplification"`
+	// Fee defaults to DefaultStableFee.
+	Fee *uint32 `json:"fee,omitempty"`
+}
+
+func (at *AmmTripod) CreateStablePool(ctx *context.WriteContext) error {
+	req := new(CreateStablePoolRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	tokens, err := SortStableTokens(req.Tokens)
+	if err != nil {
+		return err
+	}
+	if at.Exist(stablePoolKey(tokens)) {
+		return ErrPoolExists
+	}
+	fee := DefaultStableFee
+	if req.Fee != nil {
+		fee = *req.Fee
+	}
+	pool, err := NewStablePool(tokens, req.Amplification, fee)
+	if err != nil {
+		return err
+	}
+	if at.UDT.Exist([]byte(pool.LPToken)) {
+		return ErrLPTokenTaken
+	}
+	err = at.UDT.AddUdt(&udt.UDT{
+		Name:        pool.LPToken,
+		Creator:     at.Name(),
+		Description: "liquidity shares of " + strings.Join(tokenNames(tokens), "/") + " stable pool",
+		Total:       big.NewInt(0),
+		Locked:      big.NewInt(0),
+		Issued:      big.NewInt(0),
+	})
+	if err != nil {
+		return err
+	}
+	if err = at.addStablePool(tokens); err != nil {
+		return err
+	}
+	return at.setStablePool(pool)
+}
+
+// SwapStableRequest is an exact-in swap if AmountIn is set, or an exact-out swap if AmountOut is set.
+type SwapStableRequest struct {
+	FromID    string        `json:"from_id"`
+	OwnerArgs []byte        `json:"owner_args"`
+	Tokens    []udt.TokenID `json:"tokens"`
+	TokenIn   udt.TokenID   `json:"token_in"`
+	TokenOut  udt.TokenID   `json:"token_out"`
+
+	AmountIn     *big.Int `json:"amount_in,omitempty"`
+	MinAmountOut *big.Int `json:"min_amount_out,omitempty"`
+
+	AmountOut   *big.Int `json:"amount_out,omitempty"`
+	MaxAmountIn *big.Int `json:"max_amount_in,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) SwapStable(ctx *context.WriteContext) error {
+	req := new(SwapStableRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	if (req.AmountIn == nil) == (req.AmountOut == nil) {
+		return errors.New("exactly one of amount in and amount out must be set")
+	}
+	pool, err := at.getStablePool(req.Tokens)
+	if err != nil {
+		return err
+	}
+	i, err := pool.Index(req.TokenIn)
+	if err != nil {
+		return err
+	}
+	j, err := pool.Index(req.TokenOut)
+	if err != nil {
+		return err
+	}
+
+	amountIn, amountOut := req.AmountIn, req.AmountOut
+	if amountIn != nil {
+		amountOut, err = pool.SwapExactIn(i, j, amountIn, orZero(req.MinAmountOut), ctx.Block.Height)
+	} else {
+		if req.MaxAmountIn == nil {
+			return errors.New("max amount in must be set for an exact-out swap")
+		}
+		amountIn, err = pool.SwapExactOut(i, j, amountOut, req.MaxAmountIn, ctx.Block.Height)
+	}
+	if err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, req.TokenIn, amountIn); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, req.TokenOut, amountOut); err != nil {
+		return err
+	}
+	return at.setStablePool(pool)
+}
+
+// AddStableLiquidityRequest deposits Amounts in any ratio, a token missing from Amounts deposits nothing.
+// The first deposit must include every token of the pool.
+type AddStableLiquidityRequest struct {
+	FromID    string                   `json:"from_id"`
+	OwnerArgs []byte                   `json:"owner_args"`
+	Tokens    []udt.TokenID            `json:"tokens"`
+	Amounts   map[udt.TokenID]*big.Int `json:"amounts"`
+	MinShares *big.Int                 `json:"min_shares,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) AddStableLiquidity(ctx *context.WriteContext) error {
+	req := new(AddStableLiquidityRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	pool, err := at.getStablePool(req.Tokens)
+	if err != nil {
+		return err
+	}
+	amounts, err := pool.amountsOf(req.Amounts)
+	if err != nil {
+		return err
+	}
+	shares, err := pool.AddLiquidity(amounts, orZero(req.MinShares), ctx.Block.Height)
+	if err != nil {
+		return err
+	}
+	for k, amount := range amounts {
+		if err = at.Account.SubBalance(req.FromID, pool.Tokens[k], amount); err != nil {
+			return err
+		}
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.LPToken, shares); err != nil {
+		return err
+	}
+	if err = at.updateLPSupply(pool.LPToken, pool.TotalShares); err != nil {
+		return err
+	}
+	return at.setStablePool(pool)
+}
+
+type RemoveStableLiquidityRequest struct {
+	FromID     string                   `json:"from_id"`
+	OwnerArgs  []byte                   `json:"owner_args"`
+	Tokens     []udt.TokenID            `json:"tokens"`
+	Shares     *big.Int                 `json:"shares"`
+	MinAmounts map[udt.TokenID]*big.Int `json:"min_amounts,omitempty"`
+
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) RemoveStableLiquidity(ctx *context.WriteContext) error {
+	req := new(RemoveStableLiquidityRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	if req.Shares == nil || req.Shares.Sign() <= 0 {
+		return errors.New("shares must be positive")
+	}
+	pool, err := at.getStablePool(req.Tokens)
+	if err != nil {
+		return err
+	}
+	minAmounts, err := pool.amountsOf(req.MinAmounts)
+	if err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.LPToken, req.Shares); err != nil {
+		return err
+	}
+	amounts, err := pool.RemoveLiquidity(req.Shares, minAmounts)
+	if err != nil {
+		return err
+	}
+	for k, amount := range amounts {
+		if err = at.Account.AddBalance(req.FromID, pool.Tokens[k], amount); err != nil {
+			return err
+		}
+	}
+	if err = at.updateLPSupply(pool.LPToken, pool.TotalShares); err != nil {
+		return err
+	}
+	return at.setStablePool(pool)
+}
+
+// RampARequest changes the amplification of a stable pool linearly until EndHeight.
+// Only the governor can ramp, see Config.
+type RampARequest struct {
+	GovernorArgs []byte          `json:"governor_args"`
+	Tokens       []udt.TokenID   `json:"tokens"`
+	FutureA      uint64          `json:"future_a"`
+	EndHeight    common.BlockNum `json:"end_height"`
+}
+
+func (at *AmmTripod) RampA(ctx *context.WriteContext) error {
+	req := new(RampARequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.verifyGovernor(req.GovernorArgs); err != nil {
+		return err
+	}
+	pool, err := at.getStablePool(req.Tokens)
+	if err != nil {
+		return err
+	}
+	if err = pool.RampA(ctx.Block.Height, req.FutureA, req.EndHeight); err != nil {
+		return err
+	}
+	return at.setStablePool(pool)
+}
+
+// StopRampA keeps the amplification of a stable pool at its current value.
+func (at *AmmTripod) StopRampA(ctx *context.WriteContext) error {
+	req := new(RampARequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.verifyGovernor(req.GovernorArgs); err != nil {
+		return err
+	}
+	pool, err := at.getStablePool(req.Tokens)
+	if err != nil {
+		return err
+	}
+	pool.StopRampA(ctx.Block.Height)
+	return at.setStablePool(pool)
+}
+
+// StablePoolInfo is the view of a stable pool returned by GetStablePool.
+type StablePoolInfo struct {
+	*StablePool
+	// A is the amplification at the current block, with AmpPrecision.
+	A *big.Int `json:"a"`
+}
+
+// GetStablePool takes the tokens of the pool as a comma separated list.
+func (at *AmmTripod) GetStablePool(ctx *context.ReadContext) {
+	var tokens []udt.TokenID
+	for _, name := range strings.Split(ctx.GetString("tokens"), ",") {
+		tokens = append(tokens, udt.TokenID(name))
+	}
+	pool, err := at.getStablePool(tokens)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	block, err := at.GetCurrentBlock()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(&StablePoolInfo{StablePool: pool, A: pool.A(block.Height)})
+}
+
+// amountsOf lays out amounts by token in pool order, a missing token is zero.
+func (p *StablePool) amountsOf(amounts map[udt.TokenID]*big.Int) ([]*big.Int, error) {
+	laid := make([]*big.Int, len(p.Tokens))
+	for k, token := range p.Tokens {
+		laid[k] = new(big.Int).Set(orZero(amounts[token]))
+	}
+	for token := range amounts {
+		if _, err := p.Index(token); err != nil {
+			return nil, err
+		}
+	}
+	return laid, nil
+}
+
+func (at *AmmTripod) verifyGovernor(args []byte) error {
+	if at.cfg == nil || at.cfg.Governor == "" {
+		return errors.New("no governor configured")
+	}
+	return at.Account.VerifyOwner(at.cfg.Governor, args)
+}
+
+func tokenNames(tokens []udt.TokenID) []string {
+	names := make([]string, len(tokens))
+	for i, token := range tokens {
+		names[i] = string(token)
+	}
+	return names
+}
+
+// stablePoolsKey keeps the sorted tokens of all stable pools in creation order, the router searches paths over it
+// after the pairs of the constant-product pools.
+var stablePoolsKey = []byte("stablepools")
+
+func (at *AmmTripod) getStablePools() ([][]udt.TokenID, error) {
+	byt, err := at.Get(stablePoolsKey)
+	if err != nil {
+		return nil, err
+	}
+	pools := make([][]udt.TokenID, 0)
+	if byt != nil {
+		err = json.Unmarshal(byt, &pools)
+	}
+	return pools, err
+}
+
+func (at *AmmTripod) addStablePool(tokens []udt.TokenID) error {
+	pools, err := at.getStablePools()
+	if err != nil {
+		return err
+	}
+	byt, err := json.Marshal(append(pools, tokens))
+	if err != nil {
+		return err
+	}
+	at.Set(stablePoolsKey, byt)
+	return nil
+}
+
+// stablePoolKey length-prefixes the token names like poolKey, so that the pools of ("a/b","c") and ("a","b/c") have distinct keys.
+func stablePoolKey(tokens []udt.TokenID) []byte {
+	return []byte("stablepool/" + lengthPrefixed(tokens...))
+}
+
+// getStablePool loads the stable pool of tokens given in any order, it must be the pool of exactly these tokens.
+func (at *AmmTripod) getStablePool(tokens []udt.TokenID) (*StablePool, error) {
+	sorted, err := SortStableTokens(tokens)
+	if err != nil {
+		return nil, err
+	}
+	byt, err := at.Get(stablePoolKey(sorted))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, ErrPoolNotFound
+	}
+	pool := new(StablePool)
+	if err = json.Unmarshal(byt, pool); err != nil {
+		return nil, err
+	}
+	if !slices.Equal(pool.Tokens, sorted) {
+		return nil, ErrPoolNotFound
+	}
+	return pool, nil
+}
+
+func (at *AmmTripod) setStablePool(pool *StablePool) error {
+	byt, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+	at.Set(stablePoolKey(pool.Tokens), byt)
+	return nil
+}
@@ -0,0 +1,91 @@
+package swap
+
+import (
+	"errors"
+	"math/big"
+)
+
+// --------------------------
+// tick 与价格换算
+// 价格 price = 1.0001^tick，池子记录的是 sqrtPriceX96 = sqrt(price) * 2^96，
+// 与 Uniswap V3 的 TickMath 结果逐位一致，只用整数运算，保证各节点结果相同
+// --------------------------
+const (
+	MinTick int32 = -887272
+	MaxTick int32 = -MinTick
+)
+
+var (
+	Q96  = new(big.Int).Lsh(ONE, 96)
+	Q128 = new(big.Int).Lsh(ONE, 128)
+	// MinSqrtRatio 是 MinTick 的 sqrtPriceX96
+	MinSqrtRatio = big.NewInt(4295128739)
+	// MaxSqrtRatio 是 MaxTick 的 sqrtPriceX96
+	MaxSqrtRatio, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+
+	ErrTickOutOfRange  = errors.New("tick out of range")
+	ErrPriceOutOfRange = errors.New("sqrt price out of range")
+
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(ONE, 256), ONE)
+	// tickFactors[i] = 2^128 / sqrt(1.0001)^(2^i)
+	tickFactors = hexInts(
+		"fffcb933bd6fad37aa2d162d1a594001", "fff97272373d413259a46990580e213a", "fff2e50f5f656932ef12357cf3c7fdcc",
+		"ffe5caca7e10e4e61c3624eaa0941cd0", "ffcb9843d60f6159c9db58835c926644", "ff973b41fa98c081472e6896dfb254c0",
+		"ff2ea16466c96a3843ec78b326b52861", "fe5dee046a99a2a811c461f1969c3053", "fcbe86c7900a88aedcffc83b479aa3a4",
+		"f987a7253ac413176f2b074cf7815e54", "f3392b0822b70005940c7a398e4b70f3", "e7159475a2c29b7443b29c7fa6e889d9",
+		"d097f3bdfd2022b8845ad8f792aa5825", "a9f746462d870fdf8a65dc1f90e061e5", "70d869a156d2a1b890bb3df62baf32f7",
+		"31be135f97d08fd981231505542fcfa6", "9aa508b5b7a84e1c677de54f3e99bc9", "5d6af8dedb81196699c329225ee604",
+		"2216e584f5fa1ea926041bedfe98", "48a170391f7dc42444e8fa2",
+	)
+)
+
+func hexInts(hexes ...string) []*big.Int {
+	ints := make([]*big.Int, len(hexes))
+	for i, h := range hexes {
+		ints[i], _ = new(big.Int).SetString(h, 16)
+	}
+	return ints
+}
+
+// GetSqrtRatioAtTick 返回 sqrt(1.0001^tick) * 2^96，向上取整
+func GetSqrtRatioAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, ErrTickOutOfRange
+	}
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	ratio := new(big.Int).Set(Q128)
+	for i, factor := range tickFactors {
+		if absTick&(1<<i) != 0 {
+			ratio.Mul(ratio, factor)
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Div(maxUint256, ratio)
+	}
+	// Q128.128 -> Q64.96，向上取整
+	return ceilDiv(ratio, new(big.Int).Lsh(ONE, 32)), nil
+}
+
+// GetTickAtSqrtRatio 返回满足 GetSqrtRatioAtTick(tick) <= sqrtPriceX96 的最大 tick，
+// sqrtPriceX96 必须在 [MinSqrtRatio, MaxSqrtRatio) 内
+func GetTickAtSqrtRatio(sqrtPriceX96 *big.Int) (int32, error) {
+	if sqrtPriceX96.Cmp(MinSqrtRatio) < 0 || sqrtPriceX96.Cmp(MaxSqrtRatio) >= 0 {
+		return 0, ErrPriceOutOfRange
+	}
+	// GetSqrtRatioAtTick 单调递增，二分查找
+	lo, hi := MinTick, MaxTick
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+		ratio, _ := GetSqrtRatioAtTick(mid)
+		if ratio.Cmp(sqrtPriceX96) <= 0 {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
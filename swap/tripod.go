@@ -0,0 +1,422 @@
+package swap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/yu-org/JingChou/account"
+	"github.com/yu-org/JingChou/script"
+	"github.com/yu-org/JingChou/udt"
+	"github.com/yu-org/yu/common"
+	"github.com/yu-org/yu/core/context"
+	"github.com/yu-org/yu/core/tripod"
+)
+
+var (
+	ErrPoolNotFound = errors.New("pool not found")
+	ErrPoolExists   = errors.New("pool already exists")
+	ErrLPTokenTaken = errors.New("lp token name is taken by an existing udt")
+)
+
+// AmmTripod keeps the constant-product pools in the chain state, keyed by their sorted token pair,
+// the concentrated liquidity pools keyed by their sorted token pair and fee tier,
+// and the stable pools keyed by their sorted tokens.
+// The reserves are real balances moved out of and into accounts through the AccountTripod,
+// liquidity providers hold the shares of a pool as its LP token.
+// Swap, AddLiquidity and RemoveLiquidity act on the constant-product pools, each pool type has writings of its own;
+// SwapPath and QuoteBestPath route through both the constant-product and the stable pools, the concentrated pools
+// are only swapped against by SwapConcentrated.
+type AmmTripod struct {
+	*tripod.Tripod
+
+	UDT     *udt.UdtTripod         `tripod:"udt"`
+	Account *account.AccountTripod `tripod:"account"`
+	Script  *script.ScriptTripod   `tripod:"script"`
+
+	cfg *Config
+}
+
+func NewAmmTripod(cfg *Config) *AmmTripod {
+	at := &AmmTripod{
+		Tripod: tripod.NewTripodWithName("amm"),
+		cfg:    cfg,
+	}
+	at.SetWritings(
+		at.CreatePool, at.Swap, at.SwapPath, at.AddLiquidity, at.RemoveLiquidity, at.SetPoolFee, at.FlashSwap,
+		at.CreateConcentratedPool, at.SwapConcentrated, at.MintPosition, at.BurnPosition, at.CollectFees,
+		at.CreateStablePool, at.SwapStable, at.AddStableLiquidity, at.RemoveStableLiquidity, at.RampA, at.StopRampA,
+	)
+	at.SetReadings(
+		at.GetPool, at.GetPools, at.GetShares, at.QuoteBestPath, at.GetConcentratedPool, at.GetPosition,
+		at.GetStablePool, at.GetTwap, at.GetPoolStats,
+	)
+	return at
+}
+
+// SortTokens returns the two tokens of a pair in pool order.
+func SortTokens(tokenA, tokenB udt.TokenID) (token0, token1 udt.TokenID, err error) {
+	if tokenA == tokenB {
+		return "", "", errors.New("identical tokens")
+	}
+	if tokenA < tokenB {
+		return tokenA, tokenB, nil
+	}
+	return tokenB, tokenA, nil
+}
+
+type CreatePoolRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	// FeeNum and FeeDen default to DefaultFeeNum and DefaultFeeDen.
+	FeeNum *big.Int `json:"fee_num,omitempty"`
+	FeeDen *big.Int `json:"fee_den,omitempty"`
+}
+
+func (at *AmmTripod) CreatePool(ctx *context.WriteContext) error {
+	req := new(CreatePoolRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	token0, token1, err := SortTokens(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if at.Exist(poolKey(token0, token1)) {
+		return ErrPoolExists
+	}
+	feeNum, feeDen := DefaultFeeNum, DefaultFeeDen
+	if req.FeeNum != nil || req.FeeDen != nil {
+		if req.FeeNum == nil || req.FeeDen == nil || req.FeeNum.Sign() <= 0 || req.FeeNum.Cmp(req.FeeDen) > 0 {
+			return errors.New("invalid pool fee")
+		}
+		feeNum, feeDen = req.FeeNum, req.FeeDen
+	}
+	pool := NewPool(Zero, Zero, feeNum, feeDen)
+	pool.Token0, pool.Token1 = token0, token1
+	pool.LPToken = LPTokenID(token0, token1)
+	if at.UDT.Exist([]byte(pool.LPToken)) {
+		return ErrLPTokenTaken
+	}
+	err = at.UDT.AddUdt(&udt.UDT{
+		Name:        pool.LPToken,
+		Creator:     at.Name(),
+		Description: "liquidity shares of " + string(token0) + "/" + string(token1) + " pool",
+		Total:       big.NewInt(0),
+		Locked:      big.NewInt(0),
+		Issued:      big.NewInt(0),
+	})
+	if err != nil {
+		return err
+	}
+	if err = at.addPair(token0, token1); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+// SwapRequest is an exact-in swap if AmountIn is set, or an exact-out swap if AmountOut is set.
+type SwapRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenIn   udt.TokenID `json:"token_in"`
+	TokenOut  udt.TokenID `json:"token_out"`
+
+	AmountIn     *big.Int `json:"amount_in,omitempty"`
+	MinAmountOut *big.Int `json:"min_amount_out,omitempty"`
+
+	AmountOut   *big.Int `json:"amount_out,omitempty"`
+	MaxAmountIn *big.Int `json:"max_amount_in,omitempty"`
+
+	// Deadline is the last block height the swap can be executed in, 0 means no deadline.
+	Deadline common.BlockNum `json:"deadline,omitempty"`
+}
+
+func (at *AmmTripod) Swap(ctx *context.WriteContext) error {
+	req := new(SwapRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if err := checkDeadline(ctx, req.Deadline); err != nil {
+		return err
+	}
+	if (req.AmountIn == nil) == (req.AmountOut == nil) {
+		return errors.New("exactly one of amount in and amount out must be set")
+	}
+	pool, err := at.getPairPool(req.TokenIn, req.TokenOut)
+	if err != nil {
+		return err
+	}
+	if err = at.accumulate(pool, ctx.Block.Height); err != nil {
+		return err
+	}
+	zeroForOne := req.TokenIn == pool.Token0
+
+	amountIn, amountOut := req.AmountIn, req.AmountOut
+	if amountIn != nil {
+		amountOut, err = pool.SwapExactIn(zeroForOne, amountIn, orZero(req.MinAmountOut))
+	} else {
+		if req.MaxAmountIn == nil {
+			return errors.New("max amount in must be set for an exact-out swap")
+		}
+		amountIn, err = pool.SwapExactOut(zeroForOne, amountOut, req.MaxAmountIn)
+	}
+	if err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, req.TokenIn, amountIn); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, req.TokenOut, amountOut); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+type AddLiquidityRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	// AmountA and AmountB are the most to deposit, only the amounts matching the pool ratio are taken.
+	AmountA *big.Int `json:"amount_a"`
+	AmountB *big.Int `json:"amount_b"`
+	// AmountAMin and AmountBMin reject the deposit if the pool ratio moved too far.
+	AmountAMin *big.Int `json:"amount_a_min,omitempty"`
+	AmountBMin *big.Int `json:"amount_b_min,omitempty"`
+}
+
+func (at *AmmTripod) AddLiquidity(ctx *context.WriteContext) error {
+	req := new(AddLiquidityRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if req.AmountA == nil || req.AmountB == nil || req.AmountA.Sign() <= 0 || req.AmountB.Sign() <= 0 {
+		return errors.New("liquidity amounts must be positive")
+	}
+	pool, err := at.getPairPool(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if err = at.accumulate(pool, ctx.Block.Height); err != nil {
+		return err
+	}
+	desired0, desired1 := req.AmountA, req.AmountB
+	min0, min1 := orZero(req.AmountAMin), orZero(req.AmountBMin)
+	if req.TokenA != pool.Token0 {
+		desired0, desired1 = desired1, desired0
+		min0, min1 = min1, min0
+	}
+	if err = at.mintProtocolFee(pool); err != nil {
+		return err
+	}
+	amount0, amount1, shares, err := pool.AddLiquidity(desired0, desired1, min0, min1)
+	if err != nil {
+		return err
+	}
+	pool.SyncKLast()
+	if err = at.Account.SubBalance(req.FromID, pool.Token0, amount0); err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.Token1, amount1); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.LPToken, shares); err != nil {
+		return err
+	}
+	if err = at.updateLPSupply(pool.LPToken, pool.TotalShares); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+type RemoveLiquidityRequest struct {
+	FromID    string      `json:"from_id"`
+	OwnerArgs []byte      `json:"owner_args"`
+	TokenA    udt.TokenID `json:"token_a"`
+	TokenB    udt.TokenID `json:"token_b"`
+	Shares    *big.Int    `json:"shares"`
+}
+
+func (at *AmmTripod) RemoveLiquidity(ctx *context.WriteContext) error {
+	req := new(RemoveLiquidityRequest)
+	if err := ctx.BindJson(req); err != nil {
+		return err
+	}
+	if err := at.Account.VerifyOwner(req.FromID, req.OwnerArgs); err != nil {
+		return err
+	}
+	if req.Shares == nil || req.Shares.Sign() <= 0 {
+		return errors.New("shares must be positive")
+	}
+	pool, err := at.getPairPool(req.TokenA, req.TokenB)
+	if err != nil {
+		return err
+	}
+	if err = at.accumulate(pool, ctx.Block.Height); err != nil {
+		return err
+	}
+	if err = at.Account.SubBalance(req.FromID, pool.LPToken, req.Shares); err != nil {
+		return err
+	}
+	if err = at.mintProtocolFee(pool); err != nil {
+		return err
+	}
+	amount0, amount1, err := pool.RemoveLiquidity(req.Shares)
+	if err != nil {
+		return err
+	}
+	pool.SyncKLast()
+	if err = at.Account.AddBalance(req.FromID, pool.Token0, amount0); err != nil {
+		return err
+	}
+	if err = at.Account.AddBalance(req.FromID, pool.Token1, amount1); err != nil {
+		return err
+	}
+	if err = at.updateLPSupply(pool.LPToken, pool.TotalShares); err != nil {
+		return err
+	}
+	return at.setPool(pool)
+}
+
+func (at *AmmTripod) GetPool(ctx *context.ReadContext) {
+	pool, err := at.getPairPool(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(pool)
+}
+
+func (at *AmmTripod) GetShares(ctx *context.ReadContext) {
+	pool, err := at.getPairPool(udt.TokenID(ctx.GetString("token_a")), udt.TokenID(ctx.GetString("token_b")))
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	shares, err := at.Account.GetBalance(ctx.GetString("owner"), pool.LPToken)
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(shares)
+}
+
+func (at *AmmTripod) GetPools(ctx *context.ReadContext) {
+	pairs, err := at.getPairs()
+	if err != nil {
+		ctx.ErrOk(err)
+		return
+	}
+	ctx.JsonOk(pairs)
+}
+
+// pairsKey keeps the list of all pools in creation order, the router searches paths over it.
+var pairsKey = []byte("pairs")
+
+// Pair is the sorted token pair of a pool.
+type Pair struct {
+	Token0 udt.TokenID `json:"token0"`
+	Token1 udt.TokenID `json:"token1"`
+}
+
+func (at *AmmTripod) getPairs() ([]Pair, error) {
+	byt, err := at.Get(pairsKey)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]Pair, 0)
+	if byt != nil {
+		err = json.Unmarshal(byt, &pairs)
+	}
+	return pairs, err
+}
+
+func (at *AmmTripod) addPair(token0, token1 udt.TokenID) error {
+	pairs, err := at.getPairs()
+	if err != nil {
+		return err
+	}
+	byt, err := json.Marshal(append(pairs, Pair{Token0: token0, Token1: token1}))
+	if err != nil {
+		return err
+	}
+	at.Set(pairsKey, byt)
+	return nil
+}
+
+// poolKey length-prefixes the token names like LPTokenID, so that the pools ("a/b","c") and ("a","b/c") have distinct keys.
+func poolKey(token0, token1 udt.TokenID) []byte {
+	return []byte("pool/" + lengthPrefixed(token0, token1))
+}
+
+// getPairPool loads the pool of two tokens given in any order, it must be the pool of exactly these tokens.
+func (at *AmmTripod) getPairPool(tokenA, tokenB udt.TokenID) (*Pool, error) {
+	token0, token1, err := SortTokens(tokenA, tokenB)
+	if err != nil {
+		return nil, err
+	}
+	byt, err := at.Get(poolKey(token0, token1))
+	if err != nil {
+		return nil, err
+	}
+	if byt == nil {
+		return nil, ErrPoolNotFound
+	}
+	pool := new(Pool)
+	if err = json.Unmarshal(byt, pool); err != nil {
+		return nil, err
+	}
+	if pool.Token0 != token0 || pool.Token1 != token1 {
+		return nil, ErrPoolNotFound
+	}
+	return pool, nil
+}
+
+func (at *AmmTripod) setPool(pool *Pool) error {
+	if at.Exist(flashLockKey(pool.Token0, pool.Token1)) {
+		return ErrPoolLocked
+	}
+	byt, err := json.Marshal(pool)
+	if err != nil {
+		return err
+	}
+	at.Set(poolKey(pool.Token0, pool.Token1), byt)
+	return nil
+}
+
+// updateLPSupply keeps the issued amount of an LP token equal to the total shares of its pool.
+func (at *AmmTripod) updateLPSupply(lpToken udt.TokenID, totalShares *big.Int) error {
+	lp, err := at.UDT.GetUdt(lpToken)
+	if err != nil {
+		return err
+	}
+	lp.Total = new(big.Int).Set(totalShares)
+	lp.Issued = new(big.Int).Set(totalShares)
+	return at.UDT.AddUdt(lp)
+}
+
+func checkDeadline(ctx *context.WriteContext, deadline common.BlockNum) error {
+	if deadline != 0 && ctx.Block.Height > deadline {
+		return errors.New("transaction expired")
+	}
+	return nil
+}
+
+func orZero(amount *big.Int) *big.Int {
+	if amount == nil {
+		return Zero
+	}
+	return amount
+}
@@ -0,0 +1,81 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/yu-org/JingChou/script"
+	"github.com/yu-org/JingChou/udt"
+)
+
+// TestPoolKeysDoNotCollide creates the pools ("a/b","c") and ("a","b/c"), whose token names joined by "/" are the same.
+func TestPoolKeysDoNotCollide(t *testing.T) {
+	at := newTestAmm(t, script.NewNativeVM())
+	pairs := [][2]udt.TokenID{{"a/b", "c"}, {"a", "b/c"}}
+	for _, pair := range pairs {
+		mustOk(t, at.CreatePool(writeCtx(t, 2, &CreatePoolRequest{FromID: "lp", TokenA: pair[0], TokenB: pair[1]})))
+	}
+	for _, pair := range pairs {
+		pool, err := at.getPairPool(pair[0], pair[1])
+		mustOk(t, err)
+		if token0, token1, _ := SortTokens(pair[0], pair[1]); pool.Token0 != token0 || pool.Token1 != token1 {
+			t.Fatalf("loaded the pool %s/%s for %s/%s", pool.Token0, pool.Token1, token0, token1)
+		}
+	}
+}
+
+func TestConcentratedKeysDoNotCollide(t *testing.T) {
+	if string(concentratedPoolKey("a/b", "c", 100)) == string(concentratedPoolKey("a", "b/c", 100)) {
+		t.Fatal("the concentrated pools a/b-c and a-b/c share a key")
+	}
+	abc, bc := &ConcentratedPool{Token0: "a/b", Token1: "c", Fee: 100}, &ConcentratedPool{Token0: "a", Token1: "b/c", Fee: 100}
+	if string(positionKey(abc, "lp", -10, 10)) == string(positionKey(bc, "lp", -10, 10)) {
+		t.Fatal("positions in the concentrated pools a/b-c and a-b/c share a key")
+	}
+}
+
+func TestSwapConcentratedRejectsNonPositiveAmounts(t *testing.T) {
+	at := newTestAmm(t, script.NewNativeVM())
+	mustOk(t, at.Account.AddBalance("lp", "A", big.NewInt(100_000)))
+	mustOk(t, at.Account.AddBalance("lp", "B", big.NewInt(100_000)))
+	mustOk(t, at.CreateConcentratedPool(writeCtx(t, 2, &CreateConcentratedPoolRequest{FromID: "lp", TokenA: "A", TokenB: "B", Fee: 100, SqrtPriceX96: Q96})))
+	mustOk(t, at.MintPosition(writeCtx(t, 2, &MintPositionRequest{FromID: "lp", TokenA: "A", TokenB: "B", Fee: 100,
+		TickLower: -10, TickUpper: 10, AmountA: big.NewInt(100_000), AmountB: big.NewInt(100_000)})))
+	tests := []struct {
+		name    string
+		req     *SwapConcentratedRequest
+		wantErr string
+	}{
+		{"zero in", &SwapConcentratedRequest{AmountIn: big.NewInt(0)}, "amount in must be positive"},
+		{"negative in", &SwapConcentratedRequest{AmountIn: big.NewInt(-100)}, "amount in must be positive"},
+		{"zero out", &SwapConcentratedRequest{AmountOut: big.NewInt(0), MaxAmountIn: big.NewInt(100)}, "amount out must be positive"},
+		{"negative out", &SwapConcentratedRequest{AmountOut: big.NewInt(-100), MaxAmountIn: big.NewInt(100)}, "amount out must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.FromID, tt.req.TokenIn, tt.req.TokenOut, tt.req.Fee = "borrower", "A", "B", 100
+			err := at.SwapConcentrated(writeCtx(t, 3, tt.req))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("SwapConcentrated returned %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStablePoolKeysDoNotCollide(t *testing.T) {
+	if string(stablePoolKey([]udt.TokenID{"a", "b/c", "d"})) == string(stablePoolKey([]udt.TokenID{"a/b", "c", "d"})) {
+		t.Fatal("the stable pools a-b/c-d and a/b-c-d share a key")
+	}
+}
+
+func TestObservationsKeysDoNotCollide(t *testing.T) {
+	if string(observationsKey("a/b", "c")) == string(observationsKey("a", "b/c")) {
+		t.Fatal("the pools a/b-c and a-b/c share their observations")
+	}
+}
+
+func TestFlashLockKeysDoNotCollide(t *testing.T) {
+	if string(flashLockKey("a/b", "c")) == string(flashLockKey("a", "b/c")) {
+		t.Fatal("a flash swap of the pool a/b-c locks the pool a-b/c")
+	}
+}
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+import "./JingChouRollup.sol";
+
+interface IERC20 {
+    function balanceOf(address account) external view returns (uint256);
+}
+
+/**
+ * @title JingChouToken
+ * @notice The canonical ERC-20 of JingChou, the native token of L2, on L1. Only its JingChouBridge mints it
+ *         for the native withdrawals locked on L2 and burns it for the deposits unlocking them
+ */
+contract JingChouToken {
+    string public constant name = "JingChou";
+    string public constant symbol = "JC";
+    uint8 public constant decimals = 18;
+    uint256 public totalSupply;
+    address public immutable bridge;
+
+    mapping(address => uint256) public balanceOf;
+    mapping(address => mapping(address => uint256)) public allowance;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    event Approval(address indexed owner, address indexed spender, uint256 value);
+
+    constructor() {
+        bridge = msg.sender;
+    }
+
+    modifier onlyBridge() {
+        require(msg.sender == bridge, "only bridge");
+        _;
+    }
+
+    function transfer(address to, uint256 amount) external returns (bool) {
+        _transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    function approve(address spender, uint256 amount) external returns (bool) {
+        allowance[msg.sender][spender] = amount;
+        emit Approval(msg.sender, spender, amount);
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 amount) external returns (bool) {
+        uint256 allowed = allowance[from][msg.sender];
+        if (allowed != type(uint256).max) {
+            require(allowed >= amount, "insufficient allowance");
+            allowance[from][msg.sender] = allowed - amount;
+        }
+        _transfer(from, to, amount);
+        return true;
+    }
+
+    function mint(address to, uint256 amount) external onlyBridge {
+        totalSupply += amount;
+        balanceOf[to] += amount;
+        emit Transfer(address(0), to, amount);
+    }
+
+    function burn(address from, uint256 amount) external onlyBridge {
+        require(balanceOf[from] >= amount, "insufficient balance");
+        balanceOf[from] -= amount;
+        totalSupply -= amount;
+        emit Transfer(from, address(0), amount);
+    }
+
+    function _transfer(address from, address to, uint256 amount) private {
+        require(balanceOf[from] >= amount, "insufficient balance");
+        balanceOf[from] -= amount;
+        balanceOf[to] += amount;
+        emit Transfer(from, to, amount);
+    }
+}
+
+/**
+ * @title JingChouBridge
+ * @notice The parent-layer bridge of JingChou: locks deposits relayed to L2 by bridge/eth.EthRelayer,
+ *         pays out the withdrawals proven against the withdrawal roots of verified batches,
+ *         and refunds the deposits L2 never included once the rollup halted
+ * @dev ETH is token address(0). A withdrawal leaf is
+ *      keccak256(bytes.concat(keccak256(abi.encode(nonce, recipient, token, amount)))),
+ *      the tree hashes each pair in sorted order, the same as OpenZeppelin's MerkleProof.
+ *      The native token of L2 is nativeToken: it is minted when claimed and burned when deposited, never held.
+ *      A forced transaction is an encoded L2 SignedTxn, the L2 nodes reject the blocks that have not included it
+ *      within their inclusion window of L1 blocks.
+ */
+contract JingChouBridge {
+    struct DepositRecord {
+        address sender;
+        address token;
+        uint256 amount;
+    }
+
+    JingChouRollup public immutable rollup;
+    /// @notice The canonical ERC-20 of JingChou, the native token of L2
+    JingChouToken public immutable nativeToken;
+
+    /// @notice The nonce of the next deposit, L2 relays the deposits in nonce order
+    uint256 public depositNonce;
+    mapping(uint256 => DepositRecord) private deposits;
+    mapping(uint256 => bool) public withdrawalClaimed;
+    mapping(uint256 => bool) public depositRefunded;
+
+    /// @notice The largest encoded L2 transaction that can be forced, in bytes
+    uint256 public constant MAX_FORCED_TX_SIZE = 65536;
+    /// @notice The index of the next forced transaction, L2 includes them in index order of queueing
+    uint256 public forcedTxCount;
+
+    uint256 private locked = 1;
+
+    event DepositInitiated(
+        uint256 indexed nonce,
+        address indexed sender,
+        address indexed token,
+        string recipient,
+        uint256 amount
+    );
+    event WithdrawalClaimed(uint256 indexed nonce, uint256 indexed batchIndex, address indexed recipient, address token, uint256 amount);
+    event DepositRefunded(uint256 indexed nonce, address indexed sender, address token, uint256 amount);
+    event ForcedTransactionQueued(uint256 indexed index, address indexed sender, bytes txn);
+
+    modifier nonReentrant() {
+        require(locked == 1, "reentrant call");
+        locked = 2;
+        _;
+        locked = 1;
+    }
+
+    constructor(JingChouRollup _rollup) {
+        rollup = _rollup;
+        nativeToken = new JingChouToken();
+    }
+
+    /// @notice Deposit ETH to the L2 account recipient
+    function depositETH(string calldata recipient) external payable nonReentrant {
+        require(msg.value > 0, "zero deposit");
+        _deposit(address(0), msg.value, recipient);
+    }
+
+    /// @notice Deposit an ERC-20 token to the L2 account recipient, the bridge must be approved first.
+    ///         nativeToken needs no approval, it is burned and unlocked on L2
+    function depositERC20(address token, uint256 amount, string calldata recipient) external nonReentrant {
+        require(token != address(0), "invalid token");
+        if (token == address(nativeToken)) {
+            require(amount > 0, "zero deposit");
+            nativeToken.burn(msg.sender, amount);
+            _deposit(token, amount, recipient);
+            return;
+        }
+        uint256 balance = IERC20(token).balanceOf(address(this));
+        _safeTransferFrom(token, msg.sender, address(this), amount);
+        // only what arrived is credited, for tokens taking a fee on transfer
+        uint256 received = IERC20(token).balanceOf(address(this)) - balance;
+        require(received > 0, "zero deposit");
+        _deposit(token, received, recipient);
+    }
+
+    /// @notice Queue an encoded L2 transaction that L2 must include, for when its sequencer censors it
+    function forceTransaction(bytes calldata txn) external {
+        require(!rollup.halted(), "rollup halted");
+        require(txn.length > 0 && txn.length <= MAX_FORCED_TX_SIZE, "invalid forced transaction size");
+        emit ForcedTransactionQueued(forcedTxCount++, msg.sender, txn);
+    }
+
+    /**
+     * @notice Pay out a withdrawal of a verified batch
+     * @param batchIndex The batch whose withdrawal root contains the withdrawal
+     * @param proof The sibling hashes from the leaf to the root, from the GetWithdrawalProof reading on L2
+     */
+    function claimWithdrawal(
+        uint256 batchIndex,
+        uint256 nonce,
+        address recipient,
+        address token,
+        uint256 amount,
+        bytes32[] calldata proof
+    ) external nonReentrant {
+        require(!withdrawalClaimed[nonce], "withdrawal claimed");
+        bytes32 leaf = keccak256(bytes.concat(keccak256(abi.encode(nonce, recipient, token, amount))));
+        require(_verify(proof, rollup.withdrawalRoot(batchIndex), leaf), "invalid withdrawal proof");
+        withdrawalClaimed[nonce] = true;
+        _transferOut(token, recipient, amount);
+        emit WithdrawalClaimed(nonce, batchIndex, recipient, token, amount);
+    }
+
+    /// @notice Return a deposit that no verified batch included to its sender, after the rollup halted
+    function refundDeposit(uint256 nonce) external nonReentrant {
+        require(rollup.halted(), "rollup is alive");
+        require(nonce < depositNonce, "deposit not found");
+        require(nonce >= rollup.depositCount(), "deposit included on L2");
+        require(!depositRefunded[nonce], "deposit refunded");
+        depositRefunded[nonce] = true;
+        DepositRecord memory record = deposits[nonce];
+        _transferOut(record.token, record.sender, record.amount);
+        emit DepositRefunded(nonce, record.sender, record.token, record.amount);
+    }
+
+    function getDeposit(uint256 nonce) external view returns (DepositRecord memory) {
+        require(nonce < depositNonce, "deposit not found");
+        return deposits[nonce];
+    }
+
+    function _deposit(address token, uint256 amount, string calldata recipient) private {
+        require(!rollup.halted(), "rollup halted");
+        require(bytes(recipient).length > 0, "empty recipient");
+        uint256 nonce = depositNonce++;
+        deposits[nonce] = DepositRecord({sender: msg.sender, token: token, amount: amount});
+        emit DepositInitiated(nonce, msg.sender, token, recipient, amount);
+    }
+
+    function _verify(bytes32[] calldata proof, bytes32 root, bytes32 leaf) private pure returns (bool) {
+        bytes32 computed = leaf;
+        for (uint256 i = 0; i < proof.length; i++) {
+            bytes32 sibling = proof[i];
+            computed = computed < sibling
+                ? keccak256(abi.encodePacked(computed, sibling))
+                : keccak256(abi.encodePacked(sibling, computed));
+        }
+        return root != bytes32(0) && computed == root;
+    }
+
+    function _transferOut(address token, address to, uint256 amount) private {
+        if (token == address(nativeToken)) {
+            nativeToken.mint(to, amount);
+            return;
+        }
+        if (token == address(0)) {
+            (bool ok, ) = to.call{value: amount}("");
+            require(ok, "ETH transfer failed");
+            return;
+        }
+        (bool success, bytes memory data) = token.call(abi.encodeWithSignature("transfer(address,uint256)", to, amount));
+        require(success && (data.length == 0 || abi.decode(data, (bool))), "token transfer failed");
+    }
+
+    function _safeTransferFrom(address token, address from, address to, uint256 amount) private {
+        (bool success, bytes memory data) = token.call(
+            abi.encodeWithSignature("transferFrom(address,address,uint256)", from, to, amount)
+        );
+        require(success && (data.length == 0 || abi.decode(data, (bool))), "token transferFrom failed");
+    }
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+/**
+ * @title BridgedERC20
+ * @notice The ERC-20 of an L1 token on the JingChou EVM, only its bridge mints and burns it.
+ *         It carries the name, symbol and decimals of the L1 token, as the token registry maps it
+ */
+contract BridgedERC20 {
+    string public name;
+    string public symbol;
+    uint8 public immutable decimals;
+    uint256 public totalSupply;
+    address public immutable bridge;
+    address public immutable l1Token;
+
+    mapping(address => uint256) public balanceOf;
+    mapping(address => mapping(address => uint256)) public allowance;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    event Approval(address indexed owner, address indexed spender, uint256 value);
+
+    constructor(string memory _name, string memory _symbol, uint8 _decimals, address _l1Token) {
+        name = _name;
+        symbol = _symbol;
+        decimals = _decimals;
+        bridge = msg.sender;
+        l1Token = _l1Token;
+    }
+
+    modifier onlyBridge() {
+        require(msg.sender == bridge, "only bridge");
+        _;
+    }
+
+    function transfer(address to, uint256 amount) external returns (bool) {
+        _transfer(msg.sender, to, amount);
+        return true;
+    }
+
+    function approve(address spender, uint256 amount) external returns (bool) {
+        allowance[msg.sender][spender] = amount;
+        emit Approval(msg.sender, spender, amount);
+        return true;
+    }
+
+    function transferFrom(address from, address to, uint256 amount) external returns (bool) {
+        uint256 allowed = allowance[from][msg.sender];
+        if (allowed != type(uint256).max) {
+            require(allowed >= amount, "insufficient allowance");
+            allowance[from][msg.sender] = allowed - amount;
+        }
+        _transfer(from, to, amount);
+        return true;
+    }
+
+    function mint(address to, uint256 amount) external onlyBridge {
+        totalSupply += amount;
+        balanceOf[to] += amount;
+        emit Transfer(address(0), to, amount);
+    }
+
+    function burn(address from, uint256 amount) external onlyBridge {
+        require(balanceOf[from] >= amount, "insufficient balance");
+        balanceOf[from] -= amount;
+        totalSupply -= amount;
+        emit Transfer(from, address(0), amount);
+    }
+
+    function _transfer(address from, address to, uint256 amount) private {
+        require(balanceOf[from] >= amount, "insufficient balance");
+        balanceOf[from] -= amount;
+        balanceOf[to] += amount;
+        emit Transfer(from, to, amount);
+    }
+}
+
+/**
+ * @title JingChouChildBridge
+ * @notice The child-layer bridge on the JingChou EVM. bridge/eth.EthRelayer finalizes the L1 deposits
+ *         sent to an EVM address through it, and turns its WithdrawalInitiated events into L2→L1 withdrawals
+ * @dev Each L1 token, ETH being address(0), gets a BridgedERC20 created on its first deposit
+ */
+contract JingChouChildBridge {
+    /// @notice The account the relayer signs deposit finalizations with
+    address public immutable relayer;
+
+    mapping(address => BridgedERC20) public bridgedTokens;
+    mapping(uint256 => bool) public depositFinalized;
+
+    event BridgedTokenCreated(address indexed l1Token, address token);
+    event DepositFinalized(uint256 indexed nonce, address indexed l1Token, address indexed to, uint256 amount);
+    event WithdrawalInitiated(address indexed sender, address indexed l1Token, address l1Recipient, uint256 amount);
+
+    constructor(address _relayer) {
+        relayer = _relayer;
+    }
+
+    /**
+     * @notice Mint a relayed L1 deposit, each deposit nonce only once.
+     *         name, symbol and decimals are the L1 token's, taken by its BridgedERC20 on its first deposit
+     */
+    function finalizeDeposit(
+        uint256 nonce,
+        address l1Token,
+        address to,
+        uint256 amount,
+        string calldata name,
+        string calldata symbol,
+        uint8 decimals
+    ) external {
+        require(msg.sender == relayer, "only relayer");
+        require(!depositFinalized[nonce], "deposit finalized");
+        depositFinalized[nonce] = true;
+        BridgedERC20 token = bridgedTokens[l1Token];
+        if (address(token) == address(0)) {
+            token = new BridgedERC20(name, symbol, decimals, l1Token);
+            bridgedTokens[l1Token] = token;
+            emit BridgedTokenCreated(l1Token, address(token));
+        }
+        token.mint(to, amount);
+        emit DepositFinalized(nonce, l1Token, to, amount);
+    }
+
+    /// @notice Burn the bridged token of l1Token and withdraw it to l1Recipient on L1
+    function withdraw(address l1Token, address l1Recipient, uint256 amount) external {
+        BridgedERC20 token = bridgedTokens[l1Token];
+        require(address(token) != address(0), "token not bridged");
+        require(amount > 0, "zero withdrawal");
+        require(l1Recipient != address(0), "invalid recipient");
+        token.burn(msg.sender, amount);
+        emit WithdrawalInitiated(msg.sender, l1Token, l1Recipient, amount);
+    }
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+import "./IOpenVmHalo2Verifier.sol";
+
+/**
+ * @title JingChouRollup
+ * @notice Stores the state roots of the JingChou L2 batches verified through the OpenVM Halo2 verifier
+ * @dev The public values of a batch are 120 bytes, all integers big-endian:
+ *      fromBlock (uint64) | toBlock (uint64) | preStateRoot (bytes32) | newStateRoot (bytes32)
+ *      | withdrawalRoot (bytes32) | depositCount (uint64)
+ *      Anyone can submit a batch, the proof is the only authority.
+ */
+contract JingChouRollup {
+    struct Batch {
+        uint64 fromBlock;
+        uint64 toBlock;
+        bytes32 preStateRoot;
+        bytes32 newStateRoot;
+        bytes32 withdrawalRoot;
+        uint64 depositCount;
+        uint256 verifiedAt;
+    }
+
+    uint256 public constant PUBLIC_VALUES_LENGTH = 120;
+
+    IOpenVmHalo2Verifier public immutable verifier;
+    bytes32 public immutable appExeCommit;
+    bytes32 public immutable appVmCommit;
+    /// @notice How long without a verified batch before the rollup can be halted, in seconds
+    uint256 public immutable haltDelay;
+
+    Batch[] private batches;
+    /// @notice The L2 state root after the last verified batch
+    bytes32 public lastStateRoot;
+    /// @notice The last L2 block of the last verified batch
+    uint64 public lastBlock;
+    /// @notice How many L1 deposits the last verified batch has included
+    uint64 public depositCount;
+    /// @notice When the last batch was verified, or the rollup deployed
+    uint256 public lastVerifiedAt;
+    /// @notice Once halted no batch is accepted anymore, see JingChouBridge.refundDeposit
+    bool public halted;
+
+    event BatchVerified(
+        uint256 indexed batchIndex,
+        uint64 fromBlock,
+        uint64 toBlock,
+        bytes32 newStateRoot,
+        bytes32 withdrawalRoot,
+        uint64 depositCount
+    );
+    event RollupHalted(uint256 lastBatchIndex, uint64 depositCount);
+
+    constructor(
+        IOpenVmHalo2Verifier _verifier,
+        bytes32 _appExeCommit,
+        bytes32 _appVmCommit,
+        bytes32 genesisStateRoot,
+        uint256 _haltDelay
+    ) {
+        verifier = _verifier;
+        appExeCommit = _appExeCommit;
+        appVmCommit = _appVmCommit;
+        haltDelay = _haltDelay;
+        lastStateRoot = genesisStateRoot;
+        lastVerifiedAt = block.timestamp;
+    }
+
+    /**
+     * @notice Verify the proof of the next batch and store its state root and withdrawal root
+     * @param publicValues The public values revealed by the OpenVM program, see the layout above
+     * @param proofData The proof from the prover
+     */
+    function submitBatch(bytes calldata publicValues, bytes calldata proofData) external {
+        require(!halted, "rollup halted");
+        require(publicValues.length == PUBLIC_VALUES_LENGTH, "invalid public values length");
+
+        Batch memory batch = Batch({
+            fromBlock: uint64(bytes8(publicValues[0:8])),
+            toBlock: uint64(bytes8(publicValues[8:16])),
+            preStateRoot: bytes32(publicValues[16:48]),
+            newStateRoot: bytes32(publicValues[48:80]),
+            withdrawalRoot: bytes32(publicValues[80:112]),
+            depositCount: uint64(bytes8(publicValues[112:120])),
+            verifiedAt: block.timestamp
+        });
+        require(batch.fromBlock == lastBlock + 1, "batch does not follow the last block");
+        require(batch.toBlock >= batch.fromBlock, "empty batch");
+        require(batch.preStateRoot == lastStateRoot, "batch does not follow the last state root");
+        require(batch.depositCount >= depositCount, "deposit count decreased");
+
+        // reverts if the proof is invalid
+        verifier.verify(publicValues, proofData, appExeCommit, appVmCommit);
+
+        batches.push(batch);
+        lastStateRoot = batch.newStateRoot;
+        lastBlock = batch.toBlock;
+        depositCount = batch.depositCount;
+        lastVerifiedAt = block.timestamp;
+        emit BatchVerified(
+            batches.length - 1,
+            batch.fromBlock,
+            batch.toBlock,
+            batch.newStateRoot,
+            batch.withdrawalRoot,
+            batch.depositCount
+        );
+    }
+
+    /**
+     * @notice Stop the rollup after no batch was verified for haltDelay,
+     *         the deposits it has not included can be refunded on the bridge afterwards
+     * @dev This is no escape hatch: the balances on L2 can not exit against lastStateRoot,
+     *      they stay where the last verified batch left them
+     */
+    function halt() external {
+        require(!halted, "rollup halted");
+        require(block.timestamp > lastVerifiedAt + haltDelay, "rollup is alive");
+        halted = true;
+        emit RollupHalted(batches.length, depositCount);
+    }
+
+    function batchCount() external view returns (uint256) {
+        return batches.length;
+    }
+
+    function getBatch(uint256 batchIndex) external view returns (Batch memory) {
+        require(batchIndex < batches.length, "batch not verified");
+        return batches[batchIndex];
+    }
+
+    /// @notice The withdrawal root of a verified batch
+    function withdrawalRoot(uint256 batchIndex) external view returns (bytes32) {
+        require(batchIndex < batches.length, "batch not verified");
+        return batches[batchIndex].withdrawalRoot;
+    }
+}
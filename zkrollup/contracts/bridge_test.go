@@ -0,0 +1,280 @@
+package contracts_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	bridgeeth "github.com/yu-org/JingChou/bridge/eth"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+)
+
+const haltDelay = 3600
+
+// testL1 is a simulated L1 with the rollup and the bridge deployed, verifying every proof.
+type testL1 struct {
+	backend *simulated.Backend
+	client  simulated.Client
+
+	deployer *bind.TransactOpts
+	alice    *bind.TransactOpts
+
+	verifier *contracts.MockVerifier
+	rollup   *contracts.JingChouRollup
+	bridge   *contracts.JingChouBridge
+	native   *contracts.JingChouToken
+
+	bridgeAddr common.Address
+	nativeAddr common.Address
+}
+
+func newTestL1(t *testing.T) *testL1 {
+	deployerKey, aliceKey := newKey(t), newKey(t)
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		crypto.PubkeyToAddress(deployerKey.PublicKey): {Balance: funds},
+		crypto.PubkeyToAddress(aliceKey.PublicKey):    {Balance: funds},
+	})
+	t.Cleanup(func() { backend.Close() })
+	l1 := &testL1{
+		backend:  backend,
+		client:   backend.Client(),
+		deployer: newTransactor(t, deployerKey),
+		alice:    newTransactor(t, aliceKey),
+	}
+
+	verifierAddr, tx, verifier, err := contracts.DeployMockVerifier(l1.deployer, l1.client)
+	l1.mine(t, tx, err)
+	rollupAddr, tx, rollup, err := contracts.DeployJingChouRollup(
+		l1.deployer, l1.client, verifierAddr, [32]byte{1}, [32]byte{2}, [32]byte{}, big.NewInt(haltDelay),
+	)
+	l1.mine(t, tx, err)
+	bridgeAddr, tx, bridge, err := contracts.DeployJingChouBridge(l1.deployer, l1.client, rollupAddr)
+	l1.mine(t, tx, err)
+	nativeAddr, err := bridge.NativeToken(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	native, err := contracts.NewJingChouToken(nativeAddr, l1.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l1.verifier, l1.rollup, l1.bridge, l1.native = verifier, rollup, bridge, native
+	l1.bridgeAddr, l1.nativeAddr = bridgeAddr, nativeAddr
+	return l1
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newTransactor(t *testing.T, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, params.AllDevChainProtocolChanges.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+// mine commits a block with the sent transaction and fails the test unless it succeeded.
+func (l1 *testL1) mine(t *testing.T, tx *types.Transaction, err error) *types.Receipt {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l1.backend.Commit()
+	receipt, err := l1.client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("tx(%s) reverted", tx.Hash().Hex())
+	}
+	return receipt
+}
+
+func (l1 *testL1) depositETH(t *testing.T, amount int64, recipient string) *contracts.JingChouBridgeDepositInitiated {
+	t.Helper()
+	opts := *l1.alice
+	opts.Value = big.NewInt(amount)
+	tx, err := l1.bridge.DepositETH(&opts, recipient)
+	receipt := l1.mine(t, tx, err)
+	for _, log := range receipt.Logs {
+		if event, err := l1.bridge.ParseDepositInitiated(*log); err == nil {
+			return event
+		}
+	}
+	t.Fatal("no DepositInitiated event")
+	return nil
+}
+
+// submitBatch verifies the next batch of L2 blocks [from, to].
+func (l1 *testL1) submitBatch(t *testing.T, from, to uint64, newStateRoot, withdrawalRoot common.Hash, depositCount uint64) {
+	t.Helper()
+	preStateRoot, err := l1.rollup.LastStateRoot(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make([]byte, 0, 120)
+	values = binary.BigEndian.AppendUint64(values, from)
+	values = binary.BigEndian.AppendUint64(values, to)
+	values = append(values, preStateRoot[:]...)
+	values = append(values, newStateRoot[:]...)
+	values = append(values, withdrawalRoot[:]...)
+	values = binary.BigEndian.AppendUint64(values, depositCount)
+	tx, err := l1.rollup.SubmitBatch(l1.deployer, values, []byte("proof"))
+	l1.mine(t, tx, err)
+}
+
+func (l1 *testL1) balance(t *testing.T, account common.Address) *big.Int {
+	t.Helper()
+	balance, err := l1.client.BalanceAt(context.Background(), account, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return balance
+}
+
+func TestDeposit(t *testing.T) {
+	l1 := newTestL1(t)
+	first := l1.depositETH(t, 1000, "alice")
+	second := l1.depositETH(t, 2000, "bob")
+	if first.Nonce.Uint64() != 0 || second.Nonce.Uint64() != 1 {
+		t.Fatalf("deposit nonces %s, %s", first.Nonce, second.Nonce)
+	}
+	if second.Recipient != "bob" || second.Amount.Int64() != 2000 || second.Token != (common.Address{}) {
+		t.Fatalf("unexpected deposit %+v", second)
+	}
+	if balance := l1.balance(t, l1.bridgeAddr); balance.Int64() != 3000 {
+		t.Fatalf("bridge holds %s wei, want 3000", balance)
+	}
+	record, err := l1.bridge.GetDeposit(nil, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Sender != l1.alice.From || record.Amount.Int64() != 2000 {
+		t.Fatalf("unexpected deposit record %+v", record)
+	}
+	if _, err = l1.bridge.DepositETH(l1.alice, "alice"); err == nil {
+		t.Fatal("zero deposit accepted")
+	}
+}
+
+func TestSubmitBatchAndClaimWithdrawal(t *testing.T) {
+	l1 := newTestL1(t)
+	l1.depositETH(t, 5000, "alice")
+	bob := crypto.PubkeyToAddress(newKey(t).PublicKey)
+
+	withdrawals := []*bridgeeth.Withdrawal{
+		{Nonce: 0, Recipient: bob.Hex(), L1Token: common.Address{}.Hex(), Amount: big.NewInt(1500)},
+		{Nonce: 1, Recipient: bob.Hex(), L1Token: l1.nativeAddr.Hex(), Amount: big.NewInt(700)},
+		{Nonce: 2, Recipient: l1.alice.From.Hex(), L1Token: common.Address{}.Hex(), Amount: big.NewInt(100)},
+	}
+	leaves := make([]common.Hash, len(withdrawals))
+	for i, withdrawal := range withdrawals {
+		leaf, err := withdrawal.Leaf()
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaves[i] = leaf
+	}
+	l1.submitBatch(t, 1, 10, common.Hash{3}, bridgeeth.MerkleRoot(leaves), 1)
+
+	claim := func(index int, proof []common.Hash) error {
+		w := withdrawals[index]
+		siblings := make([][32]byte, len(proof))
+		for i, sibling := range proof {
+			siblings[i] = sibling
+		}
+		tx, err := l1.bridge.ClaimWithdrawal(
+			l1.deployer, big.NewInt(0), new(big.Int).SetUint64(w.Nonce),
+			common.HexToAddress(w.Recipient), common.HexToAddress(w.L1Token), w.Amount, siblings,
+		)
+		if err == nil {
+			l1.mine(t, tx, nil)
+		}
+		return err
+	}
+
+	if err := claim(0, bridgeeth.MerkleProof(leaves, 1)); err == nil {
+		t.Fatal("withdrawal claimed with the proof of another leaf")
+	}
+	if err := claim(0, bridgeeth.MerkleProof(leaves, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if balance := l1.balance(t, bob); balance.Int64() != 1500 {
+		t.Fatalf("bob has %s wei, want 1500", balance)
+	}
+	if err := claim(0, bridgeeth.MerkleProof(leaves, 0)); err == nil {
+		t.Fatal("withdrawal claimed twice")
+	}
+
+	// the native token is minted when claimed
+	if err := claim(1, bridgeeth.MerkleProof(leaves, 1)); err != nil {
+		t.Fatal(err)
+	}
+	minted, err := l1.native.BalanceOf(nil, bob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if minted.Int64() != 700 {
+		t.Fatalf("bob has %s JingChouToken, want 700", minted)
+	}
+
+	if _, err = l1.verifier.SetRejecting(l1.deployer, true); err != nil {
+		t.Fatal(err)
+	}
+	l1.backend.Commit()
+	if _, err = l1.rollup.SubmitBatch(l1.deployer, make([]byte, 120), []byte("proof")); err == nil {
+		t.Fatal("batch accepted with an invalid proof")
+	}
+}
+
+func TestRefundDeposit(t *testing.T) {
+	l1 := newTestL1(t)
+	l1.depositETH(t, 1000, "alice")
+	l1.depositETH(t, 2000, "alice")
+	// L2 included the first deposit only
+	l1.submitBatch(t, 1, 10, common.Hash{3}, common.Hash{}, 1)
+
+	if _, err := l1.bridge.RefundDeposit(l1.deployer, big.NewInt(1)); err == nil {
+		t.Fatal("deposit refunded while the rollup is alive")
+	}
+	if _, err := l1.rollup.Halt(l1.deployer); err == nil {
+		t.Fatal("rollup halted before the halt delay")
+	}
+	if err := l1.backend.AdjustTime((haltDelay + 1) * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := l1.rollup.Halt(l1.deployer)
+	l1.mine(t, tx, err)
+
+	if _, err = l1.bridge.RefundDeposit(l1.deployer, big.NewInt(0)); err == nil {
+		t.Fatal("deposit included on L2 refunded")
+	}
+	before := l1.balance(t, l1.alice.From)
+	tx, err = l1.bridge.RefundDeposit(l1.deployer, big.NewInt(1))
+	l1.mine(t, tx, err)
+	if refunded := new(big.Int).Sub(l1.balance(t, l1.alice.From), before); refunded.Int64() != 2000 {
+		t.Fatalf("alice got %s wei back, want 2000", refunded)
+	}
+	if _, err = l1.bridge.RefundDeposit(l1.deployer, big.NewInt(1)); err == nil {
+		t.Fatal("deposit refunded twice")
+	}
+	if _, err = l1.bridge.DepositETH(l1.alice, "alice"); err == nil {
+		t.Fatal("deposit accepted after the rollup halted")
+	}
+}
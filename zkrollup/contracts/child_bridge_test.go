@@ -0,0 +1,58 @@
+package contracts_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yu-org/JingChou/zkrollup/contracts"
+)
+
+func TestFinalizeDepositKeepsTokenMetadata(t *testing.T) {
+	l1 := newTestL1(t)
+	_, tx, child, err := contracts.DeployJingChouChildBridge(l1.deployer, l1.client, l1.deployer.From)
+	l1.mine(t, tx, err)
+
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	tx, err = child.FinalizeDeposit(l1.deployer, big.NewInt(0), usdc, l1.alice.From, big.NewInt(1_000_000), "USD Coin", "USDC", 6)
+	l1.mine(t, tx, err)
+	// the metadata of later deposits is ignored, the token exists already
+	tx, err = child.FinalizeDeposit(l1.deployer, big.NewInt(1), usdc, l1.alice.From, big.NewInt(500_000), "Other", "OTHER", 18)
+	l1.mine(t, tx, err)
+
+	tokenAddr, err := child.BridgedTokens(nil, usdc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// BridgedERC20 has the same ERC-20 views as JingChouToken
+	token, err := contracts.NewJingChouToken(tokenAddr, l1.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := token.Name(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symbol, err := token.Symbol(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decimals, err := token.Decimals(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "USD Coin" || symbol != "USDC" || decimals != 6 {
+		t.Fatalf("bridged token is %s (%s) with %d decimals", name, symbol, decimals)
+	}
+	balance, err := token.BalanceOf(nil, l1.alice.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != 1_500_000 {
+		t.Fatalf("alice has %s, want 1500000", balance)
+	}
+
+	if _, err = child.FinalizeDeposit(l1.alice, big.NewInt(2), usdc, l1.alice.From, big.NewInt(1), "USD Coin", "USDC", 6); err == nil {
+		t.Fatal("deposit finalized by another account than the relayer")
+	}
+}
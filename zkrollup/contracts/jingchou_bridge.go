@@ -0,0 +1,1200 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// JingChouBridgeDepositRecord is an auto generated low-level Go binding around an user-defined struct.
+type JingChouBridgeDepositRecord struct {
+	Sender common.Address
+	Token  common.Address
+	Amount *big.Int
+}
+
+// JingChouBridgeMetaData contains all meta data concerning the JingChouBridge contract.
+var JingChouBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractJingChouRollup\",\"name\":\"_rollup\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"recipient\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"DepositInitiated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"DepositRefunded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"txn\",\"type\":\"bytes\"}],\"name\":\"ForcedTransactionQueued\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"batchIndex\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"WithdrawalClaimed\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"MAX_FORCED_TX_SIZE\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"batchIndex\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"bytes32[]\",\"name\":\"proof\",\"type\":\"bytes32[]\"}],\"name\":\"claimWithdrawal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"recipient\",\"type\":\"string\"}],\"name\":\"depositERC20\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"recipient\",\"type\":\"string\"}],\"name\":\"depositETH\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"depositNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"depositRefunded\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"txn\",\"type\":\"bytes\"}],\"name\":\"forceTransaction\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"forcedTxCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"name\":\"getDeposit\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"internalType\":\"structJingChouBridge.DepositRecord\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nativeToken\",\"outputs\":[{\"internalType\":\"contractJingChouToken\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"name\":\"refundDeposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rollup\",\"outputs\":[{\"internalType\":\"contractJingChouRollup\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"withdrawalClaimed\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x60c06040526001600555348015610014575f80fd5b506040516200202a3803806200202a83398101604081905261003591610088565b6001600160a01b03811660805260405161004e9061007a565b604051809103905ff080158015610067573d5f803e3d5ffd5b506001600160a01b031660a052506100b5565b61087680620017b483390190565b5f60208284031215610098575f80fd5b81516001600160a01b03811681146100ae575f80fd5b9392505050565b60805160a05161169e620001165f395f8181610270015281816104bf0152818161053101528181610fee015261104b01525f8181610211015281816102e1015281816106f2015281816107f401528181610bb50152610d10015261169e5ff3fe6080604052600436106100bf575f3560e01c80639b1c48e61161007c578063de35f5cb11610057578063de35f5cb1461024b578063e1758bd81461025f578063f941af1514610292578063fa0161c0146102b1575f80fd5b80639b1c48e61461019e5780639f9fb968146101b1578063cb23bcb514610200575f80fd5b80631d821854146100c357806322f4a6aa146101065780634ab08a67146101295780635109ba721461013f5780635a67cb87146101605780636de0de7e1461017f575b5f80fd5b3480156100ce575f80fd5b506100f16100dd366004611314565b60026020525f908152604090205460ff1681565b60405190151581526020015b60405180910390f35b348015610111575f80fd5b5061011b60045481565b6040519081526020016100fd565b348015610134575f80fd5b5061011b6201000081565b34801561014a575f80fd5b5061015e610159366004611370565b6102df565b005b34801561016b575f80fd5b5061015e61017a3660046113ca565b610450565b34801561018a575f80fd5b5061015e610199366004611314565b6106c6565b61015e6101ac366004611370565b6109ce565b3480156101bc575f80fd5b506101d06101cb366004611314565b610a27565b6040805182516001600160a01b0390811682526020808501519091169082015291810151908201526060016100fd565b34801561020b575f80fd5b506102337f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b0390911681526020016100fd565b348015610256575f80fd5b5061011b5f5481565b34801561026a575f80fd5b506102337f000000000000000000000000000000000000000000000000000000000000000081565b34801561029d575f80fd5b5061015e6102ac366004611420565b610acb565b3480156102bc575f80fd5b506100f16102cb366004611314565b60036020525f908152604090205460ff1681565b7f00000000000000000000000000000000000000000000000000000000000000006001600160a01b031663b9b8af0b6040518163ffffffff1660e01b8152600401602060405180830381865afa15801561033b573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061035f91906114cb565b156103a15760405162461bcd60e51b815260206004820152600d60248201526c1c9bdb1b1d5c081a185b1d1959609a1b60448201526064015b60405180910390fd5b80158015906103b35750620100008111155b6103ff5760405162461bcd60e51b815260206004820152601f60248201527f696e76616c696420666f72636564207472616e73616374696f6e2073697a65006044820152606401610398565b6004805433915f61040f83611505565b919050557fe3010c9eb8189e44923b666c5266411388ef780cd10dafe1ba8a1ee6386b9dfe8484604051610444929190611545565b60405180910390a35050565b6005546001146104725760405162461bcd60e51b815260040161039890611560565b60026005556001600160a01b0384166104bd5760405162461bcd60e51b815260206004820152600d60248201526c34b73b30b634b2103a37b5b2b760991b6044820152606401610398565b7f00000000000000000000000000000000000000000000000000000000000000006001600160a01b0316846001600160a01b0316036105a1575f83116105155760405162461bcd60e51b815260040161039890611588565b604051632770a7eb60e21b8152336004820152602481018490527f00000000000000000000000000000000000000000000000000000000000000006001600160a01b031690639dc29fac906044015f604051808303815f87803b15801561057a575f80fd5b505af115801561058c573d5f803e3d5ffd5b5050505061059c84848484610d0e565b6106bb565b6040516370a0823160e01b81523060048201525f906001600160a01b038616906370a0823190602401602060405180830381865afa1580156105e5573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061060991906115ae565b905061061785333087610ecf565b6040516370a0823160e01b81523060048201525f9082906001600160a01b038816906370a0823190602401602060405180830381865afa15801561065d573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061068191906115ae565b61068b91906115c5565b90505f81116106ac5760405162461bcd60e51b815260040161039890611588565b6106b886828686610d0e565b50505b505060016005555050565b6005546001146106e85760405162461bcd60e51b815260040161039890611560565b60026005819055507f00000000000000000000000000000000000000000000000000000000000000006001600160a01b031663b9b8af0b6040518163ffffffff1660e01b8152600401602060405180830381865afa15801561074c573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061077091906114cb565b6107ae5760405162461bcd60e51b815260206004820152600f60248201526e726f6c6c757020697320616c69766560881b6044820152606401610398565b5f5481106107f25760405162461bcd60e51b815260206004820152601160248201527019195c1bdcda5d081b9bdd08199bdd5b99607a1b6044820152606401610398565b7f00000000000000000000000000000000000000000000000000000000000000006001600160a01b0316632dfdf0b56040518163ffffffff1660e01b8152600401602060405180830381865afa15801561084e573d5f803e3d5ffd5b505050506040513d601f19601f8201168201806040525081019061087291906115de565b67ffffffffffffffff168110156108c45760405162461bcd60e51b81526020600482015260166024820152753232b837b9b4ba1034b731b63ab232b21037b710261960511b6044820152606401610398565b5f8181526003602052604090205460ff16156109155760405162461bcd60e51b815260206004820152601060248201526f19195c1bdcda5d081c99599d5b99195960821b6044820152606401610398565b5f818152600360209081526040808320805460ff1916600190811790915580835292819020815160608101835281546001600160a01b039081168083529583015416938101849052600290910154918101829052926109749291610fec565b805160208083015160408085015181516001600160a01b0393841681529384015292169184917f9b6b376c360398d12c81aedddd318b22684d9f270472fdeb275627f692b664f2910160405180910390a350506001600555565b6005546001146109f05760405162461bcd60e51b815260040161039890611560565b600260055534610a125760405162461bcd60e51b815260040161039890611588565b610a1e5f348484610d0e565b50506001600555565b604080516060810182525f8082526020820181905291810182905290548210610a865760405162461bcd60e51b815260206004820152601160248201527019195c1bdcda5d081b9bdd08199bdd5b99607a1b6044820152606401610398565b505f90815260016020818152604092839020835160608101855281546001600160a01b0390811682529382015490931691830191909152600201549181019190915290565b600554600114610aed5760405162461bcd60e51b815260040161039890611560565b600260058190555f878152602091909152604090205460ff1615610b485760405162461bcd60e51b81526020600482015260126024820152711dda5d1a191c985dd85b0818db185a5b595960721b6044820152606401610398565b60408051602081018890526001600160a01b03808816928201929092529085166060820152608081018490525f9060a00160408051601f1981840301815282825280516020918201209083015201604051602081830303815290604052805190602001209050610c4683837f00000000000000000000000000000000000000000000000000000000000000006001600160a01b031663d5767c8e8c6040518263ffffffff1660e01b8152600401610c0191815260200190565b602060405180830381865afa158015610c1c573d5f803e3d5ffd5b505050506040513d601f19601f82011682018060405250810190610c4091906115ae565b8461125c565b610c925760405162461bcd60e51b815260206004820152601860248201527f696e76616c6964207769746864726177616c2070726f6f6600000000000000006044820152606401610398565b5f878152600260205260409020805460ff19166001179055610cb5858786610fec565b604080516001600160a01b038781168252602082018790528816918a918a917ff13156968b65314f47b4b18704f2b5ad93a69546be9730ec9ccc47bc83e8eb7b910160405180910390a450506001600555505050505050565b7f00000000000000000000000000000000000000000000000000000000000000006001600160a01b031663b9b8af0b6040518163ffffffff1660e01b8152600401602060405180830381865afa158015610d6a573d5f803e3d5ffd5b505050506040513d601f19601f82011682018060405250810190610d8e91906114cb565b15610dcb5760405162461bcd60e51b815260206004820152600d60248201526c1c9bdb1b1d5c081a185b1d1959609a1b6044820152606401610398565b80610e0a5760405162461bcd60e51b815260206004820152600f60248201526e195b5c1d1e481c9958da5c1a595b9d608a1b6044820152606401610398565b5f80548180610e1883611505565b9091555060408051606081018252338082526001600160a01b0389811660208085018281528587018c81525f898152600193849052889020965187549086166001600160a01b031991821617885591519287018054939095169290911691909117909255905160029093019290925591519293509183907fffea873e15a001937dcf157ea27a0ecfb8648ec500d72caecf203ca7afba83cf90610ec090889088908b90611605565b60405180910390a45050505050565b6040516001600160a01b0384811660248301528381166044830152606482018390525f91829187169060840160408051601f198184030181529181526020820180516001600160e01b03166323b872dd60e01b17905251610f309190611628565b5f604051808303815f865af19150503d805f8114610f69576040519150601f19603f3d011682016040523d82523d5f602084013e610f6e565b606091505b5091509150818015610f98575080511580610f98575080806020019051810190610f9891906114cb565b610fe45760405162461bcd60e51b815260206004820152601960248201527f746f6b656e207472616e7366657246726f6d206661696c6564000000000000006044820152606401610398565b505050505050565b7f00000000000000000000000000000000000000000000000000000000000000006001600160a01b0316836001600160a01b0316036110a7576040516340c10f1960e01b81526001600160a01b038381166004830152602482018390527f000000000000000000000000000000000000000000000000000000000000000016906340c10f19906044015f604051808303815f87803b15801561108c575f80fd5b505af115801561109e573d5f803e3d5ffd5b50505050505050565b6001600160a01b038316611150575f826001600160a01b0316826040515f6040518083038185875af1925050503d805f81146110fe576040519150601f19603f3d011682016040523d82523d5f602084013e611103565b606091505b505090508061114a5760405162461bcd60e51b8152602060048201526013602482015272115512081d1c985b9cd9995c8819985a5b1959606a1b6044820152606401610398565b50505050565b6040516001600160a01b038381166024830152604482018390525f91829186169060640160408051601f198184030181529181526020820180516001600160e01b031663a9059cbb60e01b179052516111a99190611628565b5f604051808303815f865af19150503d805f81146111e2576040519150601f19603f3d011682016040523d82523d5f602084013e6111e7565b606091505b509150915081801561121157508051158061121157508080602001905181019061121191906114cb565b6112555760405162461bcd60e51b81526020600482015260156024820152741d1bdad95b881d1c985b9cd9995c8819985a5b1959605a1b6044820152606401610398565b5050505050565b5f81815b858110156112fb575f87878381811061127b5761127b611654565b9050602002013590508083106112ba576040805160208101839052908101849052606001604051602081830303815290604052805190602001206112e5565b6040805160208101859052908101829052606001604051602081830303815290604052805190602001205b92505080806112f390611505565b915050611260565b50831580159061130a57508381145b9695505050505050565b5f60208284031215611324575f80fd5b5035919050565b5f8083601f84011261133b575f80fd5b50813567ffffffffffffffff811115611352575f80fd5b602083019150836020828501011115611369575f80fd5b9250929050565b5f8060208385031215611381575f80fd5b823567ffffffffffffffff811115611397575f80fd5b6113a38582860161132b565b90969095509350505050565b80356001600160a01b03811681146113c5575f80fd5b919050565b5f805f80606085870312156113dd575f80fd5b6113e6856113af565b935060208501359250604085013567ffffffffffffffff811115611408575f80fd5b6114148782880161132b565b95989497509550505050565b5f805f805f805f60c0888a031215611436575f80fd5b873596506020880135955061144d604089016113af565b945061145b606089016113af565b93506080880135925060a088013567ffffffffffffffff8082111561147e575f80fd5b818a0191508a601f830112611491575f80fd5b81358181111561149f575f80fd5b8b60208260051b85010111156114b3575f80fd5b60208301945080935050505092959891949750929550565b5f602082840312156114db575f80fd5b815180151581146114ea575f80fd5b9392505050565b634e487b7160e01b5f52601160045260245ffd5b5f60018201611516576115166114f1565b5060010190565b81835281816020850137505f828201602090810191909152601f909101601f19169091010190565b602081525f61155860208301848661151d565b949350505050565b6020808252600e908201526d1c99595b9d1c985b9d0818d85b1b60921b604082015260600190565b6020808252600c908201526b1e995c9bc819195c1bdcda5d60a21b604082015260600190565b5f602082840312156115be575f80fd5b5051919050565b818103818111156115d8576115d86114f1565b92915050565b5f602082840312156115ee575f80fd5b815167ffffffffffffffff811681146114ea575f80fd5b604081525f61161860408301858761151d565b9050826020830152949350505050565b5f82515f5b81811015611647576020818601810151858301520161162d565b505f920191825250919050565b634e487b7160e01b5f52603260045260245ffdfea2646970667358221220cabb977f5464452244a85216d76b360f7694752f867c0ab9ab7933ee98fae13f64736f6c6343000815003360a060405234801561000f575f80fd5b503360805260805161083c61003a5f395f818161020201528181610370015261045c015261083c5ff3fe608060405234801561000f575f80fd5b50600436106100b1575f3560e01c806370a082311161006e57806370a082311461016d57806395d89b411461018c5780639dc29fac146101ad578063a9059cbb146101c0578063dd62ed3e146101d3578063e78cea92146101fd575f80fd5b806306fdde03146100b5578063095ea7b3146100f257806318160ddd1461011557806323b872dd1461012b578063313ce5671461013e57806340c10f1914610158575b5f80fd5b6100dc604051806040016040528060088152602001674a696e6743686f7560c01b81525081565b6040516100e991906106b4565b60405180910390f35b61010561010036600461071a565b61023c565b60405190151581526020016100e9565b61011d5f5481565b6040519081526020016100e9565b610105610139366004610742565b6102a8565b610146601281565b60405160ff90911681526020016100e9565b61016b61016636600461071a565b610365565b005b61011d61017b36600461077b565b60016020525f908152604090205481565b6100dc604051806040016040528060028152602001614a4360f01b81525081565b61016b6101bb36600461071a565b610451565b6101056101ce36600461071a565b610595565b61011d6101e136600461079b565b600260209081525f928352604080842090915290825290205481565b6102247f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b0390911681526020016100e9565b335f8181526002602090815260408083206001600160a01b038716808552925280832085905551919290917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925906102969086815260200190565b60405180910390a35060015b92915050565b6001600160a01b0383165f9081526002602090815260408083203384529091528120545f19811461034f57828110156103215760405162461bcd60e51b8152602060048201526016602482015275696e73756666696369656e7420616c6c6f77616e636560501b60448201526064015b60405180910390fd5b61032b83826107e0565b6001600160a01b0386165f9081526002602090815260408083203384529091529020555b61035a8585856105aa565b506001949350505050565b336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146103cb5760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b6044820152606401610318565b805f808282546103db91906107f3565b90915550506001600160a01b0382165f90815260016020526040812080548392906104079084906107f3565b90915550506040518181526001600160a01b038316905f907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef906020015b60405180910390a35050565b336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146104b75760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b6044820152606401610318565b6001600160a01b0382165f908152600160205260409020548111156105155760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b6044820152606401610318565b6001600160a01b0382165f908152600160205260408120805483929061053c9084906107e0565b92505081905550805f8082825461055391906107e0565b90915550506040518181525f906001600160a01b038416907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef90602001610445565b5f6105a13384846105aa565b50600192915050565b6001600160a01b0383165f908152600160205260409020548111156106085760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b6044820152606401610318565b6001600160a01b0383165f908152600160205260408120805483929061062f9084906107e0565b90915550506001600160a01b0382165f908152600160205260408120805483929061065b9084906107f3565b92505081905550816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040516106a791815260200190565b60405180910390a3505050565b5f6020808352835180828501525f5b818110156106df578581018301518582016040015282016106c3565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b0381168114610715575f80fd5b919050565b5f806040838503121561072b575f80fd5b610734836106ff565b946020939093013593505050565b5f805f60608486031215610754575f80fd5b61075d846106ff565b925061076b602085016106ff565b9150604084013590509250925092565b5f6020828403121561078b575f80fd5b610794826106ff565b9392505050565b5f80604083850312156107ac575f80fd5b6107b5836106ff565b91506107c3602084016106ff565b90509250929050565b634e487b7160e01b5f52601160045260245ffd5b818103818111156102a2576102a26107cc565b808201808211156102a2576102a26107cc56fea2646970667358221220c64502a4a0e1f4aa3c4e1dc9adfa2aabba1d72385b3cd5f887ddf421ade4e69864736f6c63430008150033",
+}
+
+// JingChouBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use JingChouBridgeMetaData.ABI instead.
+var JingChouBridgeABI = JingChouBridgeMetaData.ABI
+
+// JingChouBridgeBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use JingChouBridgeMetaData.Bin instead.
+var JingChouBridgeBin = JingChouBridgeMetaData.Bin
+
+// DeployJingChouBridge deploys a new Ethereum contract, binding an instance of JingChouBridge to it.
+func DeployJingChouBridge(auth *bind.TransactOpts, backend bind.ContractBackend, _rollup common.Address) (common.Address, *types.Transaction, *JingChouBridge, error) {
+	parsed, err := JingChouBridgeMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(JingChouBridgeBin), backend, _rollup)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &JingChouBridge{JingChouBridgeCaller: JingChouBridgeCaller{contract: contract}, JingChouBridgeTransactor: JingChouBridgeTransactor{contract: contract}, JingChouBridgeFilterer: JingChouBridgeFilterer{contract: contract}}, nil
+}
+
+// JingChouBridge is an auto generated Go binding around an Ethereum contract.
+type JingChouBridge struct {
+	JingChouBridgeCaller     // Read-only binding to the contract
+	JingChouBridgeTransactor // Write-only binding to the contract
+	JingChouBridgeFilterer   // Log filterer for contract events
+}
+
+// JingChouBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type JingChouBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type JingChouBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type JingChouBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type JingChouBridgeSession struct {
+	Contract     *JingChouBridge   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// JingChouBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type JingChouBridgeCallerSession struct {
+	Contract *JingChouBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// JingChouBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type JingChouBridgeTransactorSession struct {
+	Contract     *JingChouBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// JingChouBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type JingChouBridgeRaw struct {
+	Contract *JingChouBridge // Generic contract binding to access the raw methods on
+}
+
+// JingChouBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type JingChouBridgeCallerRaw struct {
+	Contract *JingChouBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// JingChouBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type JingChouBridgeTransactorRaw struct {
+	Contract *JingChouBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewJingChouBridge creates a new instance of JingChouBridge, bound to a specific deployed contract.
+func NewJingChouBridge(address common.Address, backend bind.ContractBackend) (*JingChouBridge, error) {
+	contract, err := bindJingChouBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridge{JingChouBridgeCaller: JingChouBridgeCaller{contract: contract}, JingChouBridgeTransactor: JingChouBridgeTransactor{contract: contract}, JingChouBridgeFilterer: JingChouBridgeFilterer{contract: contract}}, nil
+}
+
+// NewJingChouBridgeCaller creates a new read-only instance of JingChouBridge, bound to a specific deployed contract.
+func NewJingChouBridgeCaller(address common.Address, caller bind.ContractCaller) (*JingChouBridgeCaller, error) {
+	contract, err := bindJingChouBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeCaller{contract: contract}, nil
+}
+
+// NewJingChouBridgeTransactor creates a new write-only instance of JingChouBridge, bound to a specific deployed contract.
+func NewJingChouBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*JingChouBridgeTransactor, error) {
+	contract, err := bindJingChouBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeTransactor{contract: contract}, nil
+}
+
+// NewJingChouBridgeFilterer creates a new log filterer instance of JingChouBridge, bound to a specific deployed contract.
+func NewJingChouBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*JingChouBridgeFilterer, error) {
+	contract, err := bindJingChouBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeFilterer{contract: contract}, nil
+}
+
+// bindJingChouBridge binds a generic wrapper to an already deployed contract.
+func bindJingChouBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := JingChouBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouBridge *JingChouBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouBridge.Contract.JingChouBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouBridge *JingChouBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.JingChouBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouBridge *JingChouBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.JingChouBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouBridge *JingChouBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouBridge *JingChouBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouBridge *JingChouBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// MAXFORCEDTXSIZE is a free data retrieval call binding the contract method 0x4ab08a67.
+//
+// Solidity: function MAX_FORCED_TX_SIZE() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCaller) MAXFORCEDTXSIZE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "MAX_FORCED_TX_SIZE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MAXFORCEDTXSIZE is a free data retrieval call binding the contract method 0x4ab08a67.
+//
+// Solidity: function MAX_FORCED_TX_SIZE() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeSession) MAXFORCEDTXSIZE() (*big.Int, error) {
+	return _JingChouBridge.Contract.MAXFORCEDTXSIZE(&_JingChouBridge.CallOpts)
+}
+
+// MAXFORCEDTXSIZE is a free data retrieval call binding the contract method 0x4ab08a67.
+//
+// Solidity: function MAX_FORCED_TX_SIZE() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCallerSession) MAXFORCEDTXSIZE() (*big.Int, error) {
+	return _JingChouBridge.Contract.MAXFORCEDTXSIZE(&_JingChouBridge.CallOpts)
+}
+
+// DepositNonce is a free data retrieval call binding the contract method 0xde35f5cb.
+//
+// Solidity: function depositNonce() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCaller) DepositNonce(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "depositNonce")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DepositNonce is a free data retrieval call binding the contract method 0xde35f5cb.
+//
+// Solidity: function depositNonce() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeSession) DepositNonce() (*big.Int, error) {
+	return _JingChouBridge.Contract.DepositNonce(&_JingChouBridge.CallOpts)
+}
+
+// DepositNonce is a free data retrieval call binding the contract method 0xde35f5cb.
+//
+// Solidity: function depositNonce() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCallerSession) DepositNonce() (*big.Int, error) {
+	return _JingChouBridge.Contract.DepositNonce(&_JingChouBridge.CallOpts)
+}
+
+// DepositRefunded is a free data retrieval call binding the contract method 0xfa0161c0.
+//
+// Solidity: function depositRefunded(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeCaller) DepositRefunded(opts *bind.CallOpts, arg0 *big.Int) (bool, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "depositRefunded", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// DepositRefunded is a free data retrieval call binding the contract method 0xfa0161c0.
+//
+// Solidity: function depositRefunded(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeSession) DepositRefunded(arg0 *big.Int) (bool, error) {
+	return _JingChouBridge.Contract.DepositRefunded(&_JingChouBridge.CallOpts, arg0)
+}
+
+// DepositRefunded is a free data retrieval call binding the contract method 0xfa0161c0.
+//
+// Solidity: function depositRefunded(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeCallerSession) DepositRefunded(arg0 *big.Int) (bool, error) {
+	return _JingChouBridge.Contract.DepositRefunded(&_JingChouBridge.CallOpts, arg0)
+}
+
+// ForcedTxCount is a free data retrieval call binding the contract method 0x22f4a6aa.
+//
+// Solidity: function forcedTxCount() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCaller) ForcedTxCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "forcedTxCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ForcedTxCount is a free data retrieval call binding the contract method 0x22f4a6aa.
+//
+// Solidity: function forcedTxCount() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeSession) ForcedTxCount() (*big.Int, error) {
+	return _JingChouBridge.Contract.ForcedTxCount(&_JingChouBridge.CallOpts)
+}
+
+// ForcedTxCount is a free data retrieval call binding the contract method 0x22f4a6aa.
+//
+// Solidity: function forcedTxCount() view returns(uint256)
+func (_JingChouBridge *JingChouBridgeCallerSession) ForcedTxCount() (*big.Int, error) {
+	return _JingChouBridge.Contract.ForcedTxCount(&_JingChouBridge.CallOpts)
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0x9f9fb968.
+//
+// Solidity: function getDeposit(uint256 nonce) view returns((address,address,uint256))
+func (_JingChouBridge *JingChouBridgeCaller) GetDeposit(opts *bind.CallOpts, nonce *big.Int) (JingChouBridgeDepositRecord, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "getDeposit", nonce)
+
+	if err != nil {
+		return *new(JingChouBridgeDepositRecord), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(JingChouBridgeDepositRecord)).(*JingChouBridgeDepositRecord)
+
+	return out0, err
+
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0x9f9fb968.
+//
+// Solidity: function getDeposit(uint256 nonce) view returns((address,address,uint256))
+func (_JingChouBridge *JingChouBridgeSession) GetDeposit(nonce *big.Int) (JingChouBridgeDepositRecord, error) {
+	return _JingChouBridge.Contract.GetDeposit(&_JingChouBridge.CallOpts, nonce)
+}
+
+// GetDeposit is a free data retrieval call binding the contract method 0x9f9fb968.
+//
+// Solidity: function getDeposit(uint256 nonce) view returns((address,address,uint256))
+func (_JingChouBridge *JingChouBridgeCallerSession) GetDeposit(nonce *big.Int) (JingChouBridgeDepositRecord, error) {
+	return _JingChouBridge.Contract.GetDeposit(&_JingChouBridge.CallOpts, nonce)
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_JingChouBridge *JingChouBridgeCaller) NativeToken(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "nativeToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_JingChouBridge *JingChouBridgeSession) NativeToken() (common.Address, error) {
+	return _JingChouBridge.Contract.NativeToken(&_JingChouBridge.CallOpts)
+}
+
+// NativeToken is a free data retrieval call binding the contract method 0xe1758bd8.
+//
+// Solidity: function nativeToken() view returns(address)
+func (_JingChouBridge *JingChouBridgeCallerSession) NativeToken() (common.Address, error) {
+	return _JingChouBridge.Contract.NativeToken(&_JingChouBridge.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_JingChouBridge *JingChouBridgeCaller) Rollup(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "rollup")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_JingChouBridge *JingChouBridgeSession) Rollup() (common.Address, error) {
+	return _JingChouBridge.Contract.Rollup(&_JingChouBridge.CallOpts)
+}
+
+// Rollup is a free data retrieval call binding the contract method 0xcb23bcb5.
+//
+// Solidity: function rollup() view returns(address)
+func (_JingChouBridge *JingChouBridgeCallerSession) Rollup() (common.Address, error) {
+	return _JingChouBridge.Contract.Rollup(&_JingChouBridge.CallOpts)
+}
+
+// WithdrawalClaimed is a free data retrieval call binding the contract method 0x1d821854.
+//
+// Solidity: function withdrawalClaimed(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeCaller) WithdrawalClaimed(opts *bind.CallOpts, arg0 *big.Int) (bool, error) {
+	var out []interface{}
+	err := _JingChouBridge.contract.Call(opts, &out, "withdrawalClaimed", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// WithdrawalClaimed is a free data retrieval call binding the contract method 0x1d821854.
+//
+// Solidity: function withdrawalClaimed(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeSession) WithdrawalClaimed(arg0 *big.Int) (bool, error) {
+	return _JingChouBridge.Contract.WithdrawalClaimed(&_JingChouBridge.CallOpts, arg0)
+}
+
+// WithdrawalClaimed is a free data retrieval call binding the contract method 0x1d821854.
+//
+// Solidity: function withdrawalClaimed(uint256 ) view returns(bool)
+func (_JingChouBridge *JingChouBridgeCallerSession) WithdrawalClaimed(arg0 *big.Int) (bool, error) {
+	return _JingChouBridge.Contract.WithdrawalClaimed(&_JingChouBridge.CallOpts, arg0)
+}
+
+// ClaimWithdrawal is a paid mutator transaction binding the contract method 0xf941af15.
+//
+// Solidity: function claimWithdrawal(uint256 batchIndex, uint256 nonce, address recipient, address token, uint256 amount, bytes32[] proof) returns()
+func (_JingChouBridge *JingChouBridgeTransactor) ClaimWithdrawal(opts *bind.TransactOpts, batchIndex *big.Int, nonce *big.Int, recipient common.Address, token common.Address, amount *big.Int, proof [][32]byte) (*types.Transaction, error) {
+	return _JingChouBridge.contract.Transact(opts, "claimWithdrawal", batchIndex, nonce, recipient, token, amount, proof)
+}
+
+// ClaimWithdrawal is a paid mutator transaction binding the contract method 0xf941af15.
+//
+// Solidity: function claimWithdrawal(uint256 batchIndex, uint256 nonce, address recipient, address token, uint256 amount, bytes32[] proof) returns()
+func (_JingChouBridge *JingChouBridgeSession) ClaimWithdrawal(batchIndex *big.Int, nonce *big.Int, recipient common.Address, token common.Address, amount *big.Int, proof [][32]byte) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.ClaimWithdrawal(&_JingChouBridge.TransactOpts, batchIndex, nonce, recipient, token, amount, proof)
+}
+
+// ClaimWithdrawal is a paid mutator transaction binding the contract method 0xf941af15.
+//
+// Solidity: function claimWithdrawal(uint256 batchIndex, uint256 nonce, address recipient, address token, uint256 amount, bytes32[] proof) returns()
+func (_JingChouBridge *JingChouBridgeTransactorSession) ClaimWithdrawal(batchIndex *big.Int, nonce *big.Int, recipient common.Address, token common.Address, amount *big.Int, proof [][32]byte) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.ClaimWithdrawal(&_JingChouBridge.TransactOpts, batchIndex, nonce, recipient, token, amount, proof)
+}
+
+// DepositERC20 is a paid mutator transaction binding the contract method 0x5a67cb87.
+//
+// Solidity: function depositERC20(address token, uint256 amount, string recipient) returns()
+func (_JingChouBridge *JingChouBridgeTransactor) DepositERC20(opts *bind.TransactOpts, token common.Address, amount *big.Int, recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.contract.Transact(opts, "depositERC20", token, amount, recipient)
+}
+
+// DepositERC20 is a paid mutator transaction binding the contract method 0x5a67cb87.
+//
+// Solidity: function depositERC20(address token, uint256 amount, string recipient) returns()
+func (_JingChouBridge *JingChouBridgeSession) DepositERC20(token common.Address, amount *big.Int, recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.DepositERC20(&_JingChouBridge.TransactOpts, token, amount, recipient)
+}
+
+// DepositERC20 is a paid mutator transaction binding the contract method 0x5a67cb87.
+//
+// Solidity: function depositERC20(address token, uint256 amount, string recipient) returns()
+func (_JingChouBridge *JingChouBridgeTransactorSession) DepositERC20(token common.Address, amount *big.Int, recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.DepositERC20(&_JingChouBridge.TransactOpts, token, amount, recipient)
+}
+
+// DepositETH is a paid mutator transaction binding the contract method 0x9b1c48e6.
+//
+// Solidity: function depositETH(string recipient) payable returns()
+func (_JingChouBridge *JingChouBridgeTransactor) DepositETH(opts *bind.TransactOpts, recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.contract.Transact(opts, "depositETH", recipient)
+}
+
+// DepositETH is a paid mutator transaction binding the contract method 0x9b1c48e6.
+//
+// Solidity: function depositETH(string recipient) payable returns()
+func (_JingChouBridge *JingChouBridgeSession) DepositETH(recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.DepositETH(&_JingChouBridge.TransactOpts, recipient)
+}
+
+// DepositETH is a paid mutator transaction binding the contract method 0x9b1c48e6.
+//
+// Solidity: function depositETH(string recipient) payable returns()
+func (_JingChouBridge *JingChouBridgeTransactorSession) DepositETH(recipient string) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.DepositETH(&_JingChouBridge.TransactOpts, recipient)
+}
+
+// ForceTransaction is a paid mutator transaction binding the contract method 0x5109ba72.
+//
+// Solidity: function forceTransaction(bytes txn) returns()
+func (_JingChouBridge *JingChouBridgeTransactor) ForceTransaction(opts *bind.TransactOpts, txn []byte) (*types.Transaction, error) {
+	return _JingChouBridge.contract.Transact(opts, "forceTransaction", txn)
+}
+
+// ForceTransaction is a paid mutator transaction binding the contract method 0x5109ba72.
+//
+// Solidity: function forceTransaction(bytes txn) returns()
+func (_JingChouBridge *JingChouBridgeSession) ForceTransaction(txn []byte) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.ForceTransaction(&_JingChouBridge.TransactOpts, txn)
+}
+
+// ForceTransaction is a paid mutator transaction binding the contract method 0x5109ba72.
+//
+// Solidity: function forceTransaction(bytes txn) returns()
+func (_JingChouBridge *JingChouBridgeTransactorSession) ForceTransaction(txn []byte) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.ForceTransaction(&_JingChouBridge.TransactOpts, txn)
+}
+
+// RefundDeposit is a paid mutator transaction binding the contract method 0x6de0de7e.
+//
+// Solidity: function refundDeposit(uint256 nonce) returns()
+func (_JingChouBridge *JingChouBridgeTransactor) RefundDeposit(opts *bind.TransactOpts, nonce *big.Int) (*types.Transaction, error) {
+	return _JingChouBridge.contract.Transact(opts, "refundDeposit", nonce)
+}
+
+// RefundDeposit is a paid mutator transaction binding the contract method 0x6de0de7e.
+//
+// Solidity: function refundDeposit(uint256 nonce) returns()
+func (_JingChouBridge *JingChouBridgeSession) RefundDeposit(nonce *big.Int) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.RefundDeposit(&_JingChouBridge.TransactOpts, nonce)
+}
+
+// RefundDeposit is a paid mutator transaction binding the contract method 0x6de0de7e.
+//
+// Solidity: function refundDeposit(uint256 nonce) returns()
+func (_JingChouBridge *JingChouBridgeTransactorSession) RefundDeposit(nonce *big.Int) (*types.Transaction, error) {
+	return _JingChouBridge.Contract.RefundDeposit(&_JingChouBridge.TransactOpts, nonce)
+}
+
+// JingChouBridgeDepositInitiatedIterator is returned from FilterDepositInitiated and is used to iterate over the raw logs and unpacked data for DepositInitiated events raised by the JingChouBridge contract.
+type JingChouBridgeDepositInitiatedIterator struct {
+	Event *JingChouBridgeDepositInitiated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouBridgeDepositInitiatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouBridgeDepositInitiated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouBridgeDepositInitiated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouBridgeDepositInitiatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouBridgeDepositInitiatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouBridgeDepositInitiated represents a DepositInitiated event raised by the JingChouBridge contract.
+type JingChouBridgeDepositInitiated struct {
+	Nonce     *big.Int
+	Sender    common.Address
+	Token     common.Address
+	Recipient string
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositInitiated is a free log retrieval operation binding the contract event 0xffea873e15a001937dcf157ea27a0ecfb8648ec500d72caecf203ca7afba83cf.
+//
+// Solidity: event DepositInitiated(uint256 indexed nonce, address indexed sender, address indexed token, string recipient, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) FilterDepositInitiated(opts *bind.FilterOpts, nonce []*big.Int, sender []common.Address, token []common.Address) (*JingChouBridgeDepositInitiatedIterator, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.FilterLogs(opts, "DepositInitiated", nonceRule, senderRule, tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeDepositInitiatedIterator{contract: _JingChouBridge.contract, event: "DepositInitiated", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositInitiated is a free log subscription operation binding the contract event 0xffea873e15a001937dcf157ea27a0ecfb8648ec500d72caecf203ca7afba83cf.
+//
+// Solidity: event DepositInitiated(uint256 indexed nonce, address indexed sender, address indexed token, string recipient, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) WatchDepositInitiated(opts *bind.WatchOpts, sink chan<- *JingChouBridgeDepositInitiated, nonce []*big.Int, sender []common.Address, token []common.Address) (event.Subscription, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var tokenRule []interface{}
+	for _, tokenItem := range token {
+		tokenRule = append(tokenRule, tokenItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.WatchLogs(opts, "DepositInitiated", nonceRule, senderRule, tokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouBridgeDepositInitiated)
+				if err := _JingChouBridge.contract.UnpackLog(event, "DepositInitiated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositInitiated is a log parse operation binding the contract event 0xffea873e15a001937dcf157ea27a0ecfb8648ec500d72caecf203ca7afba83cf.
+//
+// Solidity: event DepositInitiated(uint256 indexed nonce, address indexed sender, address indexed token, string recipient, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) ParseDepositInitiated(log types.Log) (*JingChouBridgeDepositInitiated, error) {
+	event := new(JingChouBridgeDepositInitiated)
+	if err := _JingChouBridge.contract.UnpackLog(event, "DepositInitiated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouBridgeDepositRefundedIterator is returned from FilterDepositRefunded and is used to iterate over the raw logs and unpacked data for DepositRefunded events raised by the JingChouBridge contract.
+type JingChouBridgeDepositRefundedIterator struct {
+	Event *JingChouBridgeDepositRefunded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouBridgeDepositRefundedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouBridgeDepositRefunded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouBridgeDepositRefunded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouBridgeDepositRefundedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouBridgeDepositRefundedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouBridgeDepositRefunded represents a DepositRefunded event raised by the JingChouBridge contract.
+type JingChouBridgeDepositRefunded struct {
+	Nonce  *big.Int
+	Sender common.Address
+	Token  common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositRefunded is a free log retrieval operation binding the contract event 0x9b6b376c360398d12c81aedddd318b22684d9f270472fdeb275627f692b664f2.
+//
+// Solidity: event DepositRefunded(uint256 indexed nonce, address indexed sender, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) FilterDepositRefunded(opts *bind.FilterOpts, nonce []*big.Int, sender []common.Address) (*JingChouBridgeDepositRefundedIterator, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.FilterLogs(opts, "DepositRefunded", nonceRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeDepositRefundedIterator{contract: _JingChouBridge.contract, event: "DepositRefunded", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositRefunded is a free log subscription operation binding the contract event 0x9b6b376c360398d12c81aedddd318b22684d9f270472fdeb275627f692b664f2.
+//
+// Solidity: event DepositRefunded(uint256 indexed nonce, address indexed sender, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) WatchDepositRefunded(opts *bind.WatchOpts, sink chan<- *JingChouBridgeDepositRefunded, nonce []*big.Int, sender []common.Address) (event.Subscription, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.WatchLogs(opts, "DepositRefunded", nonceRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouBridgeDepositRefunded)
+				if err := _JingChouBridge.contract.UnpackLog(event, "DepositRefunded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositRefunded is a log parse operation binding the contract event 0x9b6b376c360398d12c81aedddd318b22684d9f270472fdeb275627f692b664f2.
+//
+// Solidity: event DepositRefunded(uint256 indexed nonce, address indexed sender, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) ParseDepositRefunded(log types.Log) (*JingChouBridgeDepositRefunded, error) {
+	event := new(JingChouBridgeDepositRefunded)
+	if err := _JingChouBridge.contract.UnpackLog(event, "DepositRefunded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouBridgeForcedTransactionQueuedIterator is returned from FilterForcedTransactionQueued and is used to iterate over the raw logs and unpacked data for ForcedTransactionQueued events raised by the JingChouBridge contract.
+type JingChouBridgeForcedTransactionQueuedIterator struct {
+	Event *JingChouBridgeForcedTransactionQueued // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouBridgeForcedTransactionQueuedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouBridgeForcedTransactionQueued)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouBridgeForcedTransactionQueued)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouBridgeForcedTransactionQueuedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouBridgeForcedTransactionQueuedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouBridgeForcedTransactionQueued represents a ForcedTransactionQueued event raised by the JingChouBridge contract.
+type JingChouBridgeForcedTransactionQueued struct {
+	Index  *big.Int
+	Sender common.Address
+	Txn    []byte
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterForcedTransactionQueued is a free log retrieval operation binding the contract event 0xe3010c9eb8189e44923b666c5266411388ef780cd10dafe1ba8a1ee6386b9dfe.
+//
+// Solidity: event ForcedTransactionQueued(uint256 indexed index, address indexed sender, bytes txn)
+func (_JingChouBridge *JingChouBridgeFilterer) FilterForcedTransactionQueued(opts *bind.FilterOpts, index []*big.Int, sender []common.Address) (*JingChouBridgeForcedTransactionQueuedIterator, error) {
+
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.FilterLogs(opts, "ForcedTransactionQueued", indexRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeForcedTransactionQueuedIterator{contract: _JingChouBridge.contract, event: "ForcedTransactionQueued", logs: logs, sub: sub}, nil
+}
+
+// WatchForcedTransactionQueued is a free log subscription operation binding the contract event 0xe3010c9eb8189e44923b666c5266411388ef780cd10dafe1ba8a1ee6386b9dfe.
+//
+// Solidity: event ForcedTransactionQueued(uint256 indexed index, address indexed sender, bytes txn)
+func (_JingChouBridge *JingChouBridgeFilterer) WatchForcedTransactionQueued(opts *bind.WatchOpts, sink chan<- *JingChouBridgeForcedTransactionQueued, index []*big.Int, sender []common.Address) (event.Subscription, error) {
+
+	var indexRule []interface{}
+	for _, indexItem := range index {
+		indexRule = append(indexRule, indexItem)
+	}
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.WatchLogs(opts, "ForcedTransactionQueued", indexRule, senderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouBridgeForcedTransactionQueued)
+				if err := _JingChouBridge.contract.UnpackLog(event, "ForcedTransactionQueued", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseForcedTransactionQueued is a log parse operation binding the contract event 0xe3010c9eb8189e44923b666c5266411388ef780cd10dafe1ba8a1ee6386b9dfe.
+//
+// Solidity: event ForcedTransactionQueued(uint256 indexed index, address indexed sender, bytes txn)
+func (_JingChouBridge *JingChouBridgeFilterer) ParseForcedTransactionQueued(log types.Log) (*JingChouBridgeForcedTransactionQueued, error) {
+	event := new(JingChouBridgeForcedTransactionQueued)
+	if err := _JingChouBridge.contract.UnpackLog(event, "ForcedTransactionQueued", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouBridgeWithdrawalClaimedIterator is returned from FilterWithdrawalClaimed and is used to iterate over the raw logs and unpacked data for WithdrawalClaimed events raised by the JingChouBridge contract.
+type JingChouBridgeWithdrawalClaimedIterator struct {
+	Event *JingChouBridgeWithdrawalClaimed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouBridgeWithdrawalClaimedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouBridgeWithdrawalClaimed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouBridgeWithdrawalClaimed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouBridgeWithdrawalClaimedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouBridgeWithdrawalClaimedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouBridgeWithdrawalClaimed represents a WithdrawalClaimed event raised by the JingChouBridge contract.
+type JingChouBridgeWithdrawalClaimed struct {
+	Nonce      *big.Int
+	BatchIndex *big.Int
+	Recipient  common.Address
+	Token      common.Address
+	Amount     *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawalClaimed is a free log retrieval operation binding the contract event 0xf13156968b65314f47b4b18704f2b5ad93a69546be9730ec9ccc47bc83e8eb7b.
+//
+// Solidity: event WithdrawalClaimed(uint256 indexed nonce, uint256 indexed batchIndex, address indexed recipient, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) FilterWithdrawalClaimed(opts *bind.FilterOpts, nonce []*big.Int, batchIndex []*big.Int, recipient []common.Address) (*JingChouBridgeWithdrawalClaimedIterator, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var batchIndexRule []interface{}
+	for _, batchIndexItem := range batchIndex {
+		batchIndexRule = append(batchIndexRule, batchIndexItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.FilterLogs(opts, "WithdrawalClaimed", nonceRule, batchIndexRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouBridgeWithdrawalClaimedIterator{contract: _JingChouBridge.contract, event: "WithdrawalClaimed", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawalClaimed is a free log subscription operation binding the contract event 0xf13156968b65314f47b4b18704f2b5ad93a69546be9730ec9ccc47bc83e8eb7b.
+//
+// Solidity: event WithdrawalClaimed(uint256 indexed nonce, uint256 indexed batchIndex, address indexed recipient, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) WatchWithdrawalClaimed(opts *bind.WatchOpts, sink chan<- *JingChouBridgeWithdrawalClaimed, nonce []*big.Int, batchIndex []*big.Int, recipient []common.Address) (event.Subscription, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var batchIndexRule []interface{}
+	for _, batchIndexItem := range batchIndex {
+		batchIndexRule = append(batchIndexRule, batchIndexItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _JingChouBridge.contract.WatchLogs(opts, "WithdrawalClaimed", nonceRule, batchIndexRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouBridgeWithdrawalClaimed)
+				if err := _JingChouBridge.contract.UnpackLog(event, "WithdrawalClaimed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawalClaimed is a log parse operation binding the contract event 0xf13156968b65314f47b4b18704f2b5ad93a69546be9730ec9ccc47bc83e8eb7b.
+//
+// Solidity: event WithdrawalClaimed(uint256 indexed nonce, uint256 indexed batchIndex, address indexed recipient, address token, uint256 amount)
+func (_JingChouBridge *JingChouBridgeFilterer) ParseWithdrawalClaimed(log types.Log) (*JingChouBridgeWithdrawalClaimed, error) {
+	event := new(JingChouBridgeWithdrawalClaimed)
+	if err := _JingChouBridge.contract.UnpackLog(event, "WithdrawalClaimed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,801 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// JingChouChildBridgeMetaData contains all meta data concerning the JingChouChildBridge contract.
+var JingChouChildBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_relayer\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"}],\"name\":\"BridgedTokenCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"DepositFinalized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"l1Recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"WithdrawalInitiated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"bridgedTokens\",\"outputs\":[{\"internalType\":\"contractBridgedERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"depositFinalized\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"},{\"internalType\":\"uint8\",\"name\":\"decimals\",\"type\":\"uint8\"}],\"name\":\"finalizeDeposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"relayer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"l1Recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x60a060405234801561000f575f80fd5b5060405161144d38038061144d83398101604081905261002e9161003f565b6001600160a01b031660805261006c565b5f6020828403121561004f575f80fd5b81516001600160a01b0381168114610065575f80fd5b9392505050565b6080516113c361008a5f395f8181609501526102be01526113c35ff3fe608060405234801561000f575f80fd5b5060043610610055575f3560e01c806375014eb8146100595780638406c07914610090578063a0a1228f146100cf578063d9caed12146100f7578063f1235ded1461010c575b5f80fd5b61007b610067366004610504565b60016020525f908152604090205460ff1681565b60405190151581526020015b60405180910390f35b6100b77f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b039091168152602001610087565b6100b76100dd366004610536565b5f602081905290815260409020546001600160a01b031681565b61010a610105366004610556565b61011f565b005b61010a61011a3660046105d4565b6102b3565b6001600160a01b038084165f90815260208190526040902054168061017f5760405162461bcd60e51b81526020600482015260116024820152701d1bdad95b881b9bdd08189c9a5919d959607a1b60448201526064015b60405180910390fd5b5f82116101c05760405162461bcd60e51b815260206004820152600f60248201526e1e995c9bc81dda5d1a191c985dd85b608a1b6044820152606401610176565b6001600160a01b03831661020a5760405162461bcd60e51b81526020600482015260116024820152701a5b9d985b1a59081c9958da5c1a595b9d607a1b6044820152606401610176565b604051632770a7eb60e21b8152336004820152602481018390526001600160a01b03821690639dc29fac906044015f604051808303815f87803b15801561024f575f80fd5b505af1158015610261573d5f803e3d5ffd5b5050604080516001600160a01b03878116825260208201879052881693503392507f2fc3848834aac8e883a2d2a17a7514dc4f2d3dd268089df9b9f5d918259ef3b0910160405180910390a350505050565b336001600160a01b037f0000000000000000000000000000000000000000000000000000000000000000161461031a5760405162461bcd60e51b815260206004820152600c60248201526b37b7363c903932b630bcb2b960a11b6044820152606401610176565b5f8981526001602052604090205460ff161561036c5760405162461bcd60e51b815260206004820152601160248201527019195c1bdcda5d08199a5b985b1a5e9959607a1b6044820152606401610176565b5f898152600160208181526040808420805460ff19169093179092556001600160a01b03808c1684529083905291205416806104405785858585858d6040516103b4906104f7565b6103c3969594939291906106b0565b604051809103905ff0801580156103dc573d5f803e3d5ffd5b506001600160a01b038a81165f818152602081815260409182902080546001600160a01b03191694861694851790559051928352929350917f2303dd1075af0b3a642c60f917a98a60898e1d346a9a1d6e4d62ba89fb12a58e910160405180910390a25b6040516340c10f1960e01b81526001600160a01b038981166004830152602482018990528216906340c10f19906044015f604051808303815f87803b158015610487575f80fd5b505af1158015610499573d5f803e3d5ffd5b50505050876001600160a01b0316896001600160a01b03168b7f773838ccda00cd4d00a48093d13ff47581b108fdd9551a4f91865e4d315a52e38a6040516104e391815260200190565b60405180910390a450505050505050505050565b610c90806106fe83390190565b5f60208284031215610514575f80fd5b5035919050565b80356001600160a01b0381168114610531575f80fd5b919050565b5f60208284031215610546575f80fd5b61054f8261051b565b9392505050565b5f805f60608486031215610568575f80fd5b6105718461051b565b925061057f6020850161051b565b9150604084013590509250925092565b5f8083601f84011261059f575f80fd5b50813567ffffffffffffffff8111156105b6575f80fd5b6020830191508360208285010111156105cd575f80fd5b9250929050565b5f805f805f805f805f60e08a8c0312156105ec575f80fd5b893598506105fc60208b0161051b565b975061060a60408b0161051b565b965060608a0135955060808a013567ffffffffffffffff8082111561062d575f80fd5b6106398d838e0161058f565b909750955060a08c0135915080821115610651575f80fd5b5061065e8c828d0161058f565b90945092505060c08a013560ff81168114610677575f80fd5b809150509295985092959850929598565b81835281816020850137505f828201602090810191909152601f909101601f19169091010190565b608081525f6106c360808301888a610688565b82810360208401526106d6818789610688565b60ff95909516604084015250506001600160a01b039190911660609091015294935050505056fe60e060405234801562000010575f80fd5b5060405162000c9038038062000c9083398101604081905262000033916200012f565b5f62000040858262000258565b5060016200004f848262000258565b5060ff9091166080523360a0526001600160a01b031660c05250620003209050565b634e487b7160e01b5f52604160045260245ffd5b5f82601f83011262000095575f80fd5b81516001600160401b0380821115620000b257620000b262000071565b604051601f8301601f19908116603f01168101908282118183101715620000dd57620000dd62000071565b81604052838152602092508683858801011115620000f9575f80fd5b5f91505b838210156200011c5785820183015181830184015290820190620000fd565b5f93810190920192909252949350505050565b5f805f806080858703121562000143575f80fd5b84516001600160401b03808211156200015a575f80fd5b620001688883890162000085565b955060208701519150808211156200017e575f80fd5b506200018d8782880162000085565b935050604085015160ff81168114620001a4575f80fd5b60608601519092506001600160a01b0381168114620001c1575f80fd5b939692955090935050565b600181811c90821680620001e157607f821691505b6020821081036200020057634e487b7160e01b5f52602260045260245ffd5b50919050565b601f82111562000253575f81815260208120601f850160051c810160208610156200022e5750805b601f850160051c820191505b818110156200024f578281556001016200023a565b5050505b505050565b81516001600160401b0381111562000274576200027462000071565b6200028c81620002858454620001cc565b8462000206565b602080601f831160018114620002c2575f8415620002aa5750858301515b5f19600386901b1c1916600185901b1785556200024f565b5f85815260208120601f198616915b82811015620002f257888601518255948401946001909101908401620002d1565b50858210156200031057878501515f19600388901b60f8161c191681555b5050505050600190811b01905550565b60805160a05160c051610937620003595f395f6101da01525f818161024301528181610424015261051e01525f61013f01526109375ff3fe608060405234801561000f575f80fd5b50600436106100cb575f3560e01c806370a0823111610088578063a9059cbb11610063578063a9059cbb146101c2578063c01e1bd6146101d5578063dd62ed3e14610214578063e78cea921461023e575f80fd5b806370a082311461018857806395d89b41146101a75780639dc29fac146101af575f80fd5b806306fdde03146100cf578063095ea7b3146100ed57806318160ddd1461011057806323b872dd14610127578063313ce5671461013a57806340c10f1914610173575b5f80fd5b6100d7610265565b6040516100e49190610777565b60405180910390f35b6101006100fb3660046107dd565b6102f0565b60405190151581526020016100e4565b61011960025481565b6040519081526020016100e4565b610100610135366004610805565b61035c565b6101617f000000000000000000000000000000000000000000000000000000000000000081565b60405160ff90911681526020016100e4565b6101866101813660046107dd565b610419565b005b61011961019636600461083e565b60036020525f908152604090205481565b6100d7610506565b6101866101bd3660046107dd565b610513565b6101006101d03660046107dd565b610658565b6101fc7f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b0390911681526020016100e4565b61011961022236600461085e565b600460209081525f928352604080842090915290825290205481565b6101fc7f000000000000000000000000000000000000000000000000000000000000000081565b5f80546102719061088f565b80601f016020809104026020016040519081016040528092919081815260200182805461029d9061088f565b80156102e85780601f106102bf576101008083540402835291602001916102e8565b820191905f5260205f20905b8154815290600101906020018083116102cb57829003601f168201915b505050505081565b335f8181526004602090815260408083206001600160a01b038716808552925280832085905551919290917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b9259061034a9086815260200190565b60405180910390a35060015b92915050565b6001600160a01b0383165f9081526004602090815260408083203384529091528120545f19811461040357828110156103d55760405162461bcd60e51b8152602060048201526016602482015275696e73756666696369656e7420616c6c6f77616e636560501b60448201526064015b60405180910390fd5b6103df83826108db565b6001600160a01b0386165f9081526004602090815260408083203384529091529020555b61040e85858561066d565b506001949350505050565b336001600160a01b037f0000000000000000000000000000000000000000000000000000000000000000161461047f5760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b60448201526064016103cc565b8060025f82825461049091906108ee565b90915550506001600160a01b0382165f90815260036020526040812080548392906104bc9084906108ee565b90915550506040518181526001600160a01b038316905f907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef906020015b60405180910390a35050565b600180546102719061088f565b336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146105795760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b60448201526064016103cc565b6001600160a01b0382165f908152600360205260409020548111156105d75760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b60448201526064016103cc565b6001600160a01b0382165f90815260036020526040812080548392906105fe9084906108db565b925050819055508060025f82825461061691906108db565b90915550506040518181525f906001600160a01b038416907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef906020016104fa565b5f61066433848461066d565b50600192915050565b6001600160a01b0383165f908152600360205260409020548111156106cb5760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b60448201526064016103cc565b6001600160a01b0383165f90815260036020526040812080548392906106f29084906108db565b90915550506001600160a01b0382165f908152600360205260408120805483929061071e9084906108ee565b92505081905550816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef8360405161076a91815260200190565b60405180910390a3505050565b5f6020808352835180828501525f5b818110156107a257858101830151858201604001528201610786565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b03811681146107d8575f80fd5b919050565b5f80604083850312156107ee575f80fd5b6107f7836107c2565b946020939093013593505050565b5f805f60608486031215610817575f80fd5b610820846107c2565b925061082e602085016107c2565b9150604084013590509250925092565b5f6020828403121561084e575f80fd5b610857826107c2565b9392505050565b5f806040838503121561086f575f80fd5b610878836107c2565b9150610886602084016107c2565b90509250929050565b600181811c908216806108a357607f821691505b6020821081036108c157634e487b7160e01b5f52602260045260245ffd5b50919050565b634e487b7160e01b5f52601160045260245ffd5b81810381811115610356576103566108c7565b80820180821115610356576103566108c756fea2646970667358221220ed274d403500ff19cda230733f081408d63311d7c99487ac1d9958788a002a7464736f6c63430008150033a26469706673582212200cf7b5382eb83c4cd603e62e04ce89b42a876151da8b29da025e4bfd09f84d8264736f6c63430008150033",
+}
+
+// JingChouChildBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use JingChouChildBridgeMetaData.ABI instead.
+var JingChouChildBridgeABI = JingChouChildBridgeMetaData.ABI
+
+// JingChouChildBridgeBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use JingChouChildBridgeMetaData.Bin instead.
+var JingChouChildBridgeBin = JingChouChildBridgeMetaData.Bin
+
+// DeployJingChouChildBridge deploys a new Ethereum contract, binding an instance of JingChouChildBridge to it.
+func DeployJingChouChildBridge(auth *bind.TransactOpts, backend bind.ContractBackend, _relayer common.Address) (common.Address, *types.Transaction, *JingChouChildBridge, error) {
+	parsed, err := JingChouChildBridgeMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(JingChouChildBridgeBin), backend, _relayer)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &JingChouChildBridge{JingChouChildBridgeCaller: JingChouChildBridgeCaller{contract: contract}, JingChouChildBridgeTransactor: JingChouChildBridgeTransactor{contract: contract}, JingChouChildBridgeFilterer: JingChouChildBridgeFilterer{contract: contract}}, nil
+}
+
+// JingChouChildBridge is an auto generated Go binding around an Ethereum contract.
+type JingChouChildBridge struct {
+	JingChouChildBridgeCaller     // Read-only binding to the contract
+	JingChouChildBridgeTransactor // Write-only binding to the contract
+	JingChouChildBridgeFilterer   // Log filterer for contract events
+}
+
+// JingChouChildBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type JingChouChildBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouChildBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type JingChouChildBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouChildBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type JingChouChildBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouChildBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type JingChouChildBridgeSession struct {
+	Contract     *JingChouChildBridge // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts        // Call options to use throughout this session
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// JingChouChildBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type JingChouChildBridgeCallerSession struct {
+	Contract *JingChouChildBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts              // Call options to use throughout this session
+}
+
+// JingChouChildBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type JingChouChildBridgeTransactorSession struct {
+	Contract     *JingChouChildBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts              // Transaction auth options to use throughout this session
+}
+
+// JingChouChildBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type JingChouChildBridgeRaw struct {
+	Contract *JingChouChildBridge // Generic contract binding to access the raw methods on
+}
+
+// JingChouChildBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type JingChouChildBridgeCallerRaw struct {
+	Contract *JingChouChildBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// JingChouChildBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type JingChouChildBridgeTransactorRaw struct {
+	Contract *JingChouChildBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewJingChouChildBridge creates a new instance of JingChouChildBridge, bound to a specific deployed contract.
+func NewJingChouChildBridge(address common.Address, backend bind.ContractBackend) (*JingChouChildBridge, error) {
+	contract, err := bindJingChouChildBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridge{JingChouChildBridgeCaller: JingChouChildBridgeCaller{contract: contract}, JingChouChildBridgeTransactor: JingChouChildBridgeTransactor{contract: contract}, JingChouChildBridgeFilterer: JingChouChildBridgeFilterer{contract: contract}}, nil
+}
+
+// NewJingChouChildBridgeCaller creates a new read-only instance of JingChouChildBridge, bound to a specific deployed contract.
+func NewJingChouChildBridgeCaller(address common.Address, caller bind.ContractCaller) (*JingChouChildBridgeCaller, error) {
+	contract, err := bindJingChouChildBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeCaller{contract: contract}, nil
+}
+
+// NewJingChouChildBridgeTransactor creates a new write-only instance of JingChouChildBridge, bound to a specific deployed contract.
+func NewJingChouChildBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*JingChouChildBridgeTransactor, error) {
+	contract, err := bindJingChouChildBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeTransactor{contract: contract}, nil
+}
+
+// NewJingChouChildBridgeFilterer creates a new log filterer instance of JingChouChildBridge, bound to a specific deployed contract.
+func NewJingChouChildBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*JingChouChildBridgeFilterer, error) {
+	contract, err := bindJingChouChildBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeFilterer{contract: contract}, nil
+}
+
+// bindJingChouChildBridge binds a generic wrapper to an already deployed contract.
+func bindJingChouChildBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := JingChouChildBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouChildBridge *JingChouChildBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouChildBridge.Contract.JingChouChildBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouChildBridge *JingChouChildBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.JingChouChildBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouChildBridge *JingChouChildBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.JingChouChildBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouChildBridge *JingChouChildBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouChildBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouChildBridge *JingChouChildBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouChildBridge *JingChouChildBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// BridgedTokens is a free data retrieval call binding the contract method 0xa0a1228f.
+//
+// Solidity: function bridgedTokens(address ) view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeCaller) BridgedTokens(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouChildBridge.contract.Call(opts, &out, "bridgedTokens", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BridgedTokens is a free data retrieval call binding the contract method 0xa0a1228f.
+//
+// Solidity: function bridgedTokens(address ) view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeSession) BridgedTokens(arg0 common.Address) (common.Address, error) {
+	return _JingChouChildBridge.Contract.BridgedTokens(&_JingChouChildBridge.CallOpts, arg0)
+}
+
+// BridgedTokens is a free data retrieval call binding the contract method 0xa0a1228f.
+//
+// Solidity: function bridgedTokens(address ) view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeCallerSession) BridgedTokens(arg0 common.Address) (common.Address, error) {
+	return _JingChouChildBridge.Contract.BridgedTokens(&_JingChouChildBridge.CallOpts, arg0)
+}
+
+// DepositFinalized is a free data retrieval call binding the contract method 0x75014eb8.
+//
+// Solidity: function depositFinalized(uint256 ) view returns(bool)
+func (_JingChouChildBridge *JingChouChildBridgeCaller) DepositFinalized(opts *bind.CallOpts, arg0 *big.Int) (bool, error) {
+	var out []interface{}
+	err := _JingChouChildBridge.contract.Call(opts, &out, "depositFinalized", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// DepositFinalized is a free data retrieval call binding the contract method 0x75014eb8.
+//
+// Solidity: function depositFinalized(uint256 ) view returns(bool)
+func (_JingChouChildBridge *JingChouChildBridgeSession) DepositFinalized(arg0 *big.Int) (bool, error) {
+	return _JingChouChildBridge.Contract.DepositFinalized(&_JingChouChildBridge.CallOpts, arg0)
+}
+
+// DepositFinalized is a free data retrieval call binding the contract method 0x75014eb8.
+//
+// Solidity: function depositFinalized(uint256 ) view returns(bool)
+func (_JingChouChildBridge *JingChouChildBridgeCallerSession) DepositFinalized(arg0 *big.Int) (bool, error) {
+	return _JingChouChildBridge.Contract.DepositFinalized(&_JingChouChildBridge.CallOpts, arg0)
+}
+
+// Relayer is a free data retrieval call binding the contract method 0x8406c079.
+//
+// Solidity: function relayer() view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeCaller) Relayer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouChildBridge.contract.Call(opts, &out, "relayer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Relayer is a free data retrieval call binding the contract method 0x8406c079.
+//
+// Solidity: function relayer() view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeSession) Relayer() (common.Address, error) {
+	return _JingChouChildBridge.Contract.Relayer(&_JingChouChildBridge.CallOpts)
+}
+
+// Relayer is a free data retrieval call binding the contract method 0x8406c079.
+//
+// Solidity: function relayer() view returns(address)
+func (_JingChouChildBridge *JingChouChildBridgeCallerSession) Relayer() (common.Address, error) {
+	return _JingChouChildBridge.Contract.Relayer(&_JingChouChildBridge.CallOpts)
+}
+
+// FinalizeDeposit is a paid mutator transaction binding the contract method 0xf1235ded.
+//
+// Solidity: function finalizeDeposit(uint256 nonce, address l1Token, address to, uint256 amount, string name, string symbol, uint8 decimals) returns()
+func (_JingChouChildBridge *JingChouChildBridgeTransactor) FinalizeDeposit(opts *bind.TransactOpts, nonce *big.Int, l1Token common.Address, to common.Address, amount *big.Int, name string, symbol string, decimals uint8) (*types.Transaction, error) {
+	return _JingChouChildBridge.contract.Transact(opts, "finalizeDeposit", nonce, l1Token, to, amount, name, symbol, decimals)
+}
+
+// FinalizeDeposit is a paid mutator transaction binding the contract method 0xf1235ded.
+//
+// Solidity: function finalizeDeposit(uint256 nonce, address l1Token, address to, uint256 amount, string name, string symbol, uint8 decimals) returns()
+func (_JingChouChildBridge *JingChouChildBridgeSession) FinalizeDeposit(nonce *big.Int, l1Token common.Address, to common.Address, amount *big.Int, name string, symbol string, decimals uint8) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.FinalizeDeposit(&_JingChouChildBridge.TransactOpts, nonce, l1Token, to, amount, name, symbol, decimals)
+}
+
+// FinalizeDeposit is a paid mutator transaction binding the contract method 0xf1235ded.
+//
+// Solidity: function finalizeDeposit(uint256 nonce, address l1Token, address to, uint256 amount, string name, string symbol, uint8 decimals) returns()
+func (_JingChouChildBridge *JingChouChildBridgeTransactorSession) FinalizeDeposit(nonce *big.Int, l1Token common.Address, to common.Address, amount *big.Int, name string, symbol string, decimals uint8) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.FinalizeDeposit(&_JingChouChildBridge.TransactOpts, nonce, l1Token, to, amount, name, symbol, decimals)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xd9caed12.
+//
+// Solidity: function withdraw(address l1Token, address l1Recipient, uint256 amount) returns()
+func (_JingChouChildBridge *JingChouChildBridgeTransactor) Withdraw(opts *bind.TransactOpts, l1Token common.Address, l1Recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouChildBridge.contract.Transact(opts, "withdraw", l1Token, l1Recipient, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xd9caed12.
+//
+// Solidity: function withdraw(address l1Token, address l1Recipient, uint256 amount) returns()
+func (_JingChouChildBridge *JingChouChildBridgeSession) Withdraw(l1Token common.Address, l1Recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.Withdraw(&_JingChouChildBridge.TransactOpts, l1Token, l1Recipient, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xd9caed12.
+//
+// Solidity: function withdraw(address l1Token, address l1Recipient, uint256 amount) returns()
+func (_JingChouChildBridge *JingChouChildBridgeTransactorSession) Withdraw(l1Token common.Address, l1Recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouChildBridge.Contract.Withdraw(&_JingChouChildBridge.TransactOpts, l1Token, l1Recipient, amount)
+}
+
+// JingChouChildBridgeBridgedTokenCreatedIterator is returned from FilterBridgedTokenCreated and is used to iterate over the raw logs and unpacked data for BridgedTokenCreated events raised by the JingChouChildBridge contract.
+type JingChouChildBridgeBridgedTokenCreatedIterator struct {
+	Event *JingChouChildBridgeBridgedTokenCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouChildBridgeBridgedTokenCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouChildBridgeBridgedTokenCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouChildBridgeBridgedTokenCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouChildBridgeBridgedTokenCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouChildBridgeBridgedTokenCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouChildBridgeBridgedTokenCreated represents a BridgedTokenCreated event raised by the JingChouChildBridge contract.
+type JingChouChildBridgeBridgedTokenCreated struct {
+	L1Token common.Address
+	Token   common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterBridgedTokenCreated is a free log retrieval operation binding the contract event 0x2303dd1075af0b3a642c60f917a98a60898e1d346a9a1d6e4d62ba89fb12a58e.
+//
+// Solidity: event BridgedTokenCreated(address indexed l1Token, address token)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) FilterBridgedTokenCreated(opts *bind.FilterOpts, l1Token []common.Address) (*JingChouChildBridgeBridgedTokenCreatedIterator, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.FilterLogs(opts, "BridgedTokenCreated", l1TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeBridgedTokenCreatedIterator{contract: _JingChouChildBridge.contract, event: "BridgedTokenCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchBridgedTokenCreated is a free log subscription operation binding the contract event 0x2303dd1075af0b3a642c60f917a98a60898e1d346a9a1d6e4d62ba89fb12a58e.
+//
+// Solidity: event BridgedTokenCreated(address indexed l1Token, address token)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) WatchBridgedTokenCreated(opts *bind.WatchOpts, sink chan<- *JingChouChildBridgeBridgedTokenCreated, l1Token []common.Address) (event.Subscription, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.WatchLogs(opts, "BridgedTokenCreated", l1TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouChildBridgeBridgedTokenCreated)
+				if err := _JingChouChildBridge.contract.UnpackLog(event, "BridgedTokenCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBridgedTokenCreated is a log parse operation binding the contract event 0x2303dd1075af0b3a642c60f917a98a60898e1d346a9a1d6e4d62ba89fb12a58e.
+//
+// Solidity: event BridgedTokenCreated(address indexed l1Token, address token)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) ParseBridgedTokenCreated(log types.Log) (*JingChouChildBridgeBridgedTokenCreated, error) {
+	event := new(JingChouChildBridgeBridgedTokenCreated)
+	if err := _JingChouChildBridge.contract.UnpackLog(event, "BridgedTokenCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouChildBridgeDepositFinalizedIterator is returned from FilterDepositFinalized and is used to iterate over the raw logs and unpacked data for DepositFinalized events raised by the JingChouChildBridge contract.
+type JingChouChildBridgeDepositFinalizedIterator struct {
+	Event *JingChouChildBridgeDepositFinalized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouChildBridgeDepositFinalizedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouChildBridgeDepositFinalized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouChildBridgeDepositFinalized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouChildBridgeDepositFinalizedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouChildBridgeDepositFinalizedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouChildBridgeDepositFinalized represents a DepositFinalized event raised by the JingChouChildBridge contract.
+type JingChouChildBridgeDepositFinalized struct {
+	Nonce   *big.Int
+	L1Token common.Address
+	To      common.Address
+	Amount  *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterDepositFinalized is a free log retrieval operation binding the contract event 0x773838ccda00cd4d00a48093d13ff47581b108fdd9551a4f91865e4d315a52e3.
+//
+// Solidity: event DepositFinalized(uint256 indexed nonce, address indexed l1Token, address indexed to, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) FilterDepositFinalized(opts *bind.FilterOpts, nonce []*big.Int, l1Token []common.Address, to []common.Address) (*JingChouChildBridgeDepositFinalizedIterator, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.FilterLogs(opts, "DepositFinalized", nonceRule, l1TokenRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeDepositFinalizedIterator{contract: _JingChouChildBridge.contract, event: "DepositFinalized", logs: logs, sub: sub}, nil
+}
+
+// WatchDepositFinalized is a free log subscription operation binding the contract event 0x773838ccda00cd4d00a48093d13ff47581b108fdd9551a4f91865e4d315a52e3.
+//
+// Solidity: event DepositFinalized(uint256 indexed nonce, address indexed l1Token, address indexed to, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) WatchDepositFinalized(opts *bind.WatchOpts, sink chan<- *JingChouChildBridgeDepositFinalized, nonce []*big.Int, l1Token []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var nonceRule []interface{}
+	for _, nonceItem := range nonce {
+		nonceRule = append(nonceRule, nonceItem)
+	}
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.WatchLogs(opts, "DepositFinalized", nonceRule, l1TokenRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouChildBridgeDepositFinalized)
+				if err := _JingChouChildBridge.contract.UnpackLog(event, "DepositFinalized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDepositFinalized is a log parse operation binding the contract event 0x773838ccda00cd4d00a48093d13ff47581b108fdd9551a4f91865e4d315a52e3.
+//
+// Solidity: event DepositFinalized(uint256 indexed nonce, address indexed l1Token, address indexed to, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) ParseDepositFinalized(log types.Log) (*JingChouChildBridgeDepositFinalized, error) {
+	event := new(JingChouChildBridgeDepositFinalized)
+	if err := _JingChouChildBridge.contract.UnpackLog(event, "DepositFinalized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouChildBridgeWithdrawalInitiatedIterator is returned from FilterWithdrawalInitiated and is used to iterate over the raw logs and unpacked data for WithdrawalInitiated events raised by the JingChouChildBridge contract.
+type JingChouChildBridgeWithdrawalInitiatedIterator struct {
+	Event *JingChouChildBridgeWithdrawalInitiated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouChildBridgeWithdrawalInitiatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouChildBridgeWithdrawalInitiated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouChildBridgeWithdrawalInitiated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouChildBridgeWithdrawalInitiatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouChildBridgeWithdrawalInitiatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouChildBridgeWithdrawalInitiated represents a WithdrawalInitiated event raised by the JingChouChildBridge contract.
+type JingChouChildBridgeWithdrawalInitiated struct {
+	Sender      common.Address
+	L1Token     common.Address
+	L1Recipient common.Address
+	Amount      *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawalInitiated is a free log retrieval operation binding the contract event 0x2fc3848834aac8e883a2d2a17a7514dc4f2d3dd268089df9b9f5d918259ef3b0.
+//
+// Solidity: event WithdrawalInitiated(address indexed sender, address indexed l1Token, address l1Recipient, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) FilterWithdrawalInitiated(opts *bind.FilterOpts, sender []common.Address, l1Token []common.Address) (*JingChouChildBridgeWithdrawalInitiatedIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.FilterLogs(opts, "WithdrawalInitiated", senderRule, l1TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouChildBridgeWithdrawalInitiatedIterator{contract: _JingChouChildBridge.contract, event: "WithdrawalInitiated", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawalInitiated is a free log subscription operation binding the contract event 0x2fc3848834aac8e883a2d2a17a7514dc4f2d3dd268089df9b9f5d918259ef3b0.
+//
+// Solidity: event WithdrawalInitiated(address indexed sender, address indexed l1Token, address l1Recipient, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) WatchWithdrawalInitiated(opts *bind.WatchOpts, sink chan<- *JingChouChildBridgeWithdrawalInitiated, sender []common.Address, l1Token []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+
+	logs, sub, err := _JingChouChildBridge.contract.WatchLogs(opts, "WithdrawalInitiated", senderRule, l1TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouChildBridgeWithdrawalInitiated)
+				if err := _JingChouChildBridge.contract.UnpackLog(event, "WithdrawalInitiated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawalInitiated is a log parse operation binding the contract event 0x2fc3848834aac8e883a2d2a17a7514dc4f2d3dd268089df9b9f5d918259ef3b0.
+//
+// Solidity: event WithdrawalInitiated(address indexed sender, address indexed l1Token, address l1Recipient, uint256 amount)
+func (_JingChouChildBridge *JingChouChildBridgeFilterer) ParseWithdrawalInitiated(log types.Log) (*JingChouChildBridgeWithdrawalInitiated, error) {
+	event := new(JingChouChildBridgeWithdrawalInitiated)
+	if err := _JingChouChildBridge.contract.UnpackLog(event, "WithdrawalInitiated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
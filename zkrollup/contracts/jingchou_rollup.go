@@ -0,0 +1,943 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// JingChouRollupBatch is an auto generated low-level Go binding around an user-defined struct.
+type JingChouRollupBatch struct {
+	FromBlock      uint64
+	ToBlock        uint64
+	PreStateRoot   [32]byte
+	NewStateRoot   [32]byte
+	WithdrawalRoot [32]byte
+	DepositCount   uint64
+	VerifiedAt     *big.Int
+}
+
+// JingChouRollupMetaData contains all meta data concerning the JingChouRollup contract.
+var JingChouRollupMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractIOpenVmHalo2Verifier\",\"name\":\"_verifier\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"_appExeCommit\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"_appVmCommit\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"genesisStateRoot\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_haltDelay\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"batchIndex\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromBlock\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toBlock\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"newStateRoot\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"withdrawalRoot\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"depositCount\",\"type\":\"uint64\"}],\"name\":\"BatchVerified\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"lastBatchIndex\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"depositCount\",\"type\":\"uint64\"}],\"name\":\"RollupHalted\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"PUBLIC_VALUES_LENGTH\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"appExeCommit\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"appVmCommit\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"batchCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"depositCount\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"batchIndex\",\"type\":\"uint256\"}],\"name\":\"getBatch\",\"outputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"fromBlock\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"toBlock\",\"type\":\"uint64\"},{\"internalType\":\"bytes32\",\"name\":\"preStateRoot\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"newStateRoot\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"withdrawalRoot\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"depositCount\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"verifiedAt\",\"type\":\"uint256\"}],\"internalType\":\"structJingChouRollup.Batch\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"halt\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"haltDelay\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"halted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBlock\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastStateRoot\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastVerifiedAt\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicValues\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"proofData\",\"type\":\"bytes\"}],\"name\":\"submitBatch\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"verifier\",\"outputs\":[{\"internalType\":\"contractIOpenVmHalo2Verifier\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"batchIndex\",\"type\":\"uint256\"}],\"name\":\"withdrawalRoot\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x610100604052348015610010575f80fd5b50604051610e7e380380610e7e83398101604081905261002f91610058565b6001600160a01b0390941660805260a09290925260c05260e091909152600155426003556100a8565b5f805f805f60a0868803121561006c575f80fd5b85516001600160a01b0381168114610082575f80fd5b602087015160408801516060890151608090990151929a91995097965090945092505050565b60805160a05160c05160e051610d876100f75f395f81816101bc015261043701525f8181610277015261087501525f818161011c015261085301525f8181610143015261081e0152610d875ff3fe608060405234801561000f575f80fd5b50600436106100f0575f3560e01c80635ac4428211610093578063b70de0d911610063578063b70de0d914610299578063b9b8af0b146102a2578063c9486c8b146102bf578063d5767c8e146102d2575f80fd5b80635ac44282146101de5780635ed7ca5b14610255578063806b984f1461025f578063af472c0614610272575f80fd5b80632b7ac3f3116100ce5780632b7ac3f31461013e5780632dfdf0b51461017d5780633ead5e04146101af5780634ddf4ad5146101b7575f80fd5b806304f8356a146100f457806306f13056146101105780630e9aa4b314610117575b5f80fd5b6100fd60035481565b6040519081526020015b60405180910390f35b5f546100fd565b6100fd7f000000000000000000000000000000000000000000000000000000000000000081565b6101657f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b039091168152602001610107565b60025461019790600160401b90046001600160401b031681565b6040516001600160401b039091168152602001610107565b6100fd607881565b6100fd7f000000000000000000000000000000000000000000000000000000000000000081565b6101f16101ec366004610b3d565b6102e5565b60405161010791905f60e0820190506001600160401b038084511683528060208501511660208401526040840151604084015260608401516060840152608084015160808401528060a08501511660a08401525060c083015160c083015292915050565b61025d6103f2565b005b600254610197906001600160401b031681565b6100fd7f000000000000000000000000000000000000000000000000000000000000000081565b6100fd60015481565b6004546102af9060ff1681565b6040519015158152602001610107565b61025d6102cd366004610b98565b610501565b6100fd6102e0366004610b3d565b610ace565b6040805160e0810182525f80825260208201819052918101829052606081018290526080810182905260a0810182905260c08101829052905482106103665760405162461bcd60e51b815260206004820152601260248201527118985d18da081b9bdd081d995c9a599a595960721b60448201526064015b60405180910390fd5b5f828154811061037857610378610bfe565b5f9182526020918290206040805160e081018252600690930290910180546001600160401b038082168552600160401b9091048116948401949094526001810154918301919091526002810154606083015260038101546080830152600481015490921660a082015260059091015460c082015292915050565b60045460ff16156104355760405162461bcd60e51b815260206004820152600d60248201526c1c9bdb1b1d5c081a185b1d1959609a1b604482015260640161035d565b7f00000000000000000000000000000000000000000000000000000000000000006003546104639190610c26565b42116104a35760405162461bcd60e51b815260206004820152600f60248201526e726f6c6c757020697320616c69766560881b604482015260640161035d565b60048054600160ff199091161790555f5460025460408051928352600160401b9091046001600160401b031660208301527fbc54a93257d59540fb4f9a5ce01f1eb6b3b722e92e078bd2b8a6591922f8ac97910160405180910390a1565b60045460ff16156105445760405162461bcd60e51b815260206004820152600d60248201526c1c9bdb1b1d5c081a185b1d1959609a1b604482015260640161035d565b607883146105945760405162461bcd60e51b815260206004820152601c60248201527f696e76616c6964207075626c69632076616c756573206c656e67746800000000604482015260640161035d565b6040805160e081019091525f90806105af600884888a610c3f565b6105b891610c66565b60c01c81526020016105ce60106008888a610c3f565b6105d791610c66565b60c01c81526020016105ed60306010888a610c3f565b6105f691610c96565b815260200161060960506030888a610c3f565b61061291610c96565b815260200161062560706050888a610c3f565b61062e91610c96565b815260200161064160786070888a610c3f565b61064a91610c66565b60c01c815242602090910152600254909150610670906001600160401b03166001610cb3565b6001600160401b0316815f01516001600160401b0316146106df5760405162461bcd60e51b8152602060048201526024808201527f626174636820646f6573206e6f7420666f6c6c6f7720746865206c61737420626044820152636c6f636b60e01b606482015260840161035d565b805f01516001600160401b031681602001516001600160401b031610156107365760405162461bcd60e51b815260206004820152600b60248201526a0cadae0e8f240c4c2e8c6d60ab1b604482015260640161035d565b60015481604001511461079d5760405162461bcd60e51b815260206004820152602960248201527f626174636820646f6573206e6f7420666f6c6c6f7720746865206c61737420736044820152681d185d19481c9bdbdd60ba1b606482015260840161035d565b60025460a08201516001600160401b03600160401b9092048216911610156108075760405162461bcd60e51b815260206004820152601760248201527f6465706f73697420636f756e7420646563726561736564000000000000000000604482015260640161035d565b604051630909c35560e21b81526001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016906324270d549061089d9088908890889088907f0000000000000000000000000000000000000000000000000000000000000000907f000000000000000000000000000000000000000000000000000000000000000090600401610d02565b5f6040518083038186803b1580156108b3575f80fd5b505afa1580156108c5573d5f803e3d5ffd5b50505f80546001808201835582805285517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e5636006909302928301805460208901516001600160401b039384166fffffffffffffffffffffffffffffffff1992831617600160401b9185168281029190911790935560408a01517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e56487015560608a01517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e565870181905560808b01517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e56688015560a08b01517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e5678801805467ffffffffffffffff19169190961690811790955560c08b01517f290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e56890970196909655948455600280549091169091179190930217909155426003559054610a4a9350909150610d3e565b7fa1a877050aee3d98e385662ff8266fb4c7783da2671011d886ed10476c6f8037825f01518360200151846060015185608001518660a00151604051610abf9594939291906001600160401b039586168152938516602085015260408401929092526060830152909116608082015260a00190565b60405180910390a25050505050565b5f80548210610b145760405162461bcd60e51b815260206004820152601260248201527118985d18da081b9bdd081d995c9a599a595960721b604482015260640161035d565b5f8281548110610b2657610b26610bfe565b905f5260205f209060060201600301549050919050565b5f60208284031215610b4d575f80fd5b5035919050565b5f8083601f840112610b64575f80fd5b5081356001600160401b03811115610b7a575f80fd5b602083019150836020828501011115610b91575f80fd5b9250929050565b5f805f8060408587031215610bab575f80fd5b84356001600160401b0380821115610bc1575f80fd5b610bcd88838901610b54565b90965094506020870135915080821115610be5575f80fd5b50610bf287828801610b54565b95989497509550505050565b634e487b7160e01b5f52603260045260245ffd5b634e487b7160e01b5f52601160045260245ffd5b80820180821115610c3957610c39610c12565b92915050565b5f8085851115610c4d575f80fd5b83861115610c59575f80fd5b5050820193919092039150565b6001600160c01b03198135818116916008851015610c8e5780818660080360031b1b83161692505b505092915050565b80356020831015610c39575f19602084900360031b1b1692915050565b6001600160401b03818116838216019080821115610cd357610cd3610c12565b5092915050565b81835281816020850137505f828201602090810191909152601f909101601f19169091010190565b608081525f610d1560808301888a610cda565b8281036020840152610d28818789610cda565b6040840195909552505060600152949350505050565b81810381811115610c3957610c39610c1256fea2646970667358221220ed8e6b14b717a23f5822922ef559e1ede02eabbdf065f65f0219919fd10b5d0464736f6c63430008150033",
+}
+
+// JingChouRollupABI is the input ABI used to generate the binding from.
+// Deprecated: Use JingChouRollupMetaData.ABI instead.
+var JingChouRollupABI = JingChouRollupMetaData.ABI
+
+// JingChouRollupBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use JingChouRollupMetaData.Bin instead.
+var JingChouRollupBin = JingChouRollupMetaData.Bin
+
+// DeployJingChouRollup deploys a new Ethereum contract, binding an instance of JingChouRollup to it.
+func DeployJingChouRollup(auth *bind.TransactOpts, backend bind.ContractBackend, _verifier common.Address, _appExeCommit [32]byte, _appVmCommit [32]byte, genesisStateRoot [32]byte, _haltDelay *big.Int) (common.Address, *types.Transaction, *JingChouRollup, error) {
+	parsed, err := JingChouRollupMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(JingChouRollupBin), backend, _verifier, _appExeCommit, _appVmCommit, genesisStateRoot, _haltDelay)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &JingChouRollup{JingChouRollupCaller: JingChouRollupCaller{contract: contract}, JingChouRollupTransactor: JingChouRollupTransactor{contract: contract}, JingChouRollupFilterer: JingChouRollupFilterer{contract: contract}}, nil
+}
+
+// JingChouRollup is an auto generated Go binding around an Ethereum contract.
+type JingChouRollup struct {
+	JingChouRollupCaller     // Read-only binding to the contract
+	JingChouRollupTransactor // Write-only binding to the contract
+	JingChouRollupFilterer   // Log filterer for contract events
+}
+
+// JingChouRollupCaller is an auto generated read-only Go binding around an Ethereum contract.
+type JingChouRollupCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouRollupTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type JingChouRollupTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouRollupFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type JingChouRollupFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouRollupSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type JingChouRollupSession struct {
+	Contract     *JingChouRollup   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// JingChouRollupCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type JingChouRollupCallerSession struct {
+	Contract *JingChouRollupCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// JingChouRollupTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type JingChouRollupTransactorSession struct {
+	Contract     *JingChouRollupTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// JingChouRollupRaw is an auto generated low-level Go binding around an Ethereum contract.
+type JingChouRollupRaw struct {
+	Contract *JingChouRollup // Generic contract binding to access the raw methods on
+}
+
+// JingChouRollupCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type JingChouRollupCallerRaw struct {
+	Contract *JingChouRollupCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// JingChouRollupTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type JingChouRollupTransactorRaw struct {
+	Contract *JingChouRollupTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewJingChouRollup creates a new instance of JingChouRollup, bound to a specific deployed contract.
+func NewJingChouRollup(address common.Address, backend bind.ContractBackend) (*JingChouRollup, error) {
+	contract, err := bindJingChouRollup(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollup{JingChouRollupCaller: JingChouRollupCaller{contract: contract}, JingChouRollupTransactor: JingChouRollupTransactor{contract: contract}, JingChouRollupFilterer: JingChouRollupFilterer{contract: contract}}, nil
+}
+
+// NewJingChouRollupCaller creates a new read-only instance of JingChouRollup, bound to a specific deployed contract.
+func NewJingChouRollupCaller(address common.Address, caller bind.ContractCaller) (*JingChouRollupCaller, error) {
+	contract, err := bindJingChouRollup(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollupCaller{contract: contract}, nil
+}
+
+// NewJingChouRollupTransactor creates a new write-only instance of JingChouRollup, bound to a specific deployed contract.
+func NewJingChouRollupTransactor(address common.Address, transactor bind.ContractTransactor) (*JingChouRollupTransactor, error) {
+	contract, err := bindJingChouRollup(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollupTransactor{contract: contract}, nil
+}
+
+// NewJingChouRollupFilterer creates a new log filterer instance of JingChouRollup, bound to a specific deployed contract.
+func NewJingChouRollupFilterer(address common.Address, filterer bind.ContractFilterer) (*JingChouRollupFilterer, error) {
+	contract, err := bindJingChouRollup(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollupFilterer{contract: contract}, nil
+}
+
+// bindJingChouRollup binds a generic wrapper to an already deployed contract.
+func bindJingChouRollup(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := JingChouRollupMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouRollup *JingChouRollupRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouRollup.Contract.JingChouRollupCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouRollup *JingChouRollupRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.JingChouRollupTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouRollup *JingChouRollupRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.JingChouRollupTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouRollup *JingChouRollupCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouRollup.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouRollup *JingChouRollupTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouRollup *JingChouRollupTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.contract.Transact(opts, method, params...)
+}
+
+// PUBLICVALUESLENGTH is a free data retrieval call binding the contract method 0x3ead5e04.
+//
+// Solidity: function PUBLIC_VALUES_LENGTH() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCaller) PUBLICVALUESLENGTH(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "PUBLIC_VALUES_LENGTH")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PUBLICVALUESLENGTH is a free data retrieval call binding the contract method 0x3ead5e04.
+//
+// Solidity: function PUBLIC_VALUES_LENGTH() view returns(uint256)
+func (_JingChouRollup *JingChouRollupSession) PUBLICVALUESLENGTH() (*big.Int, error) {
+	return _JingChouRollup.Contract.PUBLICVALUESLENGTH(&_JingChouRollup.CallOpts)
+}
+
+// PUBLICVALUESLENGTH is a free data retrieval call binding the contract method 0x3ead5e04.
+//
+// Solidity: function PUBLIC_VALUES_LENGTH() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCallerSession) PUBLICVALUESLENGTH() (*big.Int, error) {
+	return _JingChouRollup.Contract.PUBLICVALUESLENGTH(&_JingChouRollup.CallOpts)
+}
+
+// AppExeCommit is a free data retrieval call binding the contract method 0x0e9aa4b3.
+//
+// Solidity: function appExeCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCaller) AppExeCommit(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "appExeCommit")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// AppExeCommit is a free data retrieval call binding the contract method 0x0e9aa4b3.
+//
+// Solidity: function appExeCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupSession) AppExeCommit() ([32]byte, error) {
+	return _JingChouRollup.Contract.AppExeCommit(&_JingChouRollup.CallOpts)
+}
+
+// AppExeCommit is a free data retrieval call binding the contract method 0x0e9aa4b3.
+//
+// Solidity: function appExeCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCallerSession) AppExeCommit() ([32]byte, error) {
+	return _JingChouRollup.Contract.AppExeCommit(&_JingChouRollup.CallOpts)
+}
+
+// AppVmCommit is a free data retrieval call binding the contract method 0xaf472c06.
+//
+// Solidity: function appVmCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCaller) AppVmCommit(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "appVmCommit")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// AppVmCommit is a free data retrieval call binding the contract method 0xaf472c06.
+//
+// Solidity: function appVmCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupSession) AppVmCommit() ([32]byte, error) {
+	return _JingChouRollup.Contract.AppVmCommit(&_JingChouRollup.CallOpts)
+}
+
+// AppVmCommit is a free data retrieval call binding the contract method 0xaf472c06.
+//
+// Solidity: function appVmCommit() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCallerSession) AppVmCommit() ([32]byte, error) {
+	return _JingChouRollup.Contract.AppVmCommit(&_JingChouRollup.CallOpts)
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCaller) BatchCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "batchCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_JingChouRollup *JingChouRollupSession) BatchCount() (*big.Int, error) {
+	return _JingChouRollup.Contract.BatchCount(&_JingChouRollup.CallOpts)
+}
+
+// BatchCount is a free data retrieval call binding the contract method 0x06f13056.
+//
+// Solidity: function batchCount() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCallerSession) BatchCount() (*big.Int, error) {
+	return _JingChouRollup.Contract.BatchCount(&_JingChouRollup.CallOpts)
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint64)
+func (_JingChouRollup *JingChouRollupCaller) DepositCount(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "depositCount")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint64)
+func (_JingChouRollup *JingChouRollupSession) DepositCount() (uint64, error) {
+	return _JingChouRollup.Contract.DepositCount(&_JingChouRollup.CallOpts)
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint64)
+func (_JingChouRollup *JingChouRollupCallerSession) DepositCount() (uint64, error) {
+	return _JingChouRollup.Contract.DepositCount(&_JingChouRollup.CallOpts)
+}
+
+// GetBatch is a free data retrieval call binding the contract method 0x5ac44282.
+//
+// Solidity: function getBatch(uint256 batchIndex) view returns((uint64,uint64,bytes32,bytes32,bytes32,uint64,uint256))
+func (_JingChouRollup *JingChouRollupCaller) GetBatch(opts *bind.CallOpts, batchIndex *big.Int) (JingChouRollupBatch, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "getBatch", batchIndex)
+
+	if err != nil {
+		return *new(JingChouRollupBatch), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(JingChouRollupBatch)).(*JingChouRollupBatch)
+
+	return out0, err
+
+}
+
+// GetBatch is a free data retrieval call binding the contract method 0x5ac44282.
+//
+// Solidity: function getBatch(uint256 batchIndex) view returns((uint64,uint64,bytes32,bytes32,bytes32,uint64,uint256))
+func (_JingChouRollup *JingChouRollupSession) GetBatch(batchIndex *big.Int) (JingChouRollupBatch, error) {
+	return _JingChouRollup.Contract.GetBatch(&_JingChouRollup.CallOpts, batchIndex)
+}
+
+// GetBatch is a free data retrieval call binding the contract method 0x5ac44282.
+//
+// Solidity: function getBatch(uint256 batchIndex) view returns((uint64,uint64,bytes32,bytes32,bytes32,uint64,uint256))
+func (_JingChouRollup *JingChouRollupCallerSession) GetBatch(batchIndex *big.Int) (JingChouRollupBatch, error) {
+	return _JingChouRollup.Contract.GetBatch(&_JingChouRollup.CallOpts, batchIndex)
+}
+
+// HaltDelay is a free data retrieval call binding the contract method 0x4ddf4ad5.
+//
+// Solidity: function haltDelay() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCaller) HaltDelay(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "haltDelay")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// HaltDelay is a free data retrieval call binding the contract method 0x4ddf4ad5.
+//
+// Solidity: function haltDelay() view returns(uint256)
+func (_JingChouRollup *JingChouRollupSession) HaltDelay() (*big.Int, error) {
+	return _JingChouRollup.Contract.HaltDelay(&_JingChouRollup.CallOpts)
+}
+
+// HaltDelay is a free data retrieval call binding the contract method 0x4ddf4ad5.
+//
+// Solidity: function haltDelay() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCallerSession) HaltDelay() (*big.Int, error) {
+	return _JingChouRollup.Contract.HaltDelay(&_JingChouRollup.CallOpts)
+}
+
+// Halted is a free data retrieval call binding the contract method 0xb9b8af0b.
+//
+// Solidity: function halted() view returns(bool)
+func (_JingChouRollup *JingChouRollupCaller) Halted(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "halted")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Halted is a free data retrieval call binding the contract method 0xb9b8af0b.
+//
+// Solidity: function halted() view returns(bool)
+func (_JingChouRollup *JingChouRollupSession) Halted() (bool, error) {
+	return _JingChouRollup.Contract.Halted(&_JingChouRollup.CallOpts)
+}
+
+// Halted is a free data retrieval call binding the contract method 0xb9b8af0b.
+//
+// Solidity: function halted() view returns(bool)
+func (_JingChouRollup *JingChouRollupCallerSession) Halted() (bool, error) {
+	return _JingChouRollup.Contract.Halted(&_JingChouRollup.CallOpts)
+}
+
+// LastBlock is a free data retrieval call binding the contract method 0x806b984f.
+//
+// Solidity: function lastBlock() view returns(uint64)
+func (_JingChouRollup *JingChouRollupCaller) LastBlock(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "lastBlock")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// LastBlock is a free data retrieval call binding the contract method 0x806b984f.
+//
+// Solidity: function lastBlock() view returns(uint64)
+func (_JingChouRollup *JingChouRollupSession) LastBlock() (uint64, error) {
+	return _JingChouRollup.Contract.LastBlock(&_JingChouRollup.CallOpts)
+}
+
+// LastBlock is a free data retrieval call binding the contract method 0x806b984f.
+//
+// Solidity: function lastBlock() view returns(uint64)
+func (_JingChouRollup *JingChouRollupCallerSession) LastBlock() (uint64, error) {
+	return _JingChouRollup.Contract.LastBlock(&_JingChouRollup.CallOpts)
+}
+
+// LastStateRoot is a free data retrieval call binding the contract method 0xb70de0d9.
+//
+// Solidity: function lastStateRoot() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCaller) LastStateRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "lastStateRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// LastStateRoot is a free data retrieval call binding the contract method 0xb70de0d9.
+//
+// Solidity: function lastStateRoot() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupSession) LastStateRoot() ([32]byte, error) {
+	return _JingChouRollup.Contract.LastStateRoot(&_JingChouRollup.CallOpts)
+}
+
+// LastStateRoot is a free data retrieval call binding the contract method 0xb70de0d9.
+//
+// Solidity: function lastStateRoot() view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCallerSession) LastStateRoot() ([32]byte, error) {
+	return _JingChouRollup.Contract.LastStateRoot(&_JingChouRollup.CallOpts)
+}
+
+// LastVerifiedAt is a free data retrieval call binding the contract method 0x04f8356a.
+//
+// Solidity: function lastVerifiedAt() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCaller) LastVerifiedAt(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "lastVerifiedAt")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LastVerifiedAt is a free data retrieval call binding the contract method 0x04f8356a.
+//
+// Solidity: function lastVerifiedAt() view returns(uint256)
+func (_JingChouRollup *JingChouRollupSession) LastVerifiedAt() (*big.Int, error) {
+	return _JingChouRollup.Contract.LastVerifiedAt(&_JingChouRollup.CallOpts)
+}
+
+// LastVerifiedAt is a free data retrieval call binding the contract method 0x04f8356a.
+//
+// Solidity: function lastVerifiedAt() view returns(uint256)
+func (_JingChouRollup *JingChouRollupCallerSession) LastVerifiedAt() (*big.Int, error) {
+	return _JingChouRollup.Contract.LastVerifiedAt(&_JingChouRollup.CallOpts)
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x2b7ac3f3.
+//
+// Solidity: function verifier() view returns(address)
+func (_JingChouRollup *JingChouRollupCaller) Verifier(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "verifier")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x2b7ac3f3.
+//
+// Solidity: function verifier() view returns(address)
+func (_JingChouRollup *JingChouRollupSession) Verifier() (common.Address, error) {
+	return _JingChouRollup.Contract.Verifier(&_JingChouRollup.CallOpts)
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x2b7ac3f3.
+//
+// Solidity: function verifier() view returns(address)
+func (_JingChouRollup *JingChouRollupCallerSession) Verifier() (common.Address, error) {
+	return _JingChouRollup.Contract.Verifier(&_JingChouRollup.CallOpts)
+}
+
+// WithdrawalRoot is a free data retrieval call binding the contract method 0xd5767c8e.
+//
+// Solidity: function withdrawalRoot(uint256 batchIndex) view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCaller) WithdrawalRoot(opts *bind.CallOpts, batchIndex *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _JingChouRollup.contract.Call(opts, &out, "withdrawalRoot", batchIndex)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// WithdrawalRoot is a free data retrieval call binding the contract method 0xd5767c8e.
+//
+// Solidity: function withdrawalRoot(uint256 batchIndex) view returns(bytes32)
+func (_JingChouRollup *JingChouRollupSession) WithdrawalRoot(batchIndex *big.Int) ([32]byte, error) {
+	return _JingChouRollup.Contract.WithdrawalRoot(&_JingChouRollup.CallOpts, batchIndex)
+}
+
+// WithdrawalRoot is a free data retrieval call binding the contract method 0xd5767c8e.
+//
+// Solidity: function withdrawalRoot(uint256 batchIndex) view returns(bytes32)
+func (_JingChouRollup *JingChouRollupCallerSession) WithdrawalRoot(batchIndex *big.Int) ([32]byte, error) {
+	return _JingChouRollup.Contract.WithdrawalRoot(&_JingChouRollup.CallOpts, batchIndex)
+}
+
+// Halt is a paid mutator transaction binding the contract method 0x5ed7ca5b.
+//
+// Solidity: function halt() returns()
+func (_JingChouRollup *JingChouRollupTransactor) Halt(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouRollup.contract.Transact(opts, "halt")
+}
+
+// Halt is a paid mutator transaction binding the contract method 0x5ed7ca5b.
+//
+// Solidity: function halt() returns()
+func (_JingChouRollup *JingChouRollupSession) Halt() (*types.Transaction, error) {
+	return _JingChouRollup.Contract.Halt(&_JingChouRollup.TransactOpts)
+}
+
+// Halt is a paid mutator transaction binding the contract method 0x5ed7ca5b.
+//
+// Solidity: function halt() returns()
+func (_JingChouRollup *JingChouRollupTransactorSession) Halt() (*types.Transaction, error) {
+	return _JingChouRollup.Contract.Halt(&_JingChouRollup.TransactOpts)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xc9486c8b.
+//
+// Solidity: function submitBatch(bytes publicValues, bytes proofData) returns()
+func (_JingChouRollup *JingChouRollupTransactor) SubmitBatch(opts *bind.TransactOpts, publicValues []byte, proofData []byte) (*types.Transaction, error) {
+	return _JingChouRollup.contract.Transact(opts, "submitBatch", publicValues, proofData)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xc9486c8b.
+//
+// Solidity: function submitBatch(bytes publicValues, bytes proofData) returns()
+func (_JingChouRollup *JingChouRollupSession) SubmitBatch(publicValues []byte, proofData []byte) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.SubmitBatch(&_JingChouRollup.TransactOpts, publicValues, proofData)
+}
+
+// SubmitBatch is a paid mutator transaction binding the contract method 0xc9486c8b.
+//
+// Solidity: function submitBatch(bytes publicValues, bytes proofData) returns()
+func (_JingChouRollup *JingChouRollupTransactorSession) SubmitBatch(publicValues []byte, proofData []byte) (*types.Transaction, error) {
+	return _JingChouRollup.Contract.SubmitBatch(&_JingChouRollup.TransactOpts, publicValues, proofData)
+}
+
+// JingChouRollupBatchVerifiedIterator is returned from FilterBatchVerified and is used to iterate over the raw logs and unpacked data for BatchVerified events raised by the JingChouRollup contract.
+type JingChouRollupBatchVerifiedIterator struct {
+	Event *JingChouRollupBatchVerified // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouRollupBatchVerifiedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouRollupBatchVerified)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouRollupBatchVerified)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouRollupBatchVerifiedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouRollupBatchVerifiedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouRollupBatchVerified represents a BatchVerified event raised by the JingChouRollup contract.
+type JingChouRollupBatchVerified struct {
+	BatchIndex     *big.Int
+	FromBlock      uint64
+	ToBlock        uint64
+	NewStateRoot   [32]byte
+	WithdrawalRoot [32]byte
+	DepositCount   uint64
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterBatchVerified is a free log retrieval operation binding the contract event 0xa1a877050aee3d98e385662ff8266fb4c7783da2671011d886ed10476c6f8037.
+//
+// Solidity: event BatchVerified(uint256 indexed batchIndex, uint64 fromBlock, uint64 toBlock, bytes32 newStateRoot, bytes32 withdrawalRoot, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) FilterBatchVerified(opts *bind.FilterOpts, batchIndex []*big.Int) (*JingChouRollupBatchVerifiedIterator, error) {
+
+	var batchIndexRule []interface{}
+	for _, batchIndexItem := range batchIndex {
+		batchIndexRule = append(batchIndexRule, batchIndexItem)
+	}
+
+	logs, sub, err := _JingChouRollup.contract.FilterLogs(opts, "BatchVerified", batchIndexRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollupBatchVerifiedIterator{contract: _JingChouRollup.contract, event: "BatchVerified", logs: logs, sub: sub}, nil
+}
+
+// WatchBatchVerified is a free log subscription operation binding the contract event 0xa1a877050aee3d98e385662ff8266fb4c7783da2671011d886ed10476c6f8037.
+//
+// Solidity: event BatchVerified(uint256 indexed batchIndex, uint64 fromBlock, uint64 toBlock, bytes32 newStateRoot, bytes32 withdrawalRoot, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) WatchBatchVerified(opts *bind.WatchOpts, sink chan<- *JingChouRollupBatchVerified, batchIndex []*big.Int) (event.Subscription, error) {
+
+	var batchIndexRule []interface{}
+	for _, batchIndexItem := range batchIndex {
+		batchIndexRule = append(batchIndexRule, batchIndexItem)
+	}
+
+	logs, sub, err := _JingChouRollup.contract.WatchLogs(opts, "BatchVerified", batchIndexRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouRollupBatchVerified)
+				if err := _JingChouRollup.contract.UnpackLog(event, "BatchVerified", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBatchVerified is a log parse operation binding the contract event 0xa1a877050aee3d98e385662ff8266fb4c7783da2671011d886ed10476c6f8037.
+//
+// Solidity: event BatchVerified(uint256 indexed batchIndex, uint64 fromBlock, uint64 toBlock, bytes32 newStateRoot, bytes32 withdrawalRoot, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) ParseBatchVerified(log types.Log) (*JingChouRollupBatchVerified, error) {
+	event := new(JingChouRollupBatchVerified)
+	if err := _JingChouRollup.contract.UnpackLog(event, "BatchVerified", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouRollupRollupHaltedIterator is returned from FilterRollupHalted and is used to iterate over the raw logs and unpacked data for RollupHalted events raised by the JingChouRollup contract.
+type JingChouRollupRollupHaltedIterator struct {
+	Event *JingChouRollupRollupHalted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouRollupRollupHaltedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouRollupRollupHalted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouRollupRollupHalted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouRollupRollupHaltedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouRollupRollupHaltedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouRollupRollupHalted represents a RollupHalted event raised by the JingChouRollup contract.
+type JingChouRollupRollupHalted struct {
+	LastBatchIndex *big.Int
+	DepositCount   uint64
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterRollupHalted is a free log retrieval operation binding the contract event 0xbc54a93257d59540fb4f9a5ce01f1eb6b3b722e92e078bd2b8a6591922f8ac97.
+//
+// Solidity: event RollupHalted(uint256 lastBatchIndex, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) FilterRollupHalted(opts *bind.FilterOpts) (*JingChouRollupRollupHaltedIterator, error) {
+
+	logs, sub, err := _JingChouRollup.contract.FilterLogs(opts, "RollupHalted")
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouRollupRollupHaltedIterator{contract: _JingChouRollup.contract, event: "RollupHalted", logs: logs, sub: sub}, nil
+}
+
+// WatchRollupHalted is a free log subscription operation binding the contract event 0xbc54a93257d59540fb4f9a5ce01f1eb6b3b722e92e078bd2b8a6591922f8ac97.
+//
+// Solidity: event RollupHalted(uint256 lastBatchIndex, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) WatchRollupHalted(opts *bind.WatchOpts, sink chan<- *JingChouRollupRollupHalted) (event.Subscription, error) {
+
+	logs, sub, err := _JingChouRollup.contract.WatchLogs(opts, "RollupHalted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouRollupRollupHalted)
+				if err := _JingChouRollup.contract.UnpackLog(event, "RollupHalted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRollupHalted is a log parse operation binding the contract event 0xbc54a93257d59540fb4f9a5ce01f1eb6b3b722e92e078bd2b8a6591922f8ac97.
+//
+// Solidity: event RollupHalted(uint256 lastBatchIndex, uint64 depositCount)
+func (_JingChouRollup *JingChouRollupFilterer) ParseRollupHalted(log types.Log) (*JingChouRollupRollupHalted, error) {
+	event := new(JingChouRollupRollupHalted)
+	if err := _JingChouRollup.contract.UnpackLog(event, "RollupHalted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
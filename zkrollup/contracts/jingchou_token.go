@@ -0,0 +1,833 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// JingChouTokenMetaData contains all meta data concerning the JingChouToken contract.
+var JingChouTokenMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"bridge\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"mint\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x60a060405234801561000f575f80fd5b503360805260805161083c61003a5f395f818161020201528181610370015261045c015261083c5ff3fe608060405234801561000f575f80fd5b50600436106100b1575f3560e01c806370a082311161006e57806370a082311461016d57806395d89b411461018c5780639dc29fac146101ad578063a9059cbb146101c0578063dd62ed3e146101d3578063e78cea92146101fd575f80fd5b806306fdde03146100b5578063095ea7b3146100f257806318160ddd1461011557806323b872dd1461012b578063313ce5671461013e57806340c10f1914610158575b5f80fd5b6100dc604051806040016040528060088152602001674a696e6743686f7560c01b81525081565b6040516100e991906106b4565b60405180910390f35b61010561010036600461071a565b61023c565b60405190151581526020016100e9565b61011d5f5481565b6040519081526020016100e9565b610105610139366004610742565b6102a8565b610146601281565b60405160ff90911681526020016100e9565b61016b61016636600461071a565b610365565b005b61011d61017b36600461077b565b60016020525f908152604090205481565b6100dc604051806040016040528060028152602001614a4360f01b81525081565b61016b6101bb36600461071a565b610451565b6101056101ce36600461071a565b610595565b61011d6101e136600461079b565b600260209081525f928352604080842090915290825290205481565b6102247f000000000000000000000000000000000000000000000000000000000000000081565b6040516001600160a01b0390911681526020016100e9565b335f8181526002602090815260408083206001600160a01b038716808552925280832085905551919290917f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925906102969086815260200190565b60405180910390a35060015b92915050565b6001600160a01b0383165f9081526002602090815260408083203384529091528120545f19811461034f57828110156103215760405162461bcd60e51b8152602060048201526016602482015275696e73756666696369656e7420616c6c6f77616e636560501b60448201526064015b60405180910390fd5b61032b83826107e0565b6001600160a01b0386165f9081526002602090815260408083203384529091529020555b61035a8585856105aa565b506001949350505050565b336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146103cb5760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b6044820152606401610318565b805f808282546103db91906107f3565b90915550506001600160a01b0382165f90815260016020526040812080548392906104079084906107f3565b90915550506040518181526001600160a01b038316905f907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef906020015b60405180910390a35050565b336001600160a01b037f000000000000000000000000000000000000000000000000000000000000000016146104b75760405162461bcd60e51b815260206004820152600b60248201526a6f6e6c792062726964676560a81b6044820152606401610318565b6001600160a01b0382165f908152600160205260409020548111156105155760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b6044820152606401610318565b6001600160a01b0382165f908152600160205260408120805483929061053c9084906107e0565b92505081905550805f8082825461055391906107e0565b90915550506040518181525f906001600160a01b038416907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef90602001610445565b5f6105a13384846105aa565b50600192915050565b6001600160a01b0383165f908152600160205260409020548111156106085760405162461bcd60e51b8152602060048201526014602482015273696e73756666696369656e742062616c616e636560601b6044820152606401610318565b6001600160a01b0383165f908152600160205260408120805483929061062f9084906107e0565b90915550506001600160a01b0382165f908152600160205260408120805483929061065b9084906107f3565b92505081905550816001600160a01b0316836001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040516106a791815260200190565b60405180910390a3505050565b5f6020808352835180828501525f5b818110156106df578581018301518582016040015282016106c3565b505f604082860101526040601f19601f8301168501019250505092915050565b80356001600160a01b0381168114610715575f80fd5b919050565b5f806040838503121561072b575f80fd5b610734836106ff565b946020939093013593505050565b5f805f60608486031215610754575f80fd5b61075d846106ff565b925061076b602085016106ff565b9150604084013590509250925092565b5f6020828403121561078b575f80fd5b610794826106ff565b9392505050565b5f80604083850312156107ac575f80fd5b6107b5836106ff565b91506107c3602084016106ff565b90509250929050565b634e487b7160e01b5f52601160045260245ffd5b818103818111156102a2576102a26107cc565b808201808211156102a2576102a26107cc56fea2646970667358221220c64502a4a0e1f4aa3c4e1dc9adfa2aabba1d72385b3cd5f887ddf421ade4e69864736f6c63430008150033",
+}
+
+// JingChouTokenABI is the input ABI used to generate the binding from.
+// Deprecated: Use JingChouTokenMetaData.ABI instead.
+var JingChouTokenABI = JingChouTokenMetaData.ABI
+
+// JingChouTokenBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use JingChouTokenMetaData.Bin instead.
+var JingChouTokenBin = JingChouTokenMetaData.Bin
+
+// DeployJingChouToken deploys a new Ethereum contract, binding an instance of JingChouToken to it.
+func DeployJingChouToken(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *JingChouToken, error) {
+	parsed, err := JingChouTokenMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(JingChouTokenBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &JingChouToken{JingChouTokenCaller: JingChouTokenCaller{contract: contract}, JingChouTokenTransactor: JingChouTokenTransactor{contract: contract}, JingChouTokenFilterer: JingChouTokenFilterer{contract: contract}}, nil
+}
+
+// JingChouToken is an auto generated Go binding around an Ethereum contract.
+type JingChouToken struct {
+	JingChouTokenCaller     // Read-only binding to the contract
+	JingChouTokenTransactor // Write-only binding to the contract
+	JingChouTokenFilterer   // Log filterer for contract events
+}
+
+// JingChouTokenCaller is an auto generated read-only Go binding around an Ethereum contract.
+type JingChouTokenCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouTokenTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type JingChouTokenTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouTokenFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type JingChouTokenFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// JingChouTokenSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type JingChouTokenSession struct {
+	Contract     *JingChouToken    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// JingChouTokenCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type JingChouTokenCallerSession struct {
+	Contract *JingChouTokenCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// JingChouTokenTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type JingChouTokenTransactorSession struct {
+	Contract     *JingChouTokenTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// JingChouTokenRaw is an auto generated low-level Go binding around an Ethereum contract.
+type JingChouTokenRaw struct {
+	Contract *JingChouToken // Generic contract binding to access the raw methods on
+}
+
+// JingChouTokenCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type JingChouTokenCallerRaw struct {
+	Contract *JingChouTokenCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// JingChouTokenTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type JingChouTokenTransactorRaw struct {
+	Contract *JingChouTokenTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewJingChouToken creates a new instance of JingChouToken, bound to a specific deployed contract.
+func NewJingChouToken(address common.Address, backend bind.ContractBackend) (*JingChouToken, error) {
+	contract, err := bindJingChouToken(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouToken{JingChouTokenCaller: JingChouTokenCaller{contract: contract}, JingChouTokenTransactor: JingChouTokenTransactor{contract: contract}, JingChouTokenFilterer: JingChouTokenFilterer{contract: contract}}, nil
+}
+
+// NewJingChouTokenCaller creates a new read-only instance of JingChouToken, bound to a specific deployed contract.
+func NewJingChouTokenCaller(address common.Address, caller bind.ContractCaller) (*JingChouTokenCaller, error) {
+	contract, err := bindJingChouToken(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouTokenCaller{contract: contract}, nil
+}
+
+// NewJingChouTokenTransactor creates a new write-only instance of JingChouToken, bound to a specific deployed contract.
+func NewJingChouTokenTransactor(address common.Address, transactor bind.ContractTransactor) (*JingChouTokenTransactor, error) {
+	contract, err := bindJingChouToken(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouTokenTransactor{contract: contract}, nil
+}
+
+// NewJingChouTokenFilterer creates a new log filterer instance of JingChouToken, bound to a specific deployed contract.
+func NewJingChouTokenFilterer(address common.Address, filterer bind.ContractFilterer) (*JingChouTokenFilterer, error) {
+	contract, err := bindJingChouToken(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouTokenFilterer{contract: contract}, nil
+}
+
+// bindJingChouToken binds a generic wrapper to an already deployed contract.
+func bindJingChouToken(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := JingChouTokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouToken *JingChouTokenRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouToken.Contract.JingChouTokenCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouToken *JingChouTokenRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouToken.Contract.JingChouTokenTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouToken *JingChouTokenRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouToken.Contract.JingChouTokenTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_JingChouToken *JingChouTokenCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _JingChouToken.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_JingChouToken *JingChouTokenTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _JingChouToken.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_JingChouToken *JingChouTokenTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _JingChouToken.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenCaller) Allowance(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "allowance", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _JingChouToken.Contract.Allowance(&_JingChouToken.CallOpts, arg0, arg1)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address , address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenCallerSession) Allowance(arg0 common.Address, arg1 common.Address) (*big.Int, error) {
+	return _JingChouToken.Contract.Allowance(&_JingChouToken.CallOpts, arg0, arg1)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenCaller) BalanceOf(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "balanceOf", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _JingChouToken.Contract.BalanceOf(&_JingChouToken.CallOpts, arg0)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address ) view returns(uint256)
+func (_JingChouToken *JingChouTokenCallerSession) BalanceOf(arg0 common.Address) (*big.Int, error) {
+	return _JingChouToken.Contract.BalanceOf(&_JingChouToken.CallOpts, arg0)
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_JingChouToken *JingChouTokenCaller) Bridge(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "bridge")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_JingChouToken *JingChouTokenSession) Bridge() (common.Address, error) {
+	return _JingChouToken.Contract.Bridge(&_JingChouToken.CallOpts)
+}
+
+// Bridge is a free data retrieval call binding the contract method 0xe78cea92.
+//
+// Solidity: function bridge() view returns(address)
+func (_JingChouToken *JingChouTokenCallerSession) Bridge() (common.Address, error) {
+	return _JingChouToken.Contract.Bridge(&_JingChouToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_JingChouToken *JingChouTokenCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_JingChouToken *JingChouTokenSession) Decimals() (uint8, error) {
+	return _JingChouToken.Contract.Decimals(&_JingChouToken.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_JingChouToken *JingChouTokenCallerSession) Decimals() (uint8, error) {
+	return _JingChouToken.Contract.Decimals(&_JingChouToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_JingChouToken *JingChouTokenCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_JingChouToken *JingChouTokenSession) Name() (string, error) {
+	return _JingChouToken.Contract.Name(&_JingChouToken.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_JingChouToken *JingChouTokenCallerSession) Name() (string, error) {
+	return _JingChouToken.Contract.Name(&_JingChouToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_JingChouToken *JingChouTokenCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_JingChouToken *JingChouTokenSession) Symbol() (string, error) {
+	return _JingChouToken.Contract.Symbol(&_JingChouToken.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_JingChouToken *JingChouTokenCallerSession) Symbol() (string, error) {
+	return _JingChouToken.Contract.Symbol(&_JingChouToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_JingChouToken *JingChouTokenCaller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _JingChouToken.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_JingChouToken *JingChouTokenSession) TotalSupply() (*big.Int, error) {
+	return _JingChouToken.Contract.TotalSupply(&_JingChouToken.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_JingChouToken *JingChouTokenCallerSession) TotalSupply() (*big.Int, error) {
+	return _JingChouToken.Contract.TotalSupply(&_JingChouToken.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Approve(&_JingChouToken.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Approve(&_JingChouToken.TransactOpts, spender, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenTransactor) Burn(opts *bind.TransactOpts, from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.contract.Transact(opts, "burn", from, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenSession) Burn(from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Burn(&_JingChouToken.TransactOpts, from, amount)
+}
+
+// Burn is a paid mutator transaction binding the contract method 0x9dc29fac.
+//
+// Solidity: function burn(address from, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenTransactorSession) Burn(from common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Burn(&_JingChouToken.TransactOpts, from, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenTransactor) Mint(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.contract.Transact(opts, "mint", to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Mint(&_JingChouToken.TransactOpts, to, amount)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x40c10f19.
+//
+// Solidity: function mint(address to, uint256 amount) returns()
+func (_JingChouToken *JingChouTokenTransactorSession) Mint(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Mint(&_JingChouToken.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Transfer(&_JingChouToken.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.Transfer(&_JingChouToken.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.TransferFrom(&_JingChouToken.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_JingChouToken *JingChouTokenTransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _JingChouToken.Contract.TransferFrom(&_JingChouToken.TransactOpts, from, to, amount)
+}
+
+// JingChouTokenApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the JingChouToken contract.
+type JingChouTokenApprovalIterator struct {
+	Event *JingChouTokenApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouTokenApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouTokenApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouTokenApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouTokenApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouTokenApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouTokenApproval represents a Approval event raised by the JingChouToken contract.
+type JingChouTokenApproval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*JingChouTokenApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _JingChouToken.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouTokenApprovalIterator{contract: _JingChouToken.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *JingChouTokenApproval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _JingChouToken.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouTokenApproval)
+				if err := _JingChouToken.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) ParseApproval(log types.Log) (*JingChouTokenApproval, error) {
+	event := new(JingChouTokenApproval)
+	if err := _JingChouToken.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// JingChouTokenTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the JingChouToken contract.
+type JingChouTokenTransferIterator struct {
+	Event *JingChouTokenTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *JingChouTokenTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(JingChouTokenTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(JingChouTokenTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *JingChouTokenTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *JingChouTokenTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// JingChouTokenTransfer represents a Transfer event raised by the JingChouToken contract.
+type JingChouTokenTransfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*JingChouTokenTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _JingChouToken.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &JingChouTokenTransferIterator{contract: _JingChouToken.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *JingChouTokenTransfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _JingChouToken.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(JingChouTokenTransfer)
+				if err := _JingChouToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_JingChouToken *JingChouTokenFilterer) ParseTransfer(log types.Log) (*JingChouTokenTransfer, error) {
+	event := new(JingChouTokenTransfer)
+	if err := _JingChouToken.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,284 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// MockVerifierMetaData contains all meta data concerning the MockVerifier contract.
+var MockVerifierMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"rejecting\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"_rejecting\",\"type\":\"bool\"}],\"name\":\"setRejecting\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"},{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"verify\",\"outputs\":[],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561000f575f80fd5b506101ff8061001d5f395ff3fe608060405234801561000f575f80fd5b506004361061003f575f3560e01c806324270d54146100435780635c3a0c091461005857806381b30d5514610078575b5f80fd5b61005661005136600461012b565b610098565b005b6100566100663660046101a3565b5f805460ff1916911515919091179055565b5f546100849060ff1681565b604051901515815260200160405180910390f35b5f5460ff16156100de5760405162461bcd60e51b815260206004820152600d60248201526c34b73b30b634b210383937b7b360991b604482015260640160405180910390fd5b505050505050565b5f8083601f8401126100f6575f80fd5b50813567ffffffffffffffff81111561010d575f80fd5b602083019150836020828501011115610124575f80fd5b9250929050565b5f805f805f8060808789031215610140575f80fd5b863567ffffffffffffffff80821115610157575f80fd5b6101638a838b016100e6565b9098509650602089013591508082111561017b575f80fd5b5061018889828a016100e6565b979a9699509760408101359660609091013595509350505050565b5f602082840312156101b3575f80fd5b813580151581146101c2575f80fd5b939250505056fea26469706673582212200e58659f95af1d03251e208ef06ba0efdc71f01bd92448f81d46b95579c2cd0a64736f6c63430008150033",
+}
+
+// MockVerifierABI is the input ABI used to generate the binding from.
+// Deprecated: Use MockVerifierMetaData.ABI instead.
+var MockVerifierABI = MockVerifierMetaData.ABI
+
+// MockVerifierBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use MockVerifierMetaData.Bin instead.
+var MockVerifierBin = MockVerifierMetaData.Bin
+
+// DeployMockVerifier deploys a new Ethereum contract, binding an instance of MockVerifier to it.
+func DeployMockVerifier(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *MockVerifier, error) {
+	parsed, err := MockVerifierMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(MockVerifierBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &MockVerifier{MockVerifierCaller: MockVerifierCaller{contract: contract}, MockVerifierTransactor: MockVerifierTransactor{contract: contract}, MockVerifierFilterer: MockVerifierFilterer{contract: contract}}, nil
+}
+
+// MockVerifier is an auto generated Go binding around an Ethereum contract.
+type MockVerifier struct {
+	MockVerifierCaller     // Read-only binding to the contract
+	MockVerifierTransactor // Write-only binding to the contract
+	MockVerifierFilterer   // Log filterer for contract events
+}
+
+// MockVerifierCaller is an auto generated read-only Go binding around an Ethereum contract.
+type MockVerifierCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockVerifierTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type MockVerifierTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockVerifierFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type MockVerifierFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockVerifierSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type MockVerifierSession struct {
+	Contract     *MockVerifier     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// MockVerifierCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type MockVerifierCallerSession struct {
+	Contract *MockVerifierCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// MockVerifierTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type MockVerifierTransactorSession struct {
+	Contract     *MockVerifierTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// MockVerifierRaw is an auto generated low-level Go binding around an Ethereum contract.
+type MockVerifierRaw struct {
+	Contract *MockVerifier // Generic contract binding to access the raw methods on
+}
+
+// MockVerifierCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type MockVerifierCallerRaw struct {
+	Contract *MockVerifierCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// MockVerifierTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type MockVerifierTransactorRaw struct {
+	Contract *MockVerifierTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewMockVerifier creates a new instance of MockVerifier, bound to a specific deployed contract.
+func NewMockVerifier(address common.Address, backend bind.ContractBackend) (*MockVerifier, error) {
+	contract, err := bindMockVerifier(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &MockVerifier{MockVerifierCaller: MockVerifierCaller{contract: contract}, MockVerifierTransactor: MockVerifierTransactor{contract: contract}, MockVerifierFilterer: MockVerifierFilterer{contract: contract}}, nil
+}
+
+// NewMockVerifierCaller creates a new read-only instance of MockVerifier, bound to a specific deployed contract.
+func NewMockVerifierCaller(address common.Address, caller bind.ContractCaller) (*MockVerifierCaller, error) {
+	contract, err := bindMockVerifier(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockVerifierCaller{contract: contract}, nil
+}
+
+// NewMockVerifierTransactor creates a new write-only instance of MockVerifier, bound to a specific deployed contract.
+func NewMockVerifierTransactor(address common.Address, transactor bind.ContractTransactor) (*MockVerifierTransactor, error) {
+	contract, err := bindMockVerifier(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockVerifierTransactor{contract: contract}, nil
+}
+
+// NewMockVerifierFilterer creates a new log filterer instance of MockVerifier, bound to a specific deployed contract.
+func NewMockVerifierFilterer(address common.Address, filterer bind.ContractFilterer) (*MockVerifierFilterer, error) {
+	contract, err := bindMockVerifier(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &MockVerifierFilterer{contract: contract}, nil
+}
+
+// bindMockVerifier binds a generic wrapper to an already deployed contract.
+func bindMockVerifier(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := MockVerifierMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockVerifier *MockVerifierRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockVerifier.Contract.MockVerifierCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockVerifier *MockVerifierRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockVerifier.Contract.MockVerifierTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockVerifier *MockVerifierRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockVerifier.Contract.MockVerifierTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockVerifier *MockVerifierCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockVerifier.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockVerifier *MockVerifierTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockVerifier.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockVerifier *MockVerifierTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockVerifier.Contract.contract.Transact(opts, method, params...)
+}
+
+// Rejecting is a free data retrieval call binding the contract method 0x81b30d55.
+//
+// Solidity: function rejecting() view returns(bool)
+func (_MockVerifier *MockVerifierCaller) Rejecting(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _MockVerifier.contract.Call(opts, &out, "rejecting")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Rejecting is a free data retrieval call binding the contract method 0x81b30d55.
+//
+// Solidity: function rejecting() view returns(bool)
+func (_MockVerifier *MockVerifierSession) Rejecting() (bool, error) {
+	return _MockVerifier.Contract.Rejecting(&_MockVerifier.CallOpts)
+}
+
+// Rejecting is a free data retrieval call binding the contract method 0x81b30d55.
+//
+// Solidity: function rejecting() view returns(bool)
+func (_MockVerifier *MockVerifierCallerSession) Rejecting() (bool, error) {
+	return _MockVerifier.Contract.Rejecting(&_MockVerifier.CallOpts)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x24270d54.
+//
+// Solidity: function verify(bytes , bytes , bytes32 , bytes32 ) view returns()
+func (_MockVerifier *MockVerifierCaller) Verify(opts *bind.CallOpts, arg0 []byte, arg1 []byte, arg2 [32]byte, arg3 [32]byte) error {
+	var out []interface{}
+	err := _MockVerifier.contract.Call(opts, &out, "verify", arg0, arg1, arg2, arg3)
+
+	if err != nil {
+		return err
+	}
+
+	return err
+
+}
+
+// Verify is a free data retrieval call binding the contract method 0x24270d54.
+//
+// Solidity: function verify(bytes , bytes , bytes32 , bytes32 ) view returns()
+func (_MockVerifier *MockVerifierSession) Verify(arg0 []byte, arg1 []byte, arg2 [32]byte, arg3 [32]byte) error {
+	return _MockVerifier.Contract.Verify(&_MockVerifier.CallOpts, arg0, arg1, arg2, arg3)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x24270d54.
+//
+// Solidity: function verify(bytes , bytes , bytes32 , bytes32 ) view returns()
+func (_MockVerifier *MockVerifierCallerSession) Verify(arg0 []byte, arg1 []byte, arg2 [32]byte, arg3 [32]byte) error {
+	return _MockVerifier.Contract.Verify(&_MockVerifier.CallOpts, arg0, arg1, arg2, arg3)
+}
+
+// SetRejecting is a paid mutator transaction binding the contract method 0x5c3a0c09.
+//
+// Solidity: function setRejecting(bool _rejecting) returns()
+func (_MockVerifier *MockVerifierTransactor) SetRejecting(opts *bind.TransactOpts, _rejecting bool) (*types.Transaction, error) {
+	return _MockVerifier.contract.Transact(opts, "setRejecting", _rejecting)
+}
+
+// SetRejecting is a paid mutator transaction binding the contract method 0x5c3a0c09.
+//
+// Solidity: function setRejecting(bool _rejecting) returns()
+func (_MockVerifier *MockVerifierSession) SetRejecting(_rejecting bool) (*types.Transaction, error) {
+	return _MockVerifier.Contract.SetRejecting(&_MockVerifier.TransactOpts, _rejecting)
+}
+
+// SetRejecting is a paid mutator transaction binding the contract method 0x5c3a0c09.
+//
+// Solidity: function setRejecting(bool _rejecting) returns()
+func (_MockVerifier *MockVerifierTransactorSession) SetRejecting(_rejecting bool) (*types.Transaction, error) {
+	return _MockVerifier.Contract.SetRejecting(&_MockVerifier.TransactOpts, _rejecting)
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+import "../IOpenVmHalo2Verifier.sol";
+
+/**
+ * @title MockVerifier
+ * @notice Accepts every proof unless it is told to reject, for testing JingChouRollup without a prover
+ */
+contract MockVerifier is IOpenVmHalo2Verifier {
+    bool public rejecting;
+
+    function setRejecting(bool _rejecting) external {
+        rejecting = _rejecting;
+    }
+
+    function verify(bytes calldata, bytes calldata, bytes32, bytes32) external view {
+        require(!rejecting, "invalid proof");
+    }
+}
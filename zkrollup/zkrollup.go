@@ -24,6 +24,22 @@ type ZkRollup struct {
 	ethCli    *ethclient.Client
 	prover    prover.Prover
 	proofChan chan *prover.ProofResult
+
+	bridge Bridge
+}
+
+// Bridge 提供证明区块内的跨链数据，由 bridge/eth.EthRelayer 实现
+type Bridge interface {
+	// WithdrawalRoot 返回区块 [from, to] 内 L2→L1 提现的 Merkle 根
+	WithdrawalRoot(from, to common.BlockNum) (ethcommon.Hash, error)
+	// DepositCount 返回到区块 height 为止已经包含的 L1 充值数量
+	DepositCount(height common.BlockNum) (uint64, error)
+}
+
+// SetBridge 设置跨链数据来源，设置后提现根和充值数量会放入 public values，
+// L1 的 JingChouRollup 合约验证证明后据此放行提现，rollup 停止后据此退还未包含的充值
+func (z *ZkRollup) SetBridge(bridge Bridge) {
+	z.bridge = bridge
 }
 
 func NewZkRollup(cfg *config.Config) (*ZkRollup, error) {
@@ -110,7 +126,10 @@ func (z *ZkRollup) SendProofToL1(proofResult *prover.ProofResult) error {
 	// 2. 准备 public values（字节数组）
 	// 这里需要根据实际的证明数据来填充
 	// 示例：可以从 proof 中提取或者根据区块数据构造
-	publicValues := z.extractPublicValues(proofResult)
+	publicValues, err := z.extractPublicValues(proofResult)
+	if err != nil {
+		return fmt.Errorf("failed to extract public values: %w", err)
+	}
 
 	// 3. 准备 appExeCommit 和 appVmCommit
 	var appExeCommit [32]byte
@@ -164,7 +183,7 @@ func (z *ZkRollup) SendProofToL1(proofResult *prover.ProofResult) error {
 
 // extractPublicValues 从证明结果中提取 public values
 // ⚠️ 重要：这个函数必须返回与你的 OpenVM 程序中 reveal_public_values() 输出完全一致的数据
-func (z *ZkRollup) extractPublicValues(proofResult *prover.ProofResult) []byte {
+func (z *ZkRollup) extractPublicValues(proofResult *prover.ProofResult) ([]byte, error) {
 	// TODO: 根据你的 OpenVM Rust 程序实际输出来实现
 	//
 	// 理想情况下，publicValues 应该从 Axiom API 的响应中直接获取
@@ -183,12 +202,16 @@ func (z *ZkRollup) extractPublicValues(proofResult *prover.ProofResult) []byte {
 	//     let to_block = ...;
 	//     let pre_state = ...;
 	//     let new_state = ...;
+	//     let withdrawal_root = ...;
+	//     let deposit_count = ...;
 	//
 	//     reveal_public_values(&[
 	//         from_block.to_le_bytes(),  // 注意：little-endian 还是 big-endian
 	//         to_block.to_le_bytes(),
 	//         pre_state.as_bytes(),
 	//         new_state.as_bytes(),
+	//         withdrawal_root.as_bytes(),
+	//         deposit_count.to_be_bytes(),
 	//     ]);
 	// }
 	// ```
@@ -213,10 +236,26 @@ func (z *ZkRollup) extractPublicValues(proofResult *prover.ProofResult) []byte {
 		// NewStateRoot (32 bytes)
 		publicValues = append(publicValues, proofResult.Proof.NewStateRoot.Bytes()...)
 
-		// 总共 80 bytes
+		// WithdrawalRoot (32 bytes) 和 DepositCount (8 bytes, big-endian)，没有设置跨链数据来源时为 0
+		var withdrawalRoot ethcommon.Hash
+		var depositCount uint64
+		if z.bridge != nil {
+			root, err := z.bridge.WithdrawalRoot(common.BlockNum(proofResult.Proof.FromBlockNum), common.BlockNum(proofResult.Proof.ToBlockNum))
+			if err != nil {
+				return nil, err
+			}
+			withdrawalRoot = root
+			if depositCount, err = z.bridge.DepositCount(common.BlockNum(proofResult.Proof.ToBlockNum)); err != nil {
+				return nil, err
+			}
+		}
+		publicValues = append(publicValues, withdrawalRoot.Bytes()...)
+		publicValues = binary.BigEndian.AppendUint64(publicValues, depositCount)
+
+		// 总共 120 bytes，与 JingChouRollup 合约的 PUBLIC_VALUES_LENGTH 一致
 	}
 
-	return publicValues
+	return publicValues, nil
 }
 
 // calculateAppExeCommit 获取应用执行承诺